@@ -45,6 +45,7 @@ export class GlobalConfig {
     'includeMirrors',
     /** NOTE that this is not a config option, but an internal variable **/
     'localDir',
+    'localPlatformDir',
     'migratePresets',
     'onboarding',
     'onboardingAutoCloseAge',
@@ -54,6 +55,7 @@ export class GlobalConfig {
     'onboardingConfigFileName',
     'onboardingNoDeps',
     'onboardingPrTitle',
+    'osvAdvisorySources',
     'platform',
     'prCacheSyncMaxPages',
     'presetCachePersistence',
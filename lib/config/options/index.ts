@@ -536,6 +536,25 @@ const options: Readonly<RenovateOptions>[] = [
     experimental: true,
     cli: false,
   },
+  {
+    name: 'reportSbomFormat',
+    description:
+      'Set the format of the SBOM which should be written for each repository alongside the report.',
+    globalOnly: true,
+    type: 'string',
+    default: null,
+    experimental: true,
+    allowedValues: ['cyclonedx', 'spdx'],
+  },
+  {
+    name: 'reportSbomPath',
+    description:
+      'Path template to where the SBOM of each repository should be written. In case of `s3` this has to be a full S3 URI.',
+    globalOnly: true,
+    type: 'string',
+    default: null,
+    experimental: true,
+  },
   {
     name: 'force',
     description:
@@ -712,6 +731,14 @@ const options: Readonly<RenovateOptions>[] = [
     default: ['./...'],
     supportedManagers: ['gomod'],
   },
+  {
+    name: 'goModuleGrouping',
+    description:
+      'Group updates of Go modules which are released together from the same repository.',
+    type: 'boolean',
+    default: false,
+    supportedManagers: ['gomod'],
+  },
   // Log options
   {
     name: 'logContext',
@@ -927,6 +954,13 @@ const options: Readonly<RenovateOptions>[] = [
     subType: 'string',
     default: null,
   },
+  {
+    name: 'dependencyDashboardLibYears',
+    description:
+      'Controls whether libyears, and their change since the last run, are reported in the Dependency Dashboard.',
+    type: 'boolean',
+    default: false,
+  },
   {
     name: 'dependencyDashboardOSVVulnerabilitySummary',
     description:
@@ -1027,6 +1061,15 @@ const options: Readonly<RenovateOptions>[] = [
     default: false,
     globalOnly: true,
   },
+  {
+    name: 'localPlatformDir',
+    description:
+      'Directory where the `local` platform writes PR files and its state. If set, Renovate creates branches in the local Git repository.',
+    type: 'string',
+    default: null,
+    globalOnly: true,
+    experimental: true,
+  },
   {
     name: 'exposeAllEnv',
     description:
@@ -1483,6 +1526,17 @@ const options: Readonly<RenovateOptions>[] = [
     cli: false,
     env: false,
   },
+  {
+    name: 'matchUnsupported',
+    description:
+      'Matches dependencies whose current version is out of its support window. Valid only within a `packageRules` object.',
+    type: 'boolean',
+    parents: ['packageRules'],
+    stage: 'package',
+    mergeable: true,
+    cli: false,
+    env: false,
+  },
   {
     name: 'matchCategories',
     description:
@@ -1875,6 +1929,16 @@ const options: Readonly<RenovateOptions>[] = [
     type: 'boolean',
     default: true,
   },
+  {
+    name: 'followBranch',
+    description:
+      'If defined, packages pinned to a commit will follow the head of this branch.',
+    stage: 'package',
+    type: 'string',
+    cli: false,
+    env: false,
+    advancedUse: true,
+  },
   {
     name: 'followTag',
     description: 'If defined, packages will follow this release tag exactly.',
@@ -2160,6 +2224,13 @@ const options: Readonly<RenovateOptions>[] = [
     type: 'string',
     default: null,
   },
+  {
+    name: 'abandonmentSourceActivity',
+    description:
+      'Also check the archived status, commits and issue activity of the source repository when detecting abandoned packages.',
+    type: 'boolean',
+    default: false,
+  },
   {
     name: 'dependencyDashboardReportAbandonment',
     description:
@@ -2401,6 +2472,23 @@ const options: Readonly<RenovateOptions>[] = [
     experimental: true,
     experimentalIssues: [20542],
   },
+  {
+    name: 'osvVulnerabilityReachability',
+    description:
+      'Skip vulnerability fixes for Go advisories whose affected symbols are not used by the code.',
+    type: 'boolean',
+    default: false,
+    experimental: true,
+  },
+  {
+    name: 'osvAdvisorySources',
+    description:
+      'Additional sources of OSV advisories, merged with the bundled `osv-offline` database.',
+    type: 'array',
+    subType: 'string',
+    default: [],
+    globalOnly: true,
+  },
   {
     name: 'pruneBranchAfterAutomerge',
     description: 'Set to `true` to enable branch pruning after automerging.',
@@ -2776,6 +2864,7 @@ const options: Readonly<RenovateOptions>[] = [
       'composerWithAll',
       'composerNoMinimalChanges',
       'dotnetWorkloadRestore',
+      'gomodLockFileMaintenanceMinor',
       'gomodMassage',
       'gomodTidy',
       'gomodTidy1.17',
@@ -2784,7 +2873,9 @@ const options: Readonly<RenovateOptions>[] = [
       'gomodUpdateImportPaths',
       'gomodSkipVendor',
       'gomodVendor',
+      'gomodVerifySumdb',
       'goGenerate',
+      'goToolchainSync',
       'helmUpdateSubChartArchives',
       'kustomizeInflateHelmCharts',
       'npmDedupe',
@@ -89,6 +89,29 @@ export const presets: Record<string, Preset> = {
     description:
       'Update `_VERSION` environment variables in GitLab pipeline file.',
   },
+  golangDockerfileChecksums: {
+    customManagers: [
+      {
+        autoReplaceStringTemplate:
+          '# renovate: golang-version archive={{{depType}}}\nARG GO_VERSION={{{newValue}}}\nARG GO_SHA256={{{lookup checksums depType}}}\n',
+        customType: 'regex',
+        datasourceTemplate: 'golang-version',
+        depNameTemplate: 'go',
+        managerFilePatterns: [
+          '**/[Dd]ockerfile*',
+          '**/[Cc]ontainerfile*',
+          '**/*.[Dd]ockerfile*',
+          '**/*.[Cc]ontainerfile*',
+        ],
+        matchStrings: [
+          '# renovate: golang-version archive=(?<depType>[^\\s]+)\\nARG GO_VERSION=(?<currentValue>[^\\s]+)\\nARG GO_SHA256=[a-f0-9]{64}\\n',
+        ],
+        registryUrlTemplate: 'https://go.dev/dl',
+      },
+    ],
+    description:
+      'Update the `GO_VERSION` and `GO_SHA256` build arguments of a Go archive in Dockerfiles.',
+  },
   helmChartYamlAppVersions: {
     customManagers: [
       {
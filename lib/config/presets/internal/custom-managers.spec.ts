@@ -1,6 +1,7 @@
 import { codeBlock } from 'common-tags';
 import { extractPackageFile } from '../../../modules/manager/index.ts';
 import { matchRegexOrGlobList } from '../../../util/string-match.ts';
+import * as template from '../../../util/template/index.ts';
 import { presets } from './custom-managers.preset.ts';
 
 describe('config/presets/internal/custom-managers', () => {
@@ -629,6 +630,62 @@ describe('config/presets/internal/custom-managers', () => {
     });
   });
 
+  describe('Update `GO_VERSION` and `GO_SHA256` in Dockerfiles', () => {
+    const customManager = presets.golangDockerfileChecksums.customManagers?.[0];
+
+    const fileContent = codeBlock`
+      FROM debian:bookworm
+
+      # renovate: golang-version archive=linux-amd64.tar.gz
+      ARG GO_VERSION=1.22.0
+      ARG GO_SHA256=f6c8a87aa03b92c4b0bf3d558e28ea03006eb29db78917daec5cfb6ec1046265
+
+      RUN curl -fsSL https://go.dev/dl/go\${GO_VERSION}.linux-amd64.tar.gz -o go.tgz
+    `;
+
+    it(`find dependencies in file`, async () => {
+      const res = await extractPackageFile(
+        'regex',
+        fileContent,
+        'Dockerfile',
+        customManager!,
+      );
+
+      expect(res?.deps).toMatchObject([
+        {
+          currentValue: '1.22.0',
+          datasource: 'golang-version',
+          depName: 'go',
+          depType: 'linux-amd64.tar.gz',
+          registryUrls: ['https://go.dev/dl'],
+          replaceString:
+            '# renovate: golang-version archive=linux-amd64.tar.gz\nARG GO_VERSION=1.22.0\nARG GO_SHA256=f6c8a87aa03b92c4b0bf3d558e28ea03006eb29db78917daec5cfb6ec1046265\n',
+        },
+      ]);
+    });
+
+    it('replaces the checksum of the archive', () => {
+      const res = template.compile(
+        customManager!.autoReplaceStringTemplate!,
+        {
+          depType: 'linux-amd64.tar.gz',
+          newValue: '1.22.1',
+          checksums: {
+            'linux-amd64.tar.gz':
+              'aab8e15785c997ae20f9c88422ee35d962c4562212bb0f879d052a35c8307c7f',
+            'linux-arm64.tar.gz':
+              'e56685a245b6a0c592fc4a55f0b7803af5b3f827aaa29feab1f40e491acf35b8',
+          },
+        },
+        false,
+      );
+
+      expect(res).toBe(
+        '# renovate: golang-version archive=linux-amd64.tar.gz\nARG GO_VERSION=1.22.1\nARG GO_SHA256=aab8e15785c997ae20f9c88422ee35d962c4562212bb0f879d052a35c8307c7f\n',
+      );
+    });
+  });
+
   describe('Update `appVersion` value in Helm chart Chart.yaml', () => {
     const customManager = presets.helmChartYamlAppVersions.customManagers?.[0];
 
@@ -70,6 +70,7 @@ export type BinarySource = 'docker' | 'global' | 'install' | 'hermit';
 export interface RenovateSharedConfig {
   $schema?: string;
   abandonmentThreshold?: Nullish<string>;
+  abandonmentSourceActivity?: boolean;
   addLabels?: string[];
   assignAutomerge?: boolean;
   autoApprove?: boolean;
@@ -105,6 +106,7 @@ export interface RenovateSharedConfig {
   extends?: string[];
   extractVersion?: string;
   managerFilePatterns?: string[];
+  followBranch?: string;
   followTag?: string;
   force?: RenovateConfig;
   gitIgnoredAuthors?: string[];
@@ -260,6 +262,7 @@ export interface RepoGlobalConfig extends GlobalInheritableConfig {
   gitTimeout?: number;
   githubTokenWarn?: boolean;
   includeMirrors?: boolean;
+  localPlatformDir?: string;
   migratePresets?: Record<string, string>;
   platform?: PlatformId;
   prCacheSyncMaxPages?: number;
@@ -277,6 +280,7 @@ export interface RepoGlobalConfig extends GlobalInheritableConfig {
   ignorePrAuthor?: boolean;
   allowedUnsafeExecutions?: AllowedUnsafeExecution[];
   onboardingAutoCloseAge?: number;
+  osvAdvisorySources?: string[];
   productLinks?: Record<string, string>;
   rebaseAllOpenBranches?: boolean;
   toolSettings?: GlobalToolSettingsOptions;
@@ -392,6 +396,8 @@ export interface RenovateConfig
   s3PathStyle?: boolean;
   reportFormatting?: boolean;
   reportPath?: string;
+  reportSbomFormat?: 'cyclonedx' | 'spdx' | null;
+  reportSbomPath?: string;
   reportType?: 'logging' | 'file' | 's3' | null;
   depName?: string;
   /** user configurable base branch patterns*/
@@ -430,6 +436,7 @@ export interface RenovateConfig
   dependencyDashboardHeader?: string;
   dependencyDashboardFooter?: string;
   dependencyDashboardLabels?: string[];
+  dependencyDashboardLibYears?: boolean;
   dependencyDashboardOSVVulnerabilitySummary?: 'none' | 'all' | 'unresolved';
   dependencyDashboardReportAbandonment?: boolean;
   mode?: 'silent' | 'full';
@@ -466,6 +473,7 @@ export interface RenovateConfig
   warnings?: ValidationMessage[];
   vulnerabilityAlerts?: RenovateSharedConfig;
   osvVulnerabilityAlerts?: boolean;
+  osvVulnerabilityReachability?: boolean;
   vulnerabilitySeverity?: string;
   customManagers?: CustomManager[];
   customDatasources?: Record<string, CustomDatasourceConfig>;
@@ -496,6 +504,8 @@ export interface RenovateConfig
   branchTopic?: string;
   additionalBranchPrefix?: string;
   sharedVariableName?: string;
+  sourceGroupName?: string;
+  goModuleGrouping?: boolean;
   minimumGroupSize?: number;
   configFileNames?: string[];
   minimumReleaseAgeBehaviour?: MinimumReleaseAgeBehaviour;
@@ -612,6 +622,7 @@ export interface PackageRule
   matchSourceUrls?: string[];
   matchRegistryUrls?: string[];
   matchUpdateTypes?: UpdateType[];
+  matchUnsupported?: boolean;
   matchJsonata?: string[];
   overrideDatasource?: string;
   overrideDepName?: string;
@@ -805,6 +816,7 @@ export interface PackageRuleInputConfig extends RenovateConfig {
   repository?: string;
   currentVersionAgeInDays?: number;
   currentVersionTimestamp?: string;
+  isUnsupported?: boolean;
   enabled?: boolean;
   skipReason?: SkipReason;
   skipStage?: StageName;
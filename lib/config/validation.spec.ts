@@ -3402,6 +3402,77 @@ describe('config/validation', () => {
       expect(errors).toBeEmptyArray();
     });
 
+    it('fails for missing reportSbomPath if reportSbomFormat is set', async () => {
+      const config: RenovateConfig = {
+        reportType: 'file',
+        reportPath: './report.json',
+        reportSbomFormat: 'cyclonedx',
+      };
+      const { warnings, errors } = await configValidation.validateConfig(
+        'global',
+        config,
+      );
+      expect(warnings).toBeEmptyArray();
+      expect(errors).toMatchObject([
+        {
+          message:
+            "reportSbomFormat 'cyclonedx' requires a configured reportSbomPath",
+        },
+      ]);
+    });
+
+    it('validates reportSbomPath if reportSbomFormat is set', async () => {
+      const config: RenovateConfig = {
+        reportType: 'file',
+        reportPath: './report.json',
+        reportSbomFormat: 'spdx',
+        reportSbomPath: './sboms/{{repository}}.spdx.json',
+      };
+      const { warnings, errors } = await configValidation.validateConfig(
+        'global',
+        config,
+      );
+      expect(warnings).toBeEmptyArray();
+      expect(errors).toBeEmptyArray();
+    });
+
+    it('fails for a reportSbomPath without repository for several repositories', async () => {
+      const config: RenovateConfig = {
+        reportType: 'file',
+        reportPath: './report.json',
+        reportSbomFormat: 'spdx',
+        reportSbomPath: './sbom.spdx.json',
+        repositories: ['org/a', 'org/b'],
+      };
+      const { warnings, errors } = await configValidation.validateConfig(
+        'global',
+        config,
+      );
+      expect(warnings).toBeEmptyArray();
+      expect(errors).toMatchObject([
+        {
+          message:
+            'reportSbomPath must contain {{repository}} when more than one repository is processed, otherwise the SBOMs overwrite each other',
+        },
+      ]);
+    });
+
+    it('allows a reportSbomPath without repository for a single repository', async () => {
+      const config: RenovateConfig = {
+        reportType: 'file',
+        reportPath: './report.json',
+        reportSbomFormat: 'spdx',
+        reportSbomPath: './sbom.spdx.json',
+        repositories: ['org/a'],
+      };
+      const { warnings, errors } = await configValidation.validateConfig(
+        'global',
+        config,
+      );
+      expect(warnings).toBeEmptyArray();
+      expect(errors).toBeEmptyArray();
+    });
+
     it('warns when registryUrls is set at the top level of global config', async () => {
       const config = {
         registryUrls: ['https://registry.npmjs.org'],
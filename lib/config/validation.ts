@@ -108,6 +108,7 @@ const ignoredNodes = [
   'minimumConfidence', // undocumented feature flag
 ];
 const tzRe = regEx(/^:timezone\((.+)\)$/);
+const sbomPathRepositoryRegex = regEx(/\{\{\{?\s*repository\s*\}?\}\}/);
 const rulesRe = regEx(/p.*Rules\[\d+\]$/);
 const repoEntryRe = regEx(/^repositories\[\d+\]$/);
 
@@ -537,6 +538,7 @@ export async function validateConfig(
                   'matchUpdateTypes',
                   'matchConfidence',
                   'matchCurrentAge',
+                  'matchUnsupported',
                   'matchRepositories',
                   'matchNewValue',
                   'matchJsonata',
@@ -602,6 +604,7 @@ export async function validateConfig(
                       const preLookupOptions = [
                         'allowedVersions',
                         'extractVersion',
+                        'followBranch',
                         'followTag',
                         'ignoreDeps',
                         'ignoreUnstable',
@@ -1178,6 +1181,26 @@ async function validateGlobalConfig(
             message: `reportType '${val}' requires a configured reportPath`,
           });
         }
+
+        if (key === 'reportSbomFormat' && !isString(config.reportSbomPath)) {
+          errors.push({
+            topic: ConfigValidationTopic.Error,
+            message: `reportSbomFormat '${val}' requires a configured reportSbomPath`,
+          });
+        }
+
+        if (
+          key === 'reportSbomPath' &&
+          !sbomPathRepositoryRegex.test(val) &&
+          (config.autodiscover === true ||
+            (isArray(config.repositories) && config.repositories.length > 1))
+        ) {
+          errors.push({
+            topic: ConfigValidationTopic.Error,
+            message:
+              'reportSbomPath must contain {{repository}} when more than one repository is processed, otherwise the SBOMs overwrite each other',
+          });
+        }
       } else {
         warnings.push({
           topic: ConfigValidationTopic.Error,
@@ -7,16 +7,20 @@ import {
 } from '@opentelemetry/sdk-trace-node';
 import { type DirectoryResult, dir } from 'tmp-promise';
 import upath from 'upath';
+import * as httpMock from '~test/http-mock.ts';
 import { bunyan } from '../expose.ts';
 import { GetDatasourceReleasesSpanProcessor } from '../modules/datasource/span-processor.ts';
 import { GitOperationSpanProcessor } from '../util/git/span-processor.ts';
 import { FileSpanExporter } from './file-exporter.ts';
 import {
   disableInstrumentations,
+  getMeterProvider,
   getTracerProvider,
   init,
   instrument,
+  shutdown,
 } from './index.ts';
+import { recordLibYears } from './metrics.ts';
 
 afterAll(disableInstrumentations);
 
@@ -28,6 +32,7 @@ describe('instrumentation/index', () => {
     tmpDir = await dir({ unsafeCleanup: true });
 
     api.trace.disable(); // clear global components
+    api.metrics.disable();
     process.env = { ...oldEnv };
 
     // remove any otel env
@@ -194,6 +199,55 @@ describe('instrumentation/index', () => {
     });
   });
 
+  it('does not register a meter provider without remote logger', () => {
+    process.env.RENOVATE_TRACING_CONSOLE_EXPORTER = 'true';
+
+    init();
+
+    expect(getMeterProvider()).toBeUndefined();
+  });
+
+  it('sends libyears to the OTLP endpoint', async () => {
+    process.env.OTEL_EXPORTER_OTLP_ENDPOINT = 'https://collector.example.com';
+    let request: any;
+    httpMock
+      .scope('https://collector.example.com')
+      .post('/v1/metrics', (body) => {
+        request = body;
+        return true;
+      })
+      .reply(200);
+
+    init();
+    expect(api.metrics.getMeterProvider()).toBe(getMeterProvider());
+    recordLibYears('some/repo', 'main', {
+      libYears: { managers: {}, packageFiles: {}, depTypes: {}, total: 1.5 },
+      dependencyStatus: { outdated: 1, total: 2 },
+    });
+    await shutdown();
+
+    const metrics = request.resourceMetrics[0].scopeMetrics[0].metrics;
+    expect(metrics).toContainEqual(
+      expect.objectContaining({
+        name: 'renovate.libyears',
+        unit: '{libyear}',
+        gauge: {
+          dataPoints: [
+            expect.objectContaining({
+              asDouble: 1.5,
+              attributes: expect.arrayContaining([
+                {
+                  key: 'renovate.repository',
+                  value: { stringValue: 'some/repo' },
+                },
+              ]),
+            }),
+          ],
+        },
+      }),
+    );
+  });
+
   describe('BunyanInstrumentation', () => {
     // OpenTelemetry's context propagation currently uses `AsyncLocalStorage`, which does not behave the same way in vitest worker threads as in a real Node.js process, so we cannot write a full end-to-end here to validate the `span_id`, `trace_id` and `trace_flags` are set
     //
@@ -3,6 +3,7 @@ import type { Context, Span, Tracer, TracerProvider } from '@opentelemetry/api';
 import * as api from '@opentelemetry/api';
 import { ProxyTracerProvider, SpanStatusCode } from '@opentelemetry/api';
 import { AsyncLocalStorageContextManager } from '@opentelemetry/context-async-hooks';
+import { OTLPMetricExporter } from '@opentelemetry/exporter-metrics-otlp-http';
 import { OTLPTraceExporter } from '@opentelemetry/exporter-trace-otlp-http';
 import type { Instrumentation } from '@opentelemetry/instrumentation';
 import { registerInstrumentations } from '@opentelemetry/instrumentation';
@@ -13,6 +14,10 @@ import {
   detectResources,
   resourceFromAttributes,
 } from '@opentelemetry/resources';
+import {
+  MeterProvider,
+  PeriodicExportingMetricReader,
+} from '@opentelemetry/sdk-metrics';
 import {
   BatchSpanProcessor,
   ConsoleSpanExporter,
@@ -41,6 +46,7 @@ import {
 } from './utils.ts';
 
 let instrumentations: Instrumentation[] = [];
+let meterProvider: MeterProvider | undefined;
 
 export function init(): void {
   const spanProcessors: SpanProcessor[] = [
@@ -97,8 +103,9 @@ export function init(): void {
     detectors: getResourceDetectors(env),
   });
 
+  const resource = baseResource.merge(detectedResource);
   const traceProvider = new NodeTracerProvider({
-    resource: baseResource.merge(detectedResource),
+    resource,
     spanProcessors,
   });
 
@@ -107,6 +114,19 @@ export function init(): void {
     contextManager,
   });
 
+  // metrics such as libyears are only sent to the same OTLP endpoint as traces
+  if (isTraceSendingEnabled()) {
+    meterProvider = new MeterProvider({
+      resource,
+      readers: [
+        new PeriodicExportingMetricReader({
+          exporter: new OTLPMetricExporter(),
+        }),
+      ],
+    });
+    api.metrics.setGlobalMeterProvider(meterProvider);
+  }
+
   instrumentations = [
     new HttpInstrumentation({
       /* v8 ignore start -- not easily testable */
@@ -146,6 +166,10 @@ export function init(): void {
 // https://github.com/open-telemetry/opentelemetry-js-api/issues/34
 /* v8 ignore next -- not easily testable */
 export async function shutdown(): Promise<void> {
+  // exports the metrics recorded since the last periodic export
+  await meterProvider?.shutdown();
+  meterProvider = undefined;
+
   const traceProvider = getTracerProvider();
   if (traceProvider instanceof NodeTracerProvider) {
     await traceProvider.shutdown();
@@ -163,6 +187,10 @@ export function disableInstrumentations(): void {
   }
 }
 
+export function getMeterProvider(): MeterProvider | undefined {
+  return meterProvider;
+}
+
 export function getTracerProvider(): TracerProvider {
   return api.trace.getTracerProvider();
 }
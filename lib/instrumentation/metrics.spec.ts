@@ -0,0 +1,98 @@
+import type { Meter } from '@opentelemetry/api';
+import * as api from '@opentelemetry/api';
+import { partial } from '~test/util.ts';
+import { recordLibYears } from './metrics.ts';
+
+describe('instrumentation/metrics', () => {
+  const recorded: Record<string, [number, api.Attributes | undefined][]> = {};
+
+  beforeEach(() => {
+    for (const name of Object.keys(recorded)) {
+      delete recorded[name];
+    }
+    vi.spyOn(api.metrics, 'getMeter').mockReturnValue(
+      partial<Meter>({
+        createGauge: (name: string) =>
+          partial<api.Gauge>({
+            record: (value, attributes) => {
+              recorded[name] ??= [];
+              recorded[name].push([value, attributes]);
+            },
+          }),
+      }),
+    );
+  });
+
+  it('records libyears', () => {
+    recordLibYears('some/repo', 'main', {
+      libYears: {
+        managers: { gomod: 1.5, npm: 0 },
+        packageFiles: { gomod: { 'go.mod': 1.5 }, npm: { 'package.json': 0 } },
+        depTypes: { gomod: { indirect: 0.5, require: 1 } },
+        total: 1.5,
+      },
+      dependencyStatus: { outdated: 2, total: 5 },
+    });
+
+    const repo = {
+      'renovate.repository': 'some/repo',
+      'renovate.baseBranch': 'main',
+    };
+    expect(recorded).toEqual({
+      'renovate.libyears': [[1.5, repo]],
+      'renovate.libyears.manager': [
+        [1.5, { ...repo, 'renovate.manager': 'gomod' }],
+        [0, { ...repo, 'renovate.manager': 'npm' }],
+      ],
+      'renovate.libyears.package_file': [
+        [
+          1.5,
+          {
+            ...repo,
+            'renovate.manager': 'gomod',
+            'renovate.packageFile': 'go.mod',
+          },
+        ],
+        [
+          0,
+          {
+            ...repo,
+            'renovate.manager': 'npm',
+            'renovate.packageFile': 'package.json',
+          },
+        ],
+      ],
+      'renovate.libyears.dep_type': [
+        [
+          0.5,
+          {
+            ...repo,
+            'renovate.manager': 'gomod',
+            'renovate.depType': 'indirect',
+          },
+        ],
+        [
+          1,
+          {
+            ...repo,
+            'renovate.manager': 'gomod',
+            'renovate.depType': 'require',
+          },
+        ],
+      ],
+      'renovate.dependencies': [[5, repo]],
+      'renovate.dependencies.outdated': [[2, repo]],
+    });
+  });
+
+  it('records libyears without base branch', () => {
+    recordLibYears('some/repo', undefined, {
+      libYears: { managers: {}, packageFiles: {}, depTypes: {}, total: 0 },
+      dependencyStatus: { outdated: 0, total: 0 },
+    });
+
+    expect(recorded['renovate.libyears']).toEqual([
+      [0, { 'renovate.repository': 'some/repo' }],
+    ]);
+  });
+});
@@ -0,0 +1,93 @@
+import type { Attributes, Meter } from '@opentelemetry/api';
+import * as api from '@opentelemetry/api';
+import type { LibYearsWithStatus } from './types.ts';
+import {
+  ATTR_RENOVATE_BASE_BRANCH,
+  ATTR_RENOVATE_DEP_TYPE,
+  ATTR_RENOVATE_MANAGER,
+  ATTR_RENOVATE_PACKAGE_FILE,
+  ATTR_RENOVATE_REPOSITORY,
+} from './types.ts';
+
+function getMeter(): Meter {
+  return api.metrics.getMeter('renovate');
+}
+
+/**
+ * Records the libyears of a repository as gauges on the global meter provider.
+ * Each breakdown is a separate metric, so that their values can be summed.
+ */
+export function recordLibYears(
+  repository: string,
+  baseBranch: string | undefined,
+  { libYears, dependencyStatus }: LibYearsWithStatus,
+): void {
+  const meter = getMeter();
+  const unit = '{libyear}';
+  const attributes: Attributes = {
+    [ATTR_RENOVATE_REPOSITORY]: repository,
+  };
+  if (baseBranch) {
+    attributes[ATTR_RENOVATE_BASE_BRANCH] = baseBranch;
+  }
+
+  meter
+    .createGauge('renovate.libyears', {
+      description: 'Libyears of all dependencies in the repository',
+      unit,
+    })
+    .record(libYears.total, attributes);
+
+  const managerGauge = meter.createGauge('renovate.libyears.manager', {
+    description: 'Libyears of the dependencies per manager',
+    unit,
+  });
+  for (const [manager, value] of Object.entries(libYears.managers)) {
+    managerGauge.record(value, {
+      ...attributes,
+      [ATTR_RENOVATE_MANAGER]: manager,
+    });
+  }
+
+  const packageFileGauge = meter.createGauge('renovate.libyears.package_file', {
+    description: 'Libyears of the dependencies per package file',
+    unit,
+  });
+  for (const [manager, packageFiles] of Object.entries(libYears.packageFiles)) {
+    for (const [packageFile, value] of Object.entries(packageFiles)) {
+      packageFileGauge.record(value, {
+        ...attributes,
+        [ATTR_RENOVATE_MANAGER]: manager,
+        [ATTR_RENOVATE_PACKAGE_FILE]: packageFile,
+      });
+    }
+  }
+
+  const depTypeGauge = meter.createGauge('renovate.libyears.dep_type', {
+    description: 'Libyears of the dependencies per dependency type',
+    unit,
+  });
+  for (const [manager, depTypes] of Object.entries(libYears.depTypes)) {
+    for (const [depType, value] of Object.entries(depTypes)) {
+      depTypeGauge.record(value, {
+        ...attributes,
+        [ATTR_RENOVATE_MANAGER]: manager,
+        [ATTR_RENOVATE_DEP_TYPE]: depType,
+      });
+    }
+  }
+
+  meter
+    .createGauge('renovate.dependencies', {
+      description: 'Number of distinct dependencies in the repository',
+      unit: '{dependency}',
+    })
+    .record(dependencyStatus.total, attributes);
+
+  meter
+    .createGauge('renovate.dependencies.outdated', {
+      description: 'Number of distinct outdated dependencies in the repository',
+      unit: '{dependency}',
+    })
+    .record(dependencyStatus.outdated, attributes);
+}
@@ -80,7 +80,7 @@ describe('instrumentation/reporting', () => {
       packageFiles: {},
     });
     addLibYears(config, {
-      libYears: { managers: {}, total: 0 },
+      libYears: { managers: {}, packageFiles: {}, depTypes: {}, total: 0 },
       dependencyStatus: { outdated: 0, total: 0 },
     });
 
@@ -196,6 +196,120 @@ describe('instrumentation/reporting', () => {
     );
   });
 
+  it('write SBOM next to the report if reportSbomFormat is set', async () => {
+    const config: RenovateConfig = {
+      repository: 'myOrg/myRepo',
+      reportType: 'file',
+      reportPath: './report.json',
+      reportSbomFormat: 'cyclonedx',
+      reportSbomPath: './sboms/{{repository}}.cdx.json',
+    };
+
+    addExtractionStats(config, { branchList: [], branches: [], packageFiles });
+
+    await exportStats(config);
+
+    expect(fs.writeSystemFile).toHaveBeenCalledTimes(2);
+    const [, [path, content]] = fs.writeSystemFile.mock.calls;
+    expect(path).toBe('./sboms/myOrg/myRepo.cdx.json');
+    expect(JSON.parse(content as string)).toMatchObject({
+      bomFormat: 'CycloneDX',
+      specVersion: '1.5',
+      metadata: { component: { name: 'myOrg/myRepo' } },
+    });
+  });
+
+  it('does not overwrite SBOMs of other repositories', async () => {
+    const config: RenovateConfig = {
+      reportType: 'file',
+      reportPath: './report.json',
+      reportSbomFormat: 'spdx',
+      reportSbomPath: './sbom.spdx.json',
+    };
+
+    for (const repository of ['myOrg/a', 'myOrg/b']) {
+      addExtractionStats(
+        { ...config, repository },
+        { branchList: [], branches: [], packageFiles },
+      );
+    }
+
+    await exportStats(config);
+
+    expect(fs.writeSystemFile).toHaveBeenCalledTimes(2);
+    expect(fs.writeSystemFile).toHaveBeenLastCalledWith(
+      './sbom.spdx.json',
+      expect.stringContaining('myOrg/a'),
+    );
+    expect(logger.logger.warn).toHaveBeenCalledWith(
+      { path: './sbom.spdx.json', repository: 'myOrg/b' },
+      'SBOM path is not unique, use {{repository}} in reportSbomPath',
+    );
+  });
+
+  it('writes the SBOMs of other repositories if one fails', async () => {
+    const config: RenovateConfig = {
+      reportType: 'file',
+      reportPath: './report.json',
+      reportSbomFormat: 'cyclonedx',
+      reportSbomPath: './sboms/{{repository}}.cdx.json',
+    };
+
+    for (const repository of ['myOrg/a', 'myOrg/b']) {
+      addExtractionStats(
+        { ...config, repository },
+        { branchList: [], branches: [], packageFiles },
+      );
+    }
+    fs.writeSystemFile
+      .mockResolvedValueOnce()
+      .mockRejectedValueOnce(new Error('EACCES'));
+
+    await exportStats(config);
+
+    expect(fs.writeSystemFile).toHaveBeenCalledTimes(3);
+    expect(fs.writeSystemFile).toHaveBeenLastCalledWith(
+      './sboms/myOrg/b.cdx.json',
+      expect.any(String),
+    );
+    expect(logger.logger.warn).toHaveBeenCalledWith(
+      {
+        err: new Error('EACCES'),
+        path: './sboms/myOrg/a.cdx.json',
+        repository: 'myOrg/a',
+      },
+      'Failed to write SBOM',
+    );
+  });
+
+  it('send SBOM to an S3 bucket if reportType is s3', async () => {
+    const mockClient = mock<S3Client>();
+    s3.parseS3Url.mockReturnValue({ Bucket: 'bucket-name', Key: 'key-name' });
+    s3.getS3Client.mockReturnValue(mockClient);
+
+    const config: RenovateConfig = {
+      repository: 'myOrg/myRepo',
+      reportType: 's3',
+      reportPath: 's3://bucket-name/key-name',
+      reportSbomFormat: 'spdx',
+      reportSbomPath: 's3://bucket-name/sboms/{{repository}}.spdx.json',
+    };
+
+    addExtractionStats(config, { branchList: [], branches: [], packageFiles });
+
+    await exportStats(config);
+
+    expect(s3.parseS3Url).toHaveBeenLastCalledWith(
+      's3://bucket-name/sboms/myOrg/myRepo.spdx.json',
+    );
+    expect(mockClient.send).toHaveBeenCalledTimes(2);
+    const body = mockClient.send.mock.calls[1][0].input as { Body: string };
+    expect(JSON.parse(body.Body)).toMatchObject({
+      spdxVersion: 'SPDX-2.3',
+      name: 'myOrg/myRepo',
+    });
+  });
+
   it('catch exception', async () => {
     const config: RenovateConfig = {
       repository: 'myOrg/myRepo',
@@ -277,7 +391,12 @@ describe('instrumentation/reporting', () => {
     addBranchStats(config, branchInformation);
     addExtractionStats(config, { branchList: [], branches: [], packageFiles });
     addLibYears(config, {
-      libYears: { managers: { npm: 1 }, total: 1 },
+      libYears: {
+        managers: { npm: 1 },
+        packageFiles: { npm: { 'package.json': 1 } },
+        depTypes: { npm: { dependencies: 1 } },
+        total: 1,
+      },
       dependencyStatus: { outdated: 1, total: 1 },
     });
 
@@ -293,6 +412,12 @@ describe('instrumentation/reporting', () => {
               managers: {
                 npm: 1,
               },
+              packageFiles: {
+                npm: { 'package.json': 1 },
+              },
+              depTypes: {
+                npm: { dependencies: 1 },
+              },
               total: 1,
             },
             dependencyStatus: {
@@ -7,7 +7,9 @@ import { getProblems, logger } from '../logger/index.ts';
 import type { BranchCache } from '../util/cache/repository/types.ts';
 import { writeSystemFile } from '../util/fs/index.ts';
 import { getS3Client, parseS3Url } from '../util/s3.ts';
+import * as template from '../util/template/index.ts';
 import type { ExtractResult } from '../workers/repository/process/extract-update.ts';
+import { getSbom } from './sbom.ts';
 import type { LibYearsWithStatus, Report } from './types.ts';
 
 const report: Report = {
@@ -78,14 +80,74 @@ export function finalizeReport(): void {
   }
 }
 
-async function getReportBody(config: RenovateConfig): Promise<string> {
-  const json = JSON.stringify(report);
+async function getJsonBody(
+  config: RenovateConfig,
+  value: unknown,
+): Promise<string> {
+  const json = JSON.stringify(value);
   if (!config.reportFormatting) {
     return json;
   }
   return prettier().format(json, { parser: 'json' });
 }
 
+async function writeS3Object(
+  config: RenovateConfig,
+  path: string,
+  body: string,
+): Promise<void> {
+  const s3Url = parseS3Url(path);
+  if (isNullOrUndefined(s3Url)) {
+    logger.warn({ reportPath: path }, 'Failed to parse s3 URL');
+    return;
+  }
+
+  const s3Params: PutObjectCommandInput = {
+    Bucket: s3Url.Bucket,
+    Key: s3Url.Key,
+    Body: body,
+    ContentType: 'application/json',
+  };
+
+  const client = getS3Client(config.s3Endpoint, config.s3PathStyle);
+  const command = new PutObjectCommand(s3Params);
+  await client.send(command);
+}
+
+async function exportSboms(config: RenovateConfig): Promise<void> {
+  if (!config.reportSbomFormat || !config.reportSbomPath) {
+    return;
+  }
+
+  const paths = new Set<string>();
+  for (const [repository, { packageFiles }] of Object.entries(
+    report.repositories,
+  )) {
+    const path = template.compile(config.reportSbomPath, { repository });
+    if (paths.has(path)) {
+      logger.warn(
+        { path, repository },
+        'SBOM path is not unique, use {{repository}} in reportSbomPath',
+      );
+      continue;
+    }
+    paths.add(path);
+
+    try {
+      const sbom = getSbom(config.reportSbomFormat, repository, packageFiles);
+      const body = await getJsonBody(config, sbom);
+      if (config.reportType === 's3') {
+        await writeS3Object(config, path, body);
+      } else {
+        await writeSystemFile(path, body);
+      }
+      logger.debug({ path, repository }, 'Writing SBOM');
+    } catch (err) {
+      logger.warn({ err, path, repository }, 'Failed to write SBOM');
+    }
+  }
+}
+
 export async function exportStats(config: RenovateConfig): Promise<void> {
   try {
     if (isNullOrUndefined(config.reportType)) {
@@ -99,32 +161,20 @@ export async function exportStats(config: RenovateConfig): Promise<void> {
 
     if (config.reportType === 'file') {
       const path = config.reportPath!;
-      await writeSystemFile(path, await getReportBody(config));
+      await writeSystemFile(path, await getJsonBody(config, report));
       logger.debug({ path }, 'Writing report');
+      await exportSboms(config);
       return;
     }
 
     // v8 ignore else -- TODO: add test #40625
     if (config.reportType === 's3') {
-      const s3Url = parseS3Url(config.reportPath!);
-      if (isNullOrUndefined(s3Url)) {
-        logger.warn(
-          { reportPath: config.reportPath },
-          'Failed to parse s3 URL',
-        );
-        return;
-      }
-
-      const s3Params: PutObjectCommandInput = {
-        Bucket: s3Url.Bucket,
-        Key: s3Url.Key,
-        Body: await getReportBody(config),
-        ContentType: 'application/json',
-      };
-
-      const client = getS3Client(config.s3Endpoint, config.s3PathStyle);
-      const command = new PutObjectCommand(s3Params);
-      await client.send(command);
+      await writeS3Object(
+        config,
+        config.reportPath!,
+        await getJsonBody(config, report),
+      );
+      await exportSboms(config);
     }
   } catch (err) {
     logger.warn({ err }, 'Reporting.exportStats() - failure');
@@ -0,0 +1,220 @@
+import type { PackageFile } from '../modules/manager/types.ts';
+import {
+  getCycloneDxSbom,
+  getPurl,
+  getSbomDependencies,
+  getSpdxSbom,
+} from './sbom.ts';
+
+const digest =
+  'sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef';
+
+const packageFiles: Record<string, PackageFile[]> = {
+  gomod: [
+    {
+      packageFile: 'go.mod',
+      deps: [
+        {
+          depName: 'go',
+          depType: 'golang',
+          datasource: 'golang-version',
+          currentValue: '1.22',
+          currentVersion: '1.22.0',
+        },
+        {
+          depName: 'github.com/foo/bar',
+          depType: 'require',
+          datasource: 'go',
+          currentValue: 'v1.2.3',
+          currentVersion: 'v1.2.3',
+        },
+        {
+          depName: 'golang.org/x/text',
+          depType: 'indirect',
+          datasource: 'go',
+          currentValue: 'v0.14.0',
+          currentVersion: 'v0.14.0',
+        },
+      ],
+    },
+  ],
+  dockerfile: [
+    {
+      packageFile: 'Dockerfile',
+      deps: [
+        {
+          depName: 'node',
+          datasource: 'docker',
+          currentValue: '20',
+          currentVersion: '20',
+          currentDigest: digest,
+        },
+        {
+          depName: 'node',
+          datasource: 'docker',
+          currentValue: '20',
+          currentVersion: '20',
+        },
+        { datasource: 'docker', currentValue: 'unnamed' },
+      ],
+    },
+  ],
+};
+
+describe('instrumentation/sbom', () => {
+  describe('getPurl()', () => {
+    it.each`
+      dep                                                                                 | expected
+      ${{ depName: 'github.com/foo/bar', datasource: 'go', currentVersion: 'v1.2.3' }}    | ${'pkg:golang/github.com/foo/bar@v1.2.3'}
+      ${{ depName: '@scope/pkg', datasource: 'npm', lockedVersion: '1.0.0' }}             | ${'pkg:npm/%40scope/pkg@1.0.0'}
+      ${{ depName: '@scope/pkg', datasource: 'npm', currentValue: '^1.0.0' }}             | ${'pkg:npm/%40scope/pkg'}
+      ${{ depName: 'org.example:artifact', datasource: 'maven', currentVersion: '1.0' }}  | ${'pkg:maven/org.example/artifact@1.0'}
+      ${{ depName: 'Django_Rest.Framework', datasource: 'pypi', currentVersion: '3.0' }}  | ${'pkg:pypi/django-rest-framework@3.0'}
+      ${{ depName: 'foo', packageName: 'bar', datasource: 'crate', currentVersion: '1' }} | ${'pkg:cargo/bar@1'}
+      ${{ depName: 'some/thing', datasource: 'custom.foo' }}                              | ${'pkg:generic/some%2Fthing'}
+      ${{ currentVersion: '1.0.0' }}                                                      | ${undefined}
+    `('getPurl($dep)', ({ dep, expected }) => {
+      expect(getPurl(dep)).toBe(expected);
+    });
+  });
+
+  describe('getSbomDependencies()', () => {
+    it('lists unique named dependencies', () => {
+      const dependencies = getSbomDependencies(packageFiles);
+
+      expect(dependencies.map(({ ref }) => ref)).toEqual([
+        'gomod:go.mod:go@1.22.0',
+        'gomod:go.mod:github.com/foo/bar@v1.2.3',
+        'gomod:go.mod:golang.org/x/text@v0.14.0',
+        'dockerfile:Dockerfile:node@20',
+      ]);
+      expect(dependencies.map(({ indirect }) => indirect)).toEqual([
+        false,
+        false,
+        true,
+        false,
+      ]);
+    });
+
+    it('omits the version of dependencies with only a range', () => {
+      const dependencies = getSbomDependencies({
+        npm: [
+          {
+            packageFile: 'package.json',
+            deps: [
+              { depName: 'foo', datasource: 'npm', currentValue: '^1.0.0' },
+            ],
+          },
+        ],
+      });
+
+      expect(dependencies).toMatchObject([
+        {
+          ref: 'npm:package.json:foo',
+          name: 'foo',
+          version: undefined,
+          purl: 'pkg:npm/foo',
+        },
+      ]);
+    });
+  });
+
+  describe('getCycloneDxSbom()', () => {
+    it('only lists direct dependencies as dependencies of the repository', () => {
+      const sbom = getCycloneDxSbom('org/repo', packageFiles);
+
+      expect(sbom).toMatchObject({
+        bomFormat: 'CycloneDX',
+        specVersion: '1.5',
+        metadata: { component: { 'bom-ref': 'org/repo' } },
+        dependencies: [
+          {
+            ref: 'org/repo',
+            dependsOn: [
+              'gomod:go.mod:go@1.22.0',
+              'gomod:go.mod:github.com/foo/bar@v1.2.3',
+              'dockerfile:Dockerfile:node@20',
+            ],
+          },
+        ],
+      });
+      expect(sbom.components).toContainEqual({
+        type: 'library',
+        'bom-ref': 'gomod:go.mod:golang.org/x/text@v0.14.0',
+        name: 'golang.org/x/text',
+        version: 'v0.14.0',
+        purl: 'pkg:golang/golang.org/x/text@v0.14.0',
+        properties: [
+          { name: 'renovate:manager', value: 'gomod' },
+          { name: 'renovate:packageFile', value: 'go.mod' },
+          { name: 'renovate:indirect', value: 'true' },
+          { name: 'renovate:depType', value: 'indirect' },
+        ],
+      });
+      expect(sbom.components).toContainEqual(
+        expect.objectContaining({
+          name: 'node',
+          hashes: [{ alg: 'SHA-256', content: digest.slice(7) }],
+        }),
+      );
+    });
+  });
+
+  describe('getSpdxSbom()', () => {
+    it('relates all dependencies to the repository', () => {
+      const sbom = getSpdxSbom('org/repo', packageFiles);
+
+      expect(sbom).toMatchObject({
+        spdxVersion: 'SPDX-2.3',
+        dataLicense: 'CC0-1.0',
+        name: 'org/repo',
+      });
+      expect(sbom.packages).toHaveLength(5);
+      expect(sbom.packages).toContainEqual({
+        SPDXID: 'SPDXRef-Package-3',
+        name: 'node',
+        versionInfo: '20',
+        downloadLocation: 'NOASSERTION',
+        filesAnalyzed: false,
+        primaryPackagePurpose: 'LIBRARY',
+        externalRefs: [
+          {
+            referenceCategory: 'PACKAGE-MANAGER',
+            referenceType: 'purl',
+            referenceLocator: 'pkg:docker/node@20',
+          },
+        ],
+        checksums: [{ algorithm: 'SHA256', checksumValue: digest.slice(7) }],
+        comment: `manager: dockerfile, packageFile: Dockerfile, digest: ${digest}`,
+      });
+      expect(sbom.relationships).toEqual([
+        {
+          spdxElementId: 'SPDXRef-DOCUMENT',
+          relationshipType: 'DESCRIBES',
+          relatedSpdxElement: 'SPDXRef-Repository',
+        },
+        {
+          spdxElementId: 'SPDXRef-Repository',
+          relationshipType: 'DEPENDS_ON',
+          relatedSpdxElement: 'SPDXRef-Package-0',
+        },
+        {
+          spdxElementId: 'SPDXRef-Repository',
+          relationshipType: 'DEPENDS_ON',
+          relatedSpdxElement: 'SPDXRef-Package-1',
+        },
+        {
+          spdxElementId: 'SPDXRef-Repository',
+          relationshipType: 'DEPENDS_ON',
+          relatedSpdxElement: 'SPDXRef-Package-2',
+          comment: 'indirect dependency',
+        },
+        {
+          spdxElementId: 'SPDXRef-Repository',
+          relationshipType: 'DEPENDS_ON',
+          relatedSpdxElement: 'SPDXRef-Package-3',
+        },
+      ]);
+    });
+  });
+});
@@ -0,0 +1,317 @@
+import { randomUUID } from 'node:crypto';
+import { pkg } from '../expose.ts';
+import type {
+  PackageDependency,
+  PackageFile,
+} from '../modules/manager/types.ts';
+import { coerceArray } from '../util/array.ts';
+import { regEx } from '../util/regex.ts';
+
+export type SbomFormat = 'cyclonedx' | 'spdx';
+
+/**
+ * A dependency of a repository, as listed in an SBOM.
+ */
+export interface SbomDependency {
+  ref: string;
+  name: string;
+  version?: string;
+  purl?: string;
+  manager: string;
+  packageFile: string;
+  depType?: string;
+  digest?: string;
+  /** Whether the dependency is only required by other dependencies, such as a Go `// indirect` requirement */
+  indirect: boolean;
+}
+
+const sha256DigestRegex = regEx(/^sha256:(?<hash>[a-f0-9]{64})$/);
+
+/**
+ * Maps datasources to package URL types.
+ *
+ * @see https://github.com/package-url/purl-spec/blob/main/PURL-TYPES.rst
+ */
+const purlTypes: Record<string, string> = {
+  cocoapods: 'cocoapods',
+  conan: 'conan',
+  crate: 'cargo',
+  docker: 'docker',
+  'github-releases': 'github',
+  'github-tags': 'github',
+  go: 'golang',
+  hex: 'hex',
+  maven: 'maven',
+  npm: 'npm',
+  nuget: 'nuget',
+  packagist: 'composer',
+  pub: 'pub',
+  pypi: 'pypi',
+  rubygems: 'gem',
+};
+
+function getSha256(digest: string | undefined): string | undefined {
+  return digest ? sha256DigestRegex.exec(digest)?.groups?.hash : undefined;
+}
+
+function getDependencyName(dep: PackageDependency): string | undefined {
+  return dep.packageName ?? dep.depName;
+}
+
+/**
+ * Returns the concrete version of a dependency, as `currentValue` is often a range.
+ */
+function getDependencyVersion(dep: PackageDependency): string | undefined {
+  return dep.lockedVersion ?? dep.currentVersion ?? undefined;
+}
+
+function encodePath(path: string): string {
+  return path.split('/').map(encodeURIComponent).join('/');
+}
+
+/**
+ * Returns the package URL of a dependency, such as `pkg:golang/github.com/foo/bar@v1.2.3`.
+ */
+export function getPurl(dep: PackageDependency): string | undefined {
+  const name = getDependencyName(dep);
+  if (!name) {
+    return undefined;
+  }
+
+  const type = purlTypes[dep.datasource ?? ''] ?? 'generic';
+  let path: string;
+  if (type === 'maven') {
+    path = encodePath(name.replace(':', '/'));
+  } else if (type === 'pypi') {
+    path = encodeURIComponent(
+      name.toLowerCase().replace(regEx(/[-_.]+/g), '-'),
+    );
+  } else if (type === 'generic' || type === 'nuget' || type === 'gem') {
+    path = encodeURIComponent(name);
+  } else {
+    path = encodePath(name);
+  }
+
+  const version = getDependencyVersion(dep);
+  return version
+    ? `pkg:${type}/${path}@${encodeURIComponent(version)}`
+    : `pkg:${type}/${path}`;
+}
+
+/**
+ * Lists the dependencies which were extracted from a repository's package files.
+ */
+export function getSbomDependencies(
+  packageFiles: Record<string, PackageFile[]>,
+): SbomDependency[] {
+  const result = new Map<string, SbomDependency>();
+
+  for (const [manager, files] of Object.entries(packageFiles)) {
+    for (const packageFile of files) {
+      for (const dep of coerceArray(packageFile.deps)) {
+        const name = getDependencyName(dep);
+        if (!name) {
+          continue;
+        }
+        const version = getDependencyVersion(dep);
+        const ref = `${manager}:${packageFile.packageFile}:${name}${version ? `@${version}` : ''}`;
+        if (result.has(ref)) {
+          continue;
+        }
+        result.set(ref, {
+          ref,
+          name,
+          version,
+          purl: getPurl(dep),
+          manager,
+          packageFile: packageFile.packageFile,
+          depType: dep.depType,
+          digest: dep.currentDigest,
+          indirect: dep.depType === 'indirect',
+        });
+      }
+    }
+  }
+
+  return [...result.values()];
+}
+
+/**
+ * Generates a CycloneDX 1.5 SBOM.
+ *
+ * Direct dependencies are listed as dependencies of the repository, indirect ones aren't.
+ *
+ * @see https://cyclonedx.org/docs/1.5/json/
+ */
+export function getCycloneDxSbom(
+  repository: string,
+  packageFiles: Record<string, PackageFile[]>,
+): Record<string, unknown> {
+  const dependencies = getSbomDependencies(packageFiles);
+
+  const components = dependencies.map((dep) => {
+    const properties = [
+      { name: 'renovate:manager', value: dep.manager },
+      { name: 'renovate:packageFile', value: dep.packageFile },
+      { name: 'renovate:indirect', value: String(dep.indirect) },
+    ];
+    if (dep.depType) {
+      properties.push({ name: 'renovate:depType', value: dep.depType });
+    }
+    if (dep.digest) {
+      properties.push({ name: 'renovate:digest', value: dep.digest });
+    }
+
+    const component: Record<string, unknown> = {
+      type: 'library',
+      'bom-ref': dep.ref,
+      name: dep.name,
+    };
+    if (dep.version) {
+      component.version = dep.version;
+    }
+    if (dep.purl) {
+      component.purl = dep.purl;
+    }
+    const hash = getSha256(dep.digest);
+    if (hash) {
+      component.hashes = [{ alg: 'SHA-256', content: hash }];
+    }
+    component.properties = properties;
+    return component;
+  });
+
+  return {
+    bomFormat: 'CycloneDX',
+    specVersion: '1.5',
+    serialNumber: `urn:uuid:${randomUUID()}`,
+    version: 1,
+    metadata: {
+      timestamp: new Date().toISOString(),
+      tools: {
+        components: [
+          { type: 'application', name: 'renovate', version: pkg.version },
+        ],
+      },
+      component: {
+        type: 'application',
+        'bom-ref': repository,
+        name: repository,
+      },
+    },
+    components,
+    dependencies: [
+      {
+        ref: repository,
+        dependsOn: dependencies
+          .filter(({ indirect }) => !indirect)
+          .map(({ ref }) => ref),
+      },
+    ],
+  };
+}
+
+function getSpdxId(index: number): string {
+  return `SPDXRef-Package-${index}`;
+}
+
+/**
+ * Generates an SPDX 2.3 SBOM.
+ *
+ * Both direct and indirect dependencies are related to the repository with `DEPENDS_ON`, and indirect ones are marked with a comment.
+ *
+ * @see https://spdx.github.io/spdx-spec/v2.3/
+ */
+export function getSpdxSbom(
+  repository: string,
+  packageFiles: Record<string, PackageFile[]>,
+): Record<string, unknown> {
+  const dependencies = getSbomDependencies(packageFiles);
+  const rootId = 'SPDXRef-Repository';
+
+  const packages = dependencies.map((dep, index) => {
+    const comment = [
+      `manager: ${dep.manager}`,
+      `packageFile: ${dep.packageFile}`,
+      dep.depType && `depType: ${dep.depType}`,
+      dep.digest && `digest: ${dep.digest}`,
+    ]
+      .filter(Boolean)
+      .join(', ');
+    const spdxPackage: Record<string, unknown> = {
+      SPDXID: getSpdxId(index),
+      name: dep.name,
+    };
+    if (dep.version) {
+      spdxPackage.versionInfo = dep.version;
+    }
+    spdxPackage.downloadLocation = 'NOASSERTION';
+    spdxPackage.filesAnalyzed = false;
+    spdxPackage.primaryPackagePurpose = 'LIBRARY';
+    if (dep.purl) {
+      spdxPackage.externalRefs = [
+        {
+          referenceCategory: 'PACKAGE-MANAGER',
+          referenceType: 'purl',
+          referenceLocator: dep.purl,
+        },
+      ];
+    }
+    const hash = getSha256(dep.digest);
+    if (hash) {
+      spdxPackage.checksums = [{ algorithm: 'SHA256', checksumValue: hash }];
+    }
+    spdxPackage.comment = comment;
+    return spdxPackage;
+  });
+
+  return {
+    spdxVersion: 'SPDX-2.3',
+    dataLicense: 'CC0-1.0',
+    SPDXID: 'SPDXRef-DOCUMENT',
+    name: repository,
+    documentNamespace: `https://docs.renovatebot.com/spdx/${encodePath(repository)}-${randomUUID()}`,
+    creationInfo: {
+      created: new Date().toISOString().replace(regEx(/\.\d{3}Z$/), 'Z'),
+      creators: [`Tool: renovate-${pkg.version}`],
+    },
+    packages: [
+      {
+        SPDXID: rootId,
+        name: repository,
+        downloadLocation: 'NOASSERTION',
+        filesAnalyzed: false,
+        primaryPackagePurpose: 'APPLICATION',
+      },
+      ...packages,
+    ],
+    relationships: [
+      {
+        spdxElementId: 'SPDXRef-DOCUMENT',
+        relationshipType: 'DESCRIBES',
+        relatedSpdxElement: rootId,
+      },
+      ...dependencies.map(({ indirect }, index) => {
+        const relationship: Record<string, string> = {
+          spdxElementId: rootId,
+          relationshipType: 'DEPENDS_ON',
+          relatedSpdxElement: getSpdxId(index),
+        };
+        if (indirect) {
+          relationship.comment = 'indirect dependency';
+        }
+        return relationship;
+      }),
+    ],
+  };
+}
+
+export function getSbom(
+  format: SbomFormat,
+  repository: string,
+  packageFiles: Record<string, PackageFile[]>,
+): Record<string, unknown> {
+  return format === 'spdx'
+    ? getSpdxSbom(repository, packageFiles)
+    : getCycloneDxSbom(repository, packageFiles);
+}
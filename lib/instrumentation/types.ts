@@ -64,6 +64,10 @@ export interface LibYearsWithStatus {
 export interface LibYears {
   total: number;
   managers: Record<string, number>;
+  /** libyears per package file, grouped by manager */
+  packageFiles: Record<string, Record<string, number>>;
+  /** libyears per dependency type, grouped by manager */
+  depTypes: Record<string, Record<string, number>>;
 }
 
 export interface DependencyStatus {
@@ -88,6 +92,31 @@ export const ATTR_RENOVATE_REGISTRY_URL = 'renovate.registryUrl';
  */
 export const ATTR_RENOVATE_PACKAGE_NAME = 'renovate.packageName';
 
+/**
+ * The repository being processed (ex: `renovatebot/renovate`).
+ */
+export const ATTR_RENOVATE_REPOSITORY = 'renovate.repository';
+
+/**
+ * The base branch being processed.
+ */
+export const ATTR_RENOVATE_BASE_BRANCH = 'renovate.baseBranch';
+
+/**
+ * The name of a Renovate manager (ex: `npm`, `gomod`, `dockerfile`, etc).
+ */
+export const ATTR_RENOVATE_MANAGER = 'renovate.manager';
+
+/**
+ * The path of a package file, relative to the repository root.
+ */
+export const ATTR_RENOVATE_PACKAGE_FILE = 'renovate.packageFile';
+
+/**
+ * The dependency type within a package file (ex: `devDependencies`, `indirect`).
+ */
+export const ATTR_RENOVATE_DEP_TYPE = 'renovate.depType';
+
 /**
  * the Git Version Control System (VCS)'s Operation Type
  *
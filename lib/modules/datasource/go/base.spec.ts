@@ -1,6 +1,8 @@
+import { codeBlock } from 'common-tags';
 import { Fixtures } from '~test/fixtures.ts';
 import { hostRules } from '~test/host-rules.ts';
 import * as httpMock from '~test/http-mock.ts';
+import { logger } from '~test/util.ts';
 import { GlobalConfig } from '../../../config/global.ts';
 import { GitTagsDatasource } from '../git-tags/index.ts';
 import { GithubTagsDatasource } from '../github-tags/index.ts';
@@ -466,7 +468,7 @@ describe('modules/datasource/go/base', () => {
         });
       });
 
-      it('returns null for mod imports', async () => {
+      it('supports mod imports', async () => {
         const meta =
           '<meta name="go-import" content="buf.build/gen/go/gogo/protobuf/protocolbuffers/go mod https://buf.build/gen/go">';
         httpMock
@@ -478,9 +480,139 @@ describe('modules/datasource/go/base', () => {
           'buf.build/gen/go/gogo/protobuf/protocolbuffers/go',
         );
 
+        expect(res).toEqual({
+          datasource: 'go-proxy',
+          registryUrl: 'https://buf.build/gen/go',
+          packageName: 'buf.build/gen/go/gogo/protobuf/protocolbuffers/go',
+        });
+      });
+
+      it('selects the longest matching go-import prefix', async () => {
+        const html = codeBlock`
+          <html>
+          <head>
+          <meta name="go-import" content="go.example.com/tool git https://git.example.com/tool">
+          <meta name="go-import" content="go.example.com/tools git https://git.example.com/tools">
+          <meta name="go-import" content="go.example.com/tools/cli git https://github.com/example/cli">
+          <meta name="go-import" content="go.example.com/tools/cli/v2 hg https://hg.example.com/cli">
+          <meta name="go-import" content="go.example.com/invalid">
+          </head>
+          </html>
+        `;
+        httpMock
+          .scope('https://go.example.com')
+          .get('/tools/cli/cmd?go-get=1')
+          .reply(200, html);
+
+        const res = await BaseGoDatasource.getDatasource(
+          'go.example.com/tools/cli/cmd',
+        );
+
+        expect(res).toEqual({
+          datasource: GithubTagsDatasource.id,
+          registryUrl: 'https://github.com',
+          packageName: 'example/cli',
+        });
+      });
+
+      it('prefers mod over VCS imports with the same prefix', async () => {
+        const html = codeBlock`
+          <meta name="go-import" content="go.example.com/lib git https://github.com/example/lib">
+          <meta name="go-import" content="go.example.com/lib mod https://proxy.example.com">
+        `;
+        httpMock
+          .scope('https://go.example.com')
+          .get('/lib?go-get=1')
+          .reply(200, html);
+
+        const res = await BaseGoDatasource.getDatasource('go.example.com/lib');
+
+        expect(res).toEqual({
+          datasource: 'go-proxy',
+          registryUrl: 'https://proxy.example.com',
+          packageName: 'go.example.com/lib',
+        });
+      });
+
+      it('returns null for unsupported VCS types', async () => {
+        const meta =
+          '<meta name="go-import" content="go.example.com/cli hg https://hg.example.com/cli">';
+        httpMock
+          .scope('https://go.example.com')
+          .get('/cli?go-get=1')
+          .reply(200, meta);
+
+        const res = await BaseGoDatasource.getDatasource('go.example.com/cli');
+
+        expect(res).toBeNull();
+        expect(logger.logger.debug).toHaveBeenCalledWith(
+          { goModule: 'go.example.com/cli', vcs: 'hg' },
+          'go-import header VCS not supported',
+        );
+      });
+
+      it('does not match a go-import prefix within a path element', async () => {
+        const meta =
+          '<meta name="go-import" content="go.example.com/cli git https://github.com/example/cli">';
+        httpMock
+          .scope('https://go.example.com')
+          .get('/cli-extra?go-get=1')
+          .reply(200, meta);
+
+        const res = await BaseGoDatasource.getDatasource(
+          'go.example.com/cli-extra',
+        );
+
         expect(res).toBeNull();
       });
 
+      it('tries parent paths when a subpath is not found', async () => {
+        const meta =
+          '<meta name="go-import" content="go.example.com/tools git https://github.com/example/tools">';
+        httpMock
+          .scope('https://go.example.com')
+          .get('/tools/cmd/lint?go-get=1')
+          .reply(404)
+          .get('/tools/cmd?go-get=1')
+          .reply(404)
+          .get('/tools?go-get=1')
+          .reply(200, meta);
+
+        const res = await BaseGoDatasource.getDatasource(
+          'go.example.com/tools/cmd/lint',
+        );
+
+        expect(res).toEqual({
+          datasource: GithubTagsDatasource.id,
+          registryUrl: 'https://github.com',
+          packageName: 'example/tools',
+        });
+      });
+
+      it('throws if no parent path is found', async () => {
+        httpMock
+          .scope('https://go.example.com')
+          .get('/tools?go-get=1')
+          .reply(404)
+          .get('/?go-get=1')
+          .reply(404);
+
+        await expect(
+          BaseGoDatasource.getDatasource('go.example.com/tools'),
+        ).rejects.toThrow();
+      });
+
+      it('does not try parent paths on server errors', async () => {
+        httpMock
+          .scope('https://go.example.com')
+          .get('/tools/cmd?go-get=1')
+          .reply(500);
+
+        await expect(
+          BaseGoDatasource.getDatasource('go.example.com/tools/cmd'),
+        ).rejects.toThrow();
+      });
+
       it('returns null for invalid import URL', async () => {
         const meta =
           '<meta name="go-import" content="buf.build/gen/go/gogo/protobuf/protocolbuffers/go git foobar">';
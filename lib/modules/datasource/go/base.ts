@@ -3,7 +3,9 @@ import { GlobalConfig } from '../../../config/global.ts';
 import { logger } from '../../../logger/index.ts';
 import { detectPlatform } from '../../../util/common.ts';
 import * as hostRules from '../../../util/host-rules.ts';
-import { Http } from '../../../util/http/index.ts';
+import type { HTMLElement } from '../../../util/html.ts';
+import { parse } from '../../../util/html.ts';
+import { Http, HttpError } from '../../../util/http/index.ts';
 import { regEx } from '../../../util/regex.ts';
 import {
   parseUrl,
@@ -16,7 +18,9 @@ import { GitTagsDatasource } from '../git-tags/index.ts';
 import { GiteaTagsDatasource } from '../gitea-tags/index.ts';
 import { GithubTagsDatasource } from '../github-tags/index.ts';
 import { GitlabTagsDatasource } from '../gitlab-tags/index.ts';
-import type { DataSource } from './types.ts';
+import { goProxyDatasourceId } from './common.ts';
+import { getGoAuthHeaders } from './goauth.ts';
+import type { DataSource, GoImport } from './types.ts';
 
 // TODO: figure out class hierarchy (#10532)
 export class BaseGoDatasource {
@@ -126,16 +130,16 @@ export class BaseGoDatasource {
     goModule: string,
   ): Promise<DataSource | null> {
     const goModuleUrl = goModule.replace(regEx(/\.git(\/[a-z0-9/]*)?$/), '');
-    const pkgUrl = `https://${goModuleUrl}?go-get=1`;
-    const { body: html } = await BaseGoDatasource.http.getText(pkgUrl);
+    const html = await BaseGoDatasource.getGoGetPage(goModuleUrl);
+    const metas = parse(html).querySelectorAll('meta');
 
-    const goSourceHeader = BaseGoDatasource.goSourceHeader(html, goModule);
+    const goSourceHeader = BaseGoDatasource.goSourceHeader(metas, goModule);
     if (goSourceHeader) {
       return goSourceHeader;
     }
 
     // GitHub Enterprise only returns a go-import meta
-    const goImport = BaseGoDatasource.goImportHeader(html, goModule);
+    const goImport = BaseGoDatasource.goImportHeader(metas, goModule);
     if (goImport) {
       return goImport;
     }
@@ -144,22 +148,117 @@ export class BaseGoDatasource {
     return null;
   }
 
+  /**
+   * Fetches the `?go-get=1` page of a module path.
+   *
+   * Not every server answers for subpaths of a repository, so parent paths are tried while the response is a 404.
+   */
+  private static async getGoGetPage(goModuleUrl: string): Promise<string> {
+    let path = goModuleUrl;
+    for (;;) {
+      try {
+        const url = `https://${path}?go-get=1`;
+        const headers = await getGoAuthHeaders(url, BaseGoDatasource.id);
+        const { body } = await BaseGoDatasource.http.getText(
+          url,
+          headers ? { headers } : {},
+        );
+        return body;
+      } catch (err) {
+        const parent = path.replace(regEx(/\/[^/]+$/), '');
+        if (
+          !(err instanceof HttpError && err.response?.statusCode === 404) ||
+          parent === path
+        ) {
+          throw err;
+        }
+        logger.debug(`go-get page not found for ${path}, trying ${parent}`);
+        path = parent;
+      }
+    }
+  }
+
+  private static getMetaContents(
+    metas: HTMLElement[],
+    name: string,
+  ): string[][] {
+    return metas
+      .filter((meta) => meta.getAttribute('name') === name)
+      .map((meta) =>
+        (meta.getAttribute('content') ?? '').trim().split(regEx(/\s+/)),
+      );
+  }
+
+  /**
+   * Parses the `go-import` meta tags of a `?go-get=1` page.
+   *
+   * @see https://go.dev/ref/mod#vcs-find
+   */
+  static parseGoImports(metas: HTMLElement[]): GoImport[] {
+    const result: GoImport[] = [];
+    for (const fields of BaseGoDatasource.getMetaContents(
+      metas,
+      'go-import',
+    )) {
+      // an optional fourth field is the subdirectory of the module in the repository
+      if (fields.length !== 3 && fields.length !== 4) {
+        continue;
+      }
+      const [prefix, vcs, repoRoot] = fields;
+      result.push({ prefix, vcs, repoRoot });
+    }
+    return result;
+  }
+
+  /**
+   * Selects the `go-import` whose prefix is the longest match of the module path at a path element boundary.
+   *
+   * As with `go get` in module mode, a `mod` import takes precedence over a VCS for the same prefix.
+   */
+  static matchGoImport(
+    goImports: GoImport[],
+    goModule: string,
+  ): GoImport | null {
+    let match: GoImport | null = null;
+    for (const goImport of goImports) {
+      const { prefix, vcs } = goImport;
+      if (goModule !== prefix && !goModule.startsWith(`${prefix}/`)) {
+        continue;
+      }
+      if (
+        !match ||
+        prefix.length > match.prefix.length ||
+        (prefix === match.prefix && vcs === 'mod' && match.vcs !== 'mod')
+      ) {
+        match = goImport;
+      }
+    }
+    return match;
+  }
+
   private static goSourceHeader(
-    html: string,
+    metas: HTMLElement[],
     goModule: string,
   ): DataSource | null {
-    const sourceMatchGroups = regEx(
-      /<meta\s+name="?go-source"?\s+content="(?<prefix>[^"\s]+)\s+(?<goSourceUrl>[^"\s]+)/,
-    ).exec(html)?.groups;
-    if (!sourceMatchGroups) {
-      return null;
+    let match: string[] | null = null;
+    for (const fields of BaseGoDatasource.getMetaContents(
+      metas,
+      'go-source',
+    )) {
+      const [prefix, goSourceUrl] = fields;
+      if (
+        goSourceUrl &&
+        goModule.startsWith(prefix) &&
+        (!match || prefix.length > match[0].length)
+      ) {
+        match = fields;
+      }
     }
-    const { prefix, goSourceUrl } = sourceMatchGroups;
-
-    if (!goModule.startsWith(prefix)) {
+    if (!match) {
       logger.trace({ goModule }, 'go-source header prefix not match');
       return null;
     }
+    const [, goSourceUrl] = match;
 
     logger.debug(`Go lookup source url ${goSourceUrl} for module ${goModule}`);
     return this.detectDatasource(goSourceUrl, goModule);
@@ -245,25 +344,34 @@ export class BaseGoDatasource {
   }
 
   private static goImportHeader(
-    html: string,
+    metas: HTMLElement[],
     goModule: string,
   ): DataSource | null {
-    const importMatchGroups = regEx(
-      /<meta\s+name="?go-import"?\s+content="(?<prefix>[^"\s]+)\s+(?<proto>[^"\s]+)\s+(?<goImportURL>[^"\s]+)/,
-    ).exec(html)?.groups;
-    if (!importMatchGroups) {
+    const goImports = BaseGoDatasource.parseGoImports(metas);
+    if (!goImports.length) {
       logger.trace({ goModule }, 'No go-source or go-import header found');
       return null;
     }
-    const { prefix, proto, goImportURL } = importMatchGroups;
 
-    if (!goModule.startsWith(prefix)) {
+    const goImport = BaseGoDatasource.matchGoImport(goImports, goModule);
+    if (!goImport) {
       logger.trace({ goModule }, 'go-import header prefix not match');
       return null;
     }
+    const { vcs, repoRoot: goImportURL } = goImport;
+
+    if (vcs === 'mod') {
+      // the module is served by a Go module proxy at the given URL
+      logger.debug(`Go module: ${goModule} lookup proxy url ${goImportURL}`);
+      return {
+        datasource: goProxyDatasourceId,
+        registryUrl: goImportURL,
+        packageName: goModule,
+      };
+    }
 
-    if (proto !== 'git') {
-      logger.trace({ goModule }, 'go-import header proto not git');
+    if (vcs !== 'git') {
+      logger.debug({ goModule, vcs }, 'go-import header VCS not supported');
       return null;
     }
 
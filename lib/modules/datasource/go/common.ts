@@ -8,6 +8,11 @@ import { getSourceUrl as gitlabSourceUrl } from '../gitlab-tags/util.ts';
 
 import type { DataSource } from './types.ts';
 
+/**
+ * The id of `GoProxyDatasource`, used for modules whose `go-import` meta points at a Go module proxy.
+ */
+export const goProxyDatasourceId = 'go-proxy';
+
 export type GoproxyFallback =
   | ',' // WhenNotFoundOrGone
   | '|'; // Always
@@ -0,0 +1,254 @@
+import { codeBlock } from 'common-tags';
+import { fs, hostRules, partial } from '~test/util.ts';
+import * as memCache from '../../../util/cache/memory/index.ts';
+import { setCustomEnv, setUserEnv } from '../../../util/env.ts';
+import { exec as _exec } from '../../../util/exec/index.ts';
+import type { ExecResult } from '../../../util/exec/types.ts';
+import { toBase64 } from '../../../util/string.ts';
+import {
+  getGoAuthHeaders,
+  getNetrcPath,
+  parseGoAuthCommandOutput,
+  parseGoauth,
+  parseNetrc,
+} from './goauth.ts';
+
+vi.mock('../../../util/exec/index.ts');
+vi.mock('../../../util/fs/index.ts');
+vi.mock('../../../util/host-rules.ts');
+
+const exec = vi.mocked(_exec);
+
+const netrc = codeBlock`
+  machine proxy.example.com
+    login user
+    password pass
+
+  machine git.example.com login other password secret
+`;
+
+const basicAuth = (login: string, password: string): string =>
+  `Basic ${toBase64(`${login}:${password}`)}`;
+
+describe('modules/datasource/go/goauth', () => {
+  beforeEach(() => {
+    memCache.init();
+    setCustomEnv({});
+    setUserEnv({});
+    hostRules.find.mockReturnValue({});
+  });
+
+  afterEach(() => {
+    memCache.reset();
+  });
+
+  describe('parseGoauth()', () => {
+    it.each`
+      input                            | expected
+      ${undefined}                     | ${[{ type: 'netrc' }]}
+      ${''}                            | ${[]}
+      ${'off'}                         | ${[{ type: 'off' }]}
+      ${'netrc; git /src'}             | ${[{ type: 'netrc' }, { type: 'git', dir: '/src' }]}
+      ${'/usr/bin/auth --flag;netrc;'} | ${[{ type: 'command', command: '/usr/bin/auth --flag' }, { type: 'netrc' }]}
+    `('parseGoauth("$input")', ({ input, expected }) => {
+      expect(parseGoauth(input)).toEqual(expected);
+    });
+  });
+
+  describe('parseNetrc()', () => {
+    it('parses machines', () => {
+      expect(parseNetrc(netrc)).toEqual([
+        {
+          prefix: 'https://proxy.example.com',
+          headers: { authorization: basicAuth('user', 'pass') },
+        },
+        {
+          prefix: 'https://git.example.com',
+          headers: { authorization: basicAuth('other', 'secret') },
+        },
+      ]);
+    });
+
+    it('ignores macros, incomplete entries and default', () => {
+      const content = codeBlock`
+        machine a.example.com login a
+        macdef init
+          machine b.example.com login b password b
+
+        machine c.example.com login c password c
+        default login d password d
+        machine e.example.com login e password e
+      `;
+      expect(parseNetrc(content)).toEqual([
+        {
+          prefix: 'https://c.example.com',
+          headers: { authorization: basicAuth('c', 'c') },
+        },
+      ]);
+    });
+  });
+
+  describe('parseGoAuthCommandOutput()', () => {
+    it('parses credential blocks', () => {
+      const output = codeBlock`
+        https://example.com
+        https://example.org/private
+
+        Authorization: Bearer token
+        X-Custom: value
+
+        https://example.net
+
+        Authorization: Basic abc
+
+      `;
+      expect(parseGoAuthCommandOutput(output)).toEqual([
+        {
+          prefix: 'https://example.com',
+          headers: { authorization: 'Bearer token', 'x-custom': 'value' },
+        },
+        {
+          prefix: 'https://example.org/private',
+          headers: { authorization: 'Bearer token', 'x-custom': 'value' },
+        },
+        {
+          prefix: 'https://example.net',
+          headers: { authorization: 'Basic abc' },
+        },
+      ]);
+    });
+
+    it('returns empty list for empty output', () => {
+      expect(parseGoAuthCommandOutput('')).toEqual([]);
+    });
+  });
+
+  describe('getNetrcPath()', () => {
+    it('uses NETRC', () => {
+      setCustomEnv({ NETRC: '/custom/.netrc' });
+      expect(getNetrcPath()).toBe('/custom/.netrc');
+    });
+
+    it('uses HOME', () => {
+      setCustomEnv({ HOME: '/home/user' });
+      expect(getNetrcPath()).toBe('/home/user/.netrc');
+    });
+  });
+
+  describe('getGoAuthHeaders()', () => {
+    it('uses netrc by default', async () => {
+      setCustomEnv({ NETRC: '/custom/.netrc' });
+      fs.readSystemFile.mockResolvedValue(netrc as never);
+
+      expect(
+        await getGoAuthHeaders(
+          'https://proxy.example.com/foo/@v/list',
+          'goproxy',
+        ),
+      ).toEqual({ authorization: basicAuth('user', 'pass') });
+      expect(
+        await getGoAuthHeaders('https://git.example.com/foo?go-get=1', 'go'),
+      ).toEqual({ authorization: basicAuth('other', 'secret') });
+      expect(
+        await getGoAuthHeaders('https://proxy.example.com.evil/foo', 'go'),
+      ).toBeUndefined();
+      expect(fs.readSystemFile).toHaveBeenCalledExactlyOnceWith(
+        '/custom/.netrc',
+        'utf8',
+      );
+    });
+
+    it('returns undefined without netrc', async () => {
+      fs.readSystemFile.mockRejectedValueOnce(new Error('ENOENT'));
+
+      expect(
+        await getGoAuthHeaders('https://proxy.example.com/foo', 'goproxy'),
+      ).toBeUndefined();
+    });
+
+    it('ignores plain http', async () => {
+      expect(
+        await getGoAuthHeaders('http://proxy.example.com/foo', 'goproxy'),
+      ).toBeUndefined();
+      expect(fs.readSystemFile).not.toHaveBeenCalled();
+    });
+
+    it('prefers host rules', async () => {
+      hostRules.find.mockReturnValue({ token: 'token' });
+
+      expect(
+        await getGoAuthHeaders('https://proxy.example.com/foo', 'goproxy'),
+      ).toBeUndefined();
+      expect(fs.readSystemFile).not.toHaveBeenCalled();
+    });
+
+    it('ignores GOAUTH and NETRC from the repository env', async () => {
+      setUserEnv({ GOAUTH: 'auth-helper', NETRC: '/repo/.netrc' });
+      setCustomEnv({ NETRC: '/custom/.netrc' });
+      fs.readSystemFile.mockResolvedValue(netrc as never);
+
+      expect(
+        await getGoAuthHeaders('https://proxy.example.com/foo', 'goproxy'),
+      ).toEqual({ authorization: basicAuth('user', 'pass') });
+      expect(exec).not.toHaveBeenCalled();
+      expect(fs.readSystemFile).toHaveBeenCalledExactlyOnceWith(
+        '/custom/.netrc',
+        'utf8',
+      );
+    });
+
+    it('is disabled by off', async () => {
+      setCustomEnv({ GOAUTH: 'netrc;off' });
+
+      expect(
+        await getGoAuthHeaders('https://proxy.example.com/foo', 'goproxy'),
+      ).toBeUndefined();
+      expect(fs.readSystemFile).not.toHaveBeenCalled();
+    });
+
+    it('runs commands and uses the longest matching prefix', async () => {
+      setCustomEnv({ GOAUTH: 'git /src; auth-helper' });
+      exec.mockResolvedValueOnce(
+        partial<ExecResult>({
+          stdout: codeBlock`
+            https://example.com
+
+            Authorization: Bearer outer
+
+            https://example.com/private
+
+            Authorization: Bearer inner
+
+          `,
+        }),
+      );
+
+      expect(
+        await getGoAuthHeaders(
+          'https://example.com/private/mod/@v/list',
+          'goproxy',
+        ),
+      ).toEqual({ authorization: 'Bearer inner' });
+      expect(
+        await getGoAuthHeaders('https://example.com/public/@v/list', 'goproxy'),
+      ).toEqual({ authorization: 'Bearer outer' });
+      expect(exec).toHaveBeenCalledExactlyOnceWith(
+        "auth-helper 'https://example.com/private/mod/@v/list'",
+        { timeout: 60000 },
+      );
+    });
+
+    it('runs commands once per origin', async () => {
+      setCustomEnv({ GOAUTH: 'auth-helper' });
+      exec.mockRejectedValueOnce(new Error('failed'));
+
+      expect(
+        await getGoAuthHeaders('https://example.com/a/@v/list', 'goproxy'),
+      ).toBeUndefined();
+      expect(
+        await getGoAuthHeaders('https://example.com/b/@v/list', 'goproxy'),
+      ).toBeUndefined();
+      expect(exec).toHaveBeenCalledOnce();
+    });
+  });
+});
@@ -0,0 +1,292 @@
+import os from 'node:os';
+import { quote } from 'shlex';
+import upath from 'upath';
+import { logger } from '../../../logger/index.ts';
+import * as memCache from '../../../util/cache/memory/index.ts';
+import { getCustomEnv } from '../../../util/env.ts';
+import { exec } from '../../../util/exec/index.ts';
+import { readSystemFile } from '../../../util/fs/index.ts';
+import * as hostRules from '../../../util/host-rules.ts';
+import { newlineRegex, regEx } from '../../../util/regex.ts';
+import { addSecretForSanitizing } from '../../../util/sanitize.ts';
+import { toBase64 } from '../../../util/string.ts';
+import { parseUrl } from '../../../util/url.ts';
+
+export type GoAuthCommand =
+  | { type: 'off' }
+  | { type: 'netrc' }
+  | { type: 'git'; dir: string }
+  | { type: 'command'; command: string };
+
+/**
+ * Headers which GOAUTH attaches to requests for URLs starting with `prefix`.
+ */
+export interface GoAuthCredential {
+  prefix: string;
+  headers: Record<string, string>;
+}
+
+interface CommandCache {
+  credentials: GoAuthCredential[];
+  /** origins for which the command has been run */
+  origins: string[];
+}
+
+const headerLineRegex = regEx(/^(?<name>[^:\s]+):\s*(?<value>.*)$/);
+
+// GOAUTH commands can be slow, but shouldn't stall a lookup
+const commandTimeout = 60 * 1000;
+
+/**
+ * The environment of the Renovate process, including `customEnvVariables`.
+ *
+ * GOAUTH runs commands and reads credential files, so it's never read from the `env` config of a repository.
+ */
+function getAdminEnv(): Record<string, string | undefined> {
+  return { ...process.env, ...getCustomEnv() };
+}
+
+/**
+ * `GOAUTH` and `NETRC` for running the `go` command, which are also never read from the `env` config of a repository.
+ */
+export function getGoAuthEnv(): Record<string, string | undefined> {
+  const { GOAUTH, NETRC } = getAdminEnv();
+  return { GOAUTH, NETRC };
+}
+
+/**
+ * Parses the semicolon-separated list of authentication commands in `GOAUTH`, which defaults to `netrc`.
+ *
+ * @see https://go.dev/ref/mod#goauth
+ */
+export function parseGoauth(input = getAdminEnv().GOAUTH): GoAuthCommand[] {
+  const result: GoAuthCommand[] = [];
+  for (const entry of (input ?? 'netrc').split(';')) {
+    const command = entry.trim();
+    if (command === 'off' || command === 'netrc') {
+      result.push({ type: command });
+    } else if (command.startsWith('git ')) {
+      result.push({ type: 'git', dir: command.slice(4).trim() });
+    } else if (command) {
+      result.push({ type: 'command', command });
+    }
+  }
+  return result;
+}
+
+/**
+ * Parses a `.netrc` file in the same way as the `go` command, which ignores `default` and `macdef` entries.
+ */
+export function parseNetrc(content: string): GoAuthCredential[] {
+  const result: GoAuthCredential[] = [];
+  let machine: string | undefined;
+  let login: string | undefined;
+  let password: string | undefined;
+  let inMacro = false;
+
+  const addCredential = (): void => {
+    if (machine && login && password) {
+      result.push({
+        prefix: `https://${machine}`,
+        headers: {
+          authorization: `Basic ${toBase64(`${login}:${password}`)}`,
+        },
+      });
+    }
+    machine = undefined;
+    login = undefined;
+    password = undefined;
+  };
+
+  for (const line of content.split(newlineRegex)) {
+    if (inMacro) {
+      inMacro = line.trim() !== '';
+      continue;
+    }
+
+    const fields = line.trim().split(regEx(/\s+/));
+    for (let i = 0; i < fields.length - 1; i += 2) {
+      const [token, value] = [fields[i], fields[i + 1]];
+      if (token === 'machine') {
+        addCredential();
+        machine = value;
+      } else if (token === 'login') {
+        login = value;
+      } else if (token === 'password') {
+        password = value;
+      } else if (token === 'macdef') {
+        inMacro = true;
+        break;
+      } else if (token === 'default') {
+        addCredential();
+        return result;
+      }
+    }
+  }
+
+  addCredential();
+  return result;
+}
+
+/**
+ * Parses the output of a GOAUTH command: blocks of URL lines, a blank line, and header lines, terminated by another blank line.
+ */
+export function parseGoAuthCommandOutput(output: string): GoAuthCredential[] {
+  const result: GoAuthCredential[] = [];
+  let prefixes: string[] = [];
+  let headers: Record<string, string> = {};
+  let inHeaders = false;
+
+  for (const line of [...output.split(newlineRegex), '']) {
+    if (!line.trim()) {
+      if (inHeaders) {
+        for (const prefix of prefixes) {
+          result.push({ prefix, headers });
+        }
+        prefixes = [];
+        headers = {};
+      }
+      inHeaders = !inHeaders && prefixes.length > 0;
+      continue;
+    }
+
+    if (!inHeaders) {
+      if (line.startsWith('https://')) {
+        prefixes.push(line.trim());
+      }
+      continue;
+    }
+
+    const header = headerLineRegex.exec(line.trim())?.groups;
+    if (header) {
+      headers[header.name.toLowerCase()] = header.value;
+    }
+  }
+
+  return result;
+}
+
+export function getNetrcPath(): string {
+  const env = getAdminEnv();
+  if (env.NETRC) {
+    return env.NETRC;
+  }
+  const fileName = os.platform() === 'win32' ? '_netrc' : '.netrc';
+  return upath.join(env.HOME ?? os.homedir(), fileName);
+}
+
+async function getNetrcCredentials(): Promise<GoAuthCredential[]> {
+  const netrcPath = getNetrcPath();
+  const cacheKey = `goauth:netrc:${netrcPath}`;
+  let credentials = memCache.get<GoAuthCredential[] | undefined>(cacheKey);
+  if (!credentials) {
+    try {
+      credentials = parseNetrc(await readSystemFile(netrcPath, 'utf8'));
+    } catch (err) {
+      logger.trace({ err, netrcPath }, 'GOAUTH: no netrc file');
+      credentials = [];
+    }
+    memCache.set(cacheKey, credentials);
+  }
+  return credentials;
+}
+
+/**
+ * Runs a GOAUTH command for a URL without cached credentials, and caches its credentials.
+ */
+async function getCommandCredentials(
+  command: string,
+  url: string,
+): Promise<GoAuthCredential[]> {
+  const cacheKey = `goauth:command:${command}`;
+  const cache = memCache.get<CommandCache | undefined>(cacheKey) ?? {
+    credentials: [],
+    origins: [],
+  };
+  const origin = parseUrl(url)!.origin;
+  if (
+    findCredential(cache.credentials, url) ||
+    cache.origins.includes(origin)
+  ) {
+    return cache.credentials;
+  }
+
+  cache.origins.push(origin);
+  try {
+    const { stdout } = await exec(`${command} ${quote(url)}`, {
+      timeout: commandTimeout,
+    });
+    cache.credentials.push(...parseGoAuthCommandOutput(stdout));
+  } catch (err) {
+    logger.debug({ err, command }, 'GOAUTH: command failed');
+  }
+  memCache.set(cacheKey, cache);
+  return cache.credentials;
+}
+
+function findCredential(
+  credentials: GoAuthCredential[],
+  url: string,
+): GoAuthCredential | undefined {
+  // the longest matching prefix wins, as with `go`
+  return credentials
+    .filter(({ prefix }) => {
+      const trimmed = prefix.replace(regEx(/\/$/), '');
+      return (
+        url === trimmed ||
+        url.startsWith(`${trimmed}/`) ||
+        url.startsWith(`${trimmed}?`)
+      );
+    })
+    .sort((a, b) => b.prefix.length - a.prefix.length)[0];
+}
+
+/**
+ * Returns the headers which the `go` command would send with a request to `url`, according to `GOAUTH`.
+ *
+ * Credentials configured with `hostRules` take precedence.
+ */
+export async function getGoAuthHeaders(
+  url: string,
+  hostType: string,
+): Promise<Record<string, string> | undefined> {
+  const parsedUrl = parseUrl(url);
+  // the `go` command only sends credentials over HTTPS
+  if (parsedUrl?.protocol !== 'https:') {
+    return undefined;
+  }
+
+  const hostRule = hostRules.find({ hostType, url });
+  if (hostRule.token ?? hostRule.username ?? hostRule.password) {
+    return undefined;
+  }
+
+  const commands = parseGoauth();
+  if (commands.some(({ type }) => type === 'off')) {
+    return undefined;
+  }
+
+  for (const command of commands) {
+    let credentials: GoAuthCredential[] = [];
+    if (command.type === 'netrc') {
+      credentials = await getNetrcCredentials();
+    } else if (command.type === 'command') {
+      credentials = await getCommandCredentials(
+        command.command,
+        `${parsedUrl.origin}${parsedUrl.pathname}`,
+      );
+    } else {
+      logger.once.debug('GOAUTH: `git` authentication is not supported');
+    }
+
+    const credential = findCredential(credentials, url);
+    if (credential) {
+      for (const value of Object.values(credential.headers)) {
+        addSecretForSanitizing(value);
+      }
+      return credential.headers;
+    }
+  }
+
+  return undefined;
+}
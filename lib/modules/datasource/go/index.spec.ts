@@ -37,11 +37,14 @@ vi.mock('./releases-direct.ts', () => {
 });
 
 const getReleasesProxyMock = vi.fn();
+const resolveModulePathMock = vi.fn();
 vi.mock('./releases-goproxy.ts', () => {
   return {
     GoProxyDatasource: vi.fn(
       class {
-        getReleases = () => getReleasesProxyMock();
+        getReleases = (...args: any[]) => getReleasesProxyMock(...args);
+        resolveModulePath = (...args: any[]) =>
+          resolveModulePathMock(...args);
       },
     ),
   };
@@ -68,6 +71,55 @@ describe('modules/datasource/go/index', () => {
       expect(getReleasesProxyMock).toHaveBeenCalled();
       expect(getReleasesDirectMock).not.toHaveBeenCalled();
     });
+
+    it('fetches the releases of the module providing a package', async () => {
+      resolveModulePathMock.mockResolvedValueOnce('golang.org/x/tools');
+      getReleasesProxyMock.mockResolvedValueOnce({
+        releases: [{ version: 'v0.21.0' }, { version: 'v2.0.0' }],
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'golang.org/x/tools/cmd/stringer',
+        currentValue: 'v0.21.0',
+        goPackagePath: true,
+      });
+
+      expect(res).toEqual({
+        releases: [{ version: 'v0.21.0' }, { version: 'v2.0.0' }],
+        lookupName: 'golang.org/x/tools',
+      });
+      expect(resolveModulePathMock).toHaveBeenCalledExactlyOnceWith(
+        'golang.org/x/tools/cmd/stringer',
+        'v0.21.0',
+      );
+      expect(getReleasesProxyMock).toHaveBeenCalledExactlyOnceWith({
+        packageName: 'golang.org/x/tools',
+        currentValue: 'v0.21.0',
+        goPackagePath: true,
+      });
+    });
+
+    it('skips other major versions of an unresolved package', async () => {
+      resolveModulePathMock.mockResolvedValueOnce(null);
+      getReleasesProxyMock.mockResolvedValueOnce({
+        releases: [
+          { version: 'v1.2.0' },
+          { version: 'v1.3.0' },
+          { version: 'v2.0.0' },
+          { version: 'not-a-version' },
+        ],
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'example.com/private/tools/cmd/gen',
+        currentValue: 'v1.2.0',
+        goPackagePath: true,
+      });
+
+      expect(res).toEqual({
+        releases: [{ version: 'v1.2.0' }, { version: 'v1.3.0' }],
+      });
+    });
   });
 
   describe('getDigest', () => {
@@ -210,6 +262,29 @@ describe('modules/datasource/go/index', () => {
       expect(res).toBe('123');
     });
 
+    it('returns the digest of the head of followBranch', async () => {
+      getReleasesProxyMock.mockResolvedValueOnce({
+        releases: [
+          {
+            version: 'v1.4.1-0.20240601103045-0123456789ab',
+            newDigest: '0123456789ab',
+          },
+        ],
+      });
+
+      const res = await datasource.getDigest(
+        {
+          packageName: 'github.com/foo/bar',
+          currentValue: 'v1.4.1-0.20240101000000-abcdef123456',
+          followBranch: 'release-1.x',
+        },
+        'v1.4.1-0.20240101000000-abcdef123456',
+      );
+
+      expect(res).toBe('0123456789ab');
+      expect(getDigestGithubMock).not.toHaveBeenCalled();
+    });
+
     describe('GOPROXY', () => {
       afterEach(() => {
         delete process.env.GOPROXY;
@@ -5,7 +5,7 @@ import { getEnv } from '../../../util/env.ts';
 import { regEx } from '../../../util/regex.ts';
 import { addSecretForSanitizing } from '../../../util/sanitize.ts';
 import { parseUrl } from '../../../util/url.ts';
-import { id as semverId } from '../../versioning/semver/index.ts';
+import { api as semver, id as semverId } from '../../versioning/semver/index.ts';
 import { BitbucketTagsDatasource } from '../bitbucket-tags/index.ts';
 import { Datasource } from '../datasource.ts';
 import { ForgejoTagsDatasource } from '../forgejo-tags/index.ts';
@@ -56,19 +56,66 @@ export class GoDatasource extends Datasource {
   private _getReleases(
     config: GetReleasesConfig,
   ): Promise<ReleaseResult | null> {
+    if (config.goPackagePath) {
+      return this.getPackageReleases(config);
+    }
     return this.goproxy.getReleases(config);
   }
 
+  /**
+   * Looks up the releases of the module which provides a package, and returns its path as `lookupName`.
+   *
+   * Other major versions have a module path of their own, so they are left out if the module path can't be resolved.
+   */
+  private async getPackageReleases(
+    config: GetReleasesConfig,
+  ): Promise<ReleaseResult | null> {
+    const { packageName, currentValue } = config;
+    const modulePath = currentValue
+      ? await this.goproxy.resolveModulePath(packageName, currentValue)
+      : null;
+    if (modulePath) {
+      const res = await this.goproxy.getReleases({
+        ...config,
+        packageName: modulePath,
+      });
+      return res && { ...res, lookupName: modulePath };
+    }
+
+    logger.debug(
+      `Could not resolve the module of ${packageName}, skipping other major versions`,
+    );
+    const res = await this.goproxy.getReleases(config);
+    if (!res || !currentValue || !semver.isVersion(currentValue)) {
+      return res;
+    }
+    const currentMajor = semver.getMajor(currentValue);
+    return {
+      ...res,
+      releases: res.releases.filter(
+        ({ version }) =>
+          semver.isVersion(version) &&
+          semver.getMajor(version) === currentMajor,
+      ),
+    };
+  }
+
   getReleases(config: GetReleasesConfig): Promise<ReleaseResult | null> {
     const constraintsFilteringKey =
       config.constraintsFiltering && config.constraintsFiltering !== 'none'
         ? `@@${config.constraintsFiltering}`
         : '';
+    const followBranchKey = config.followBranch
+      ? `@@branch=${config.followBranch}`
+      : '';
+    const goPackagePathKey = config.goPackagePath
+      ? `@@package@${config.currentValue}`
+      : '';
     return withCache(
       {
         namespace: `datasource-${GoDatasource.id}`,
         // TODO: types (#22198)
-        key: `getReleases:${config.packageName}@@${constraintsFilteringKey}`,
+        key: `getReleases:${config.packageName}@@${constraintsFilteringKey}${followBranchKey}${goPackagePathKey}`,
         fallback: true,
       },
       () => this._getReleases(config),
@@ -84,9 +131,11 @@ export class GoDatasource extends Datasource {
    * This function will:
    *  - Determine the source URL for the module
    *  - Call the respective getDigest in github to retrieve the commit hash
+   *
+   * With `followBranch`, the head of that branch is resolved instead of the default branch.
    */
   private async _getDigest(
-    { packageName }: DigestConfig,
+    { packageName, currentValue, followBranch }: DigestConfig,
     newValue?: string,
   ): Promise<string | null> {
     if (parseGoproxy().some(({ url }) => url === 'off')) {
@@ -96,6 +145,15 @@ export class GoDatasource extends Datasource {
       return null;
     }
 
+    if (followBranch) {
+      const res = await this.getReleases({
+        packageName,
+        currentValue,
+        followBranch,
+      });
+      return res?.releases[0]?.newDigest ?? null;
+    }
+
     const source = await BaseGoDatasource.getDatasource(packageName);
     if (!source) {
       return null;
@@ -111,6 +169,12 @@ export class GoDatasource extends Datasource {
         : undefined;
 
     switch (source.datasource) {
+      case GoProxyDatasource.id: {
+        logger.debug(
+          `Skip digest fetch for ${packageName} served by the module proxy ${source.registryUrl}`,
+        );
+        return null;
+      }
       case ForgejoTagsDatasource.id: {
         return this.direct.forgejo.getDigest(source, tag);
       }
@@ -140,10 +204,13 @@ export class GoDatasource extends Datasource {
     config: DigestConfig,
     newValue?: string,
   ): Promise<string | null> {
+    const followBranchKey = config.followBranch
+      ? `@@branch=${config.followBranch}`
+      : '';
     return withCache(
       {
         namespace: `datasource-${GoDatasource.id}`,
-        key: `getDigest:${config.packageName}:${newValue}`,
+        key: `getDigest:${config.packageName}:${newValue}${followBranchKey}`,
         fallback: true,
       },
       () => this._getDigest(config, newValue),
@@ -0,0 +1,255 @@
+import { codeBlock } from 'common-tags';
+import type { Release, ReleaseResult } from '../types.ts';
+import {
+  applyDeprecation,
+  applyRetractions,
+  isRetracted,
+  parseModFileMetadata,
+  parseModule,
+  parseRetractions,
+} from './mod-file.ts';
+
+describe('modules/datasource/go/mod-file', () => {
+  describe('parseRetractions', () => {
+    it('returns empty list for go.mod without retractions', () => {
+      const goMod = codeBlock`
+        module example.com/foo
+
+        go 1.22
+
+        require example.com/bar v1.0.0
+      `;
+      expect(parseRetractions(goMod)).toEqual([]);
+    });
+
+    it('parses single versions and ranges with rationale', () => {
+      const goMod = codeBlock`
+        module example.com/foo
+
+        go 1.22
+
+        // Published accidentally.
+        retract v1.0.0
+
+        retract [v1.1.0, v1.1.5] // Contains a data race.
+
+        retract v1.2.0
+
+        // Broken build.
+        // Use v1.3.1 instead.
+        retract (
+        	v1.3.0
+        	// Leaks credentials.
+        	v1.4.0
+        	[v1.5.0, v1.5.2] // Wrong module path.
+        )
+      `;
+      expect(parseRetractions(goMod)).toEqual([
+        { low: 'v1.0.0', high: 'v1.0.0', rationale: 'Published accidentally.' },
+        { low: 'v1.1.0', high: 'v1.1.5', rationale: 'Contains a data race.' },
+        { low: 'v1.2.0', high: 'v1.2.0' },
+        {
+          low: 'v1.3.0',
+          high: 'v1.3.0',
+          rationale: 'Broken build.\nUse v1.3.1 instead.',
+        },
+        { low: 'v1.4.0', high: 'v1.4.0', rationale: 'Leaks credentials.' },
+        { low: 'v1.5.0', high: 'v1.5.2', rationale: 'Wrong module path.' },
+      ]);
+    });
+
+    it('does not use comments separated by a blank line', () => {
+      const goMod = codeBlock`
+        module example.com/foo
+
+        // Not related.
+
+        retract v1.0.0
+      `;
+      expect(parseRetractions(goMod)).toEqual([
+        { low: 'v1.0.0', high: 'v1.0.0' },
+      ]);
+    });
+
+    it('skips invalid versions', () => {
+      const goMod = codeBlock`
+        module example.com/foo
+
+        retract foo
+        retract [v1.0.0, bar]
+      `;
+      expect(parseRetractions(goMod)).toEqual([]);
+    });
+  });
+
+  describe('parseModule', () => {
+    it('parses the module path', () => {
+      expect(parseModule('module github.com/foo/bar\n')).toEqual({
+        module: 'github.com/foo/bar',
+      });
+    });
+
+    it('parses a deprecation notice before the module directive', () => {
+      const goMod = codeBlock`
+        // Package bar does things.
+        //
+        // Deprecated: use github.com/foo/baz instead.
+        // It is no longer maintained.
+        //
+        // See the changelog.
+        module "github.com/foo/bar"
+
+        go 1.22
+      `;
+      expect(parseModule(goMod)).toEqual({
+        module: 'github.com/foo/bar',
+        deprecation:
+          'use github.com/foo/baz instead.\nIt is no longer maintained.',
+      });
+    });
+
+    it('parses a deprecation notice on the module directive', () => {
+      expect(
+        parseModule('module github.com/foo/bar // Deprecated: unmaintained'),
+      ).toEqual({ module: 'github.com/foo/bar', deprecation: 'unmaintained' });
+    });
+
+    it('ignores comments which are not immediately before the module directive', () => {
+      const goMod = codeBlock`
+        // Deprecated: unmaintained
+
+        module github.com/foo/bar
+      `;
+      expect(parseModule(goMod)).toEqual({ module: 'github.com/foo/bar' });
+    });
+
+    it('requires the notice to start a paragraph', () => {
+      const goMod = codeBlock`
+        // This is not
+        // Deprecated: at the start of a paragraph
+        module github.com/foo/bar
+      `;
+      expect(parseModule(goMod)).toEqual({ module: 'github.com/foo/bar' });
+    });
+
+    it('returns null without module directive', () => {
+      expect(parseModule('go 1.22')).toBeNull();
+    });
+  });
+
+  describe('parseModFileMetadata', () => {
+    it('returns retractions', () => {
+      expect(parseModFileMetadata('retract v0.1.0 // oops')).toEqual({
+        retractions: [{ low: 'v0.1.0', high: 'v0.1.0', rationale: 'oops' }],
+      });
+    });
+
+    it('returns the module and its deprecation notice', () => {
+      expect(
+        parseModFileMetadata('module foo // Deprecated: unmaintained'),
+      ).toEqual({
+        retractions: [],
+        module: 'foo',
+        deprecation: 'unmaintained',
+      });
+    });
+  });
+
+  describe('applyDeprecation', () => {
+    it('marks the module as deprecated, but not its releases', () => {
+      const result: ReleaseResult = {
+        releases: [
+          { version: 'v1.0.0' },
+          {
+            version: 'v1.1.0',
+            isDeprecated: true,
+            deprecationMessage: 'Retracted by the module author',
+          },
+        ],
+      };
+
+      applyDeprecation(result, {
+        retractions: [],
+        module: 'github.com/foo/bar',
+        deprecation: 'unmaintained',
+      });
+
+      expect(result).toEqual({
+        deprecationMessage:
+          'The Go module `github.com/foo/bar` has been deprecated by its author:\n\n`unmaintained`',
+        releases: [
+          { version: 'v1.0.0' },
+          {
+            version: 'v1.1.0',
+            isDeprecated: true,
+            deprecationMessage: 'Retracted by the module author',
+          },
+        ],
+      });
+    });
+
+    it('does nothing without deprecation', () => {
+      const result: ReleaseResult = { releases: [{ version: 'v1.0.0' }] };
+
+      applyDeprecation(result, { retractions: [] });
+
+      expect(result).toEqual({ releases: [{ version: 'v1.0.0' }] });
+    });
+  });
+
+  describe('isRetracted', () => {
+    it.each`
+      version                                   | expected
+      ${'v1.0.9'}                               | ${false}
+      ${'v1.1.0'}                               | ${true}
+      ${'v1.1.3'}                               | ${true}
+      ${'v1.1.5'}                               | ${true}
+      ${'v1.1.6'}                               | ${false}
+      ${'v1.1.4-0.20240101000000-abcdefabcdef'} | ${true}
+      ${'not-a-version'}                        | ${false}
+    `(
+      'isRetracted("$version") === $expected',
+      ({ version, expected }: { version: string; expected: boolean }) => {
+        expect(isRetracted(version, { low: 'v1.1.0', high: 'v1.1.5' })).toBe(
+          expected,
+        );
+      },
+    );
+  });
+
+  describe('applyRetractions', () => {
+    it('marks retracted releases as deprecated', () => {
+      const releases: Release[] = [
+        { version: 'v1.0.0' },
+        { version: 'v1.1.0' },
+        { version: 'v1.2.0' },
+      ];
+
+      applyRetractions(releases, [
+        { low: 'v1.1.0', high: 'v1.1.0', rationale: 'Contains a data race.' },
+        { low: 'v1.2.0', high: 'v1.2.0' },
+      ]);
+
+      expect(releases).toEqual([
+        { version: 'v1.0.0' },
+        {
+          version: 'v1.1.0',
+          isDeprecated: true,
+          deprecationMessage:
+            'Retracted by the module author: Contains a data race.',
+        },
+        {
+          version: 'v1.2.0',
+          isDeprecated: true,
+          deprecationMessage: 'Retracted by the module author',
+        },
+      ]);
+    });
+
+    it('does nothing without retractions', () => {
+      const releases: Release[] = [{ version: 'v1.0.0' }];
+      applyRetractions(releases, []);
+      expect(releases).toEqual([{ version: 'v1.0.0' }]);
+    });
+  });
+});
@@ -0,0 +1,169 @@
+import semver from 'semver';
+import { getDirectives, parseGoMod } from '../../manager/gomod/parser.ts';
+import type { GoModDirective } from '../../manager/gomod/types.ts';
+import type { Release, ReleaseResult } from '../types.ts';
+import type { GoModFileMetadata, GoRetraction } from './types.ts';
+
+const deprecatedPrefix = 'Deprecated:';
+
+function toRetraction(
+  { args }: GoModDirective,
+  rationale: string,
+): GoRetraction | null {
+  const versions = args
+    .filter(({ type }) => type === 'word' || type === 'string')
+    .map(({ value }) => value);
+  const isRange = args[0]?.type === 'lbracket';
+  if (versions.length !== (isRange ? 2 : 1)) {
+    return null;
+  }
+  const [low, high = low] = versions;
+  if (!semver.valid(low) || !semver.valid(high)) {
+    return null;
+  }
+
+  const retraction: GoRetraction = { low, high };
+  if (rationale) {
+    retraction.rationale = rationale;
+  }
+  return retraction;
+}
+
+/**
+ * Parse the `retract` directives of a `go.mod` file.
+ *
+ * The rationale for a retraction is the comment on the same line or, if there isn't one, the comment block immediately before it.
+ *
+ * @see https://go.dev/ref/mod#go-mod-file-retract
+ */
+export function parseRetractions(goMod: string): GoRetraction[] {
+  const result: GoRetraction[] = [];
+
+  for (const statement of parseGoMod(goMod).statements) {
+    if (statement.verb !== 'retract') {
+      continue;
+    }
+    const directives =
+      statement.type === 'block' ? statement.directives : [statement];
+    for (const directive of directives) {
+      const { before, suffix } = directive.comments;
+      // entries without their own comment share the comment of the block
+      const comment =
+        before.length || statement.type === 'directive'
+          ? before
+          : statement.comments.before;
+      const retraction = toRetraction(directive, suffix ?? comment.join('\n'));
+      if (retraction) {
+        result.push(retraction);
+      }
+    }
+  }
+
+  return result;
+}
+
+export function isRetracted(
+  version: string,
+  { low, high }: GoRetraction,
+): boolean {
+  if (!semver.valid(version)) {
+    return false;
+  }
+  return semver.gte(version, low) && semver.lte(version, high);
+}
+
+/**
+ * Mark each release covered by a retraction as deprecated, using the retraction's rationale as the deprecation message.
+ */
+export function applyRetractions(
+  releases: Release[],
+  retractions: GoRetraction[],
+): void {
+  if (!retractions.length) {
+    return;
+  }
+
+  for (const release of releases) {
+    const retraction = retractions.find((r) => isRetracted(release.version, r));
+    if (!retraction) {
+      continue;
+    }
+
+    release.isDeprecated = true;
+    release.deprecationMessage = retraction.rationale
+      ? `Retracted by the module author: ${retraction.rationale}`
+      : 'Retracted by the module author';
+  }
+}
+
+/**
+ * Returns the paragraph of a comment which starts with `Deprecated:`, without that prefix.
+ */
+function getDeprecation(comment: string[]): string | undefined {
+  let paragraph: string[] = [];
+  for (const line of [...comment, '']) {
+    if (line) {
+      paragraph.push(line);
+      continue;
+    }
+    if (paragraph[0]?.startsWith(deprecatedPrefix)) {
+      const message = paragraph
+        .join('\n')
+        .slice(deprecatedPrefix.length)
+        .trim();
+      return message || undefined;
+    }
+    paragraph = [];
+  }
+  return undefined;
+}
+
+/**
+ * Parse the `module` directive of a `go.mod` file, along with its deprecation notice.
+ *
+ * The notice is a paragraph starting with `Deprecated:` in the comment block immediately before the directive, or in the comment on the same line.
+ *
+ * @see https://go.dev/ref/mod#go-mod-file-module-deprecation
+ */
+export function parseModule(
+  goMod: string,
+): { module: string; deprecation?: string } | null {
+  const directive = getDirectives(parseGoMod(goMod)).find(
+    ({ verb, inBlock }) => verb === 'module' && !inBlock,
+  );
+  const module = directive?.args[0]?.value;
+  if (!module) {
+    return null;
+  }
+
+  const { before, suffix } = directive.comments;
+  const deprecation =
+    getDeprecation(before) ?? (suffix ? getDeprecation([suffix]) : undefined);
+  return deprecation ? { module, deprecation } : { module };
+}
+
+/**
+ * Mark a module as deprecated.
+ *
+ * Its releases are left as they are, because deprecation applies to the module rather than to specific versions, unlike retractions.
+ */
+export function applyDeprecation(
+  result: ReleaseResult,
+  { module, deprecation }: GoModFileMetadata,
+): void {
+  if (!module || !deprecation) {
+    return;
+  }
+
+  result.deprecationMessage = `The Go module \`${module}\` has been deprecated by its author:\n\n\`${deprecation}\``;
+}
+
+/**
+ * Extract the metadata which a module author publishes in the `go.mod` file of a module version.
+ */
+export function parseModFileMetadata(goMod: string): GoModFileMetadata {
+  return {
+    retractions: parseRetractions(goMod),
+    ...parseModule(goMod),
+  };
+}
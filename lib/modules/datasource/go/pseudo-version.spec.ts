@@ -0,0 +1,35 @@
+import { buildPseudoVersion, getPseudoVersionBase } from './pseudo-version.ts';
+
+describe('modules/datasource/go/pseudo-version', () => {
+  describe('getPseudoVersionBase()', () => {
+    it.each`
+      version                                                | expected
+      ${'v0.0.0-20240101000000-abcdef123456'}                | ${{ major: 'v0', build: '' }}
+      ${'v2.0.0-20240101000000-abcdef123456'}                | ${{ major: 'v2', build: '' }}
+      ${'v1.4.1-0.20240101000000-abcdef123456'}              | ${{ major: 'v1', version: 'v1.4.0', build: '' }}
+      ${'v1.5.0-rc.1.0.20240101000000-abcdef123456'}         | ${{ major: 'v1', version: 'v1.5.0-rc.1', build: '' }}
+      ${'v3.2.1-0.20240101000000-abcdef123456+incompatible'} | ${{ major: 'v3', version: 'v3.2.0', build: '+incompatible' }}
+      ${'v1.4.0'}                                            | ${{ major: 'v1', version: 'v1.4.0', build: '' }}
+      ${'v3.0.0+incompatible'}                               | ${{ major: 'v3', version: 'v3.0.0', build: '+incompatible' }}
+      ${'1.4.0'}                                             | ${null}
+      ${'latest'}                                            | ${null}
+    `('getPseudoVersionBase("$version")', ({ version, expected }) => {
+      expect(getPseudoVersionBase(version)).toEqual(expected);
+    });
+  });
+
+  describe('buildPseudoVersion()', () => {
+    const time = '2024-06-01T12:30:45+02:00';
+    const hash = '0123456789abcdef0123456789abcdef01234567';
+
+    it.each`
+      base                                                          | expected
+      ${{ major: 'v0', build: '' }}                                 | ${'v0.0.0-20240601103045-0123456789ab'}
+      ${{ major: 'v1', version: 'v1.4.0', build: '' }}              | ${'v1.4.1-0.20240601103045-0123456789ab'}
+      ${{ major: 'v1', version: 'v1.5.0-rc.1', build: '' }}         | ${'v1.5.0-rc.1.0.20240601103045-0123456789ab'}
+      ${{ major: 'v3', version: 'v3.2.0', build: '+incompatible' }} | ${'v3.2.1-0.20240601103045-0123456789ab+incompatible'}
+    `('builds $expected', ({ base, expected }) => {
+      expect(buildPseudoVersion(base, time, hash)).toBe(expected);
+    });
+  });
+});
@@ -0,0 +1,101 @@
+import { DateTime } from 'luxon';
+import { regEx } from '../../../util/regex.ts';
+import { isVersion } from '../../versioning/semver/index.ts';
+
+/**
+ * The version a pseudo-version is derived from.
+ *
+ * @see https://go.dev/ref/mod#pseudo-versions
+ */
+export interface PseudoVersionBase {
+  /** the major version prefix, e.g. `v2` */
+  major: string;
+  /** the latest semantic version tag before the commit, if there is one */
+  version?: string;
+  /** build metadata, i.e. `+incompatible` */
+  build: string;
+}
+
+const noBaseRegex = regEx(
+  /^(?<major>v\d+)\.0\.0-\d{14}-[0-9a-f]{12}(?<build>\+incompatible)?$/,
+);
+
+const releaseBaseRegex = regEx(
+  /^v(?<major>\d+)\.(?<minor>\d+)\.(?<patch>\d+)-0\.\d{14}-[0-9a-f]{12}(?<build>\+incompatible)?$/,
+);
+
+const prereleaseBaseRegex = regEx(
+  /^(?<version>(?<major>v\d+)\.\d+\.\d+-[^+]+)\.0\.\d{14}-[0-9a-f]{12}(?<build>\+incompatible)?$/,
+);
+
+const buildRegex = regEx(/\+.*$/);
+
+/**
+ * Returns the base of a pseudo-version, or of a version which a new pseudo-version would be derived from.
+ */
+export function getPseudoVersionBase(
+  version: string,
+): PseudoVersionBase | null {
+  const noBase = noBaseRegex.exec(version)?.groups;
+  if (noBase) {
+    return { major: noBase.major, build: noBase.build ?? '' };
+  }
+
+  const release = releaseBaseRegex.exec(version)?.groups;
+  if (release && release.patch !== '0') {
+    const { major, minor, patch } = release;
+    return {
+      major: `v${major}`,
+      version: `v${major}.${minor}.${parseInt(patch, 10) - 1}`,
+      build: release.build ?? '',
+    };
+  }
+
+  const prerelease = prereleaseBaseRegex.exec(version)?.groups;
+  if (prerelease) {
+    return {
+      major: prerelease.major,
+      version: prerelease.version,
+      build: prerelease.build ?? '',
+    };
+  }
+
+  if (version.startsWith('v') && isVersion(version)) {
+    const build = buildRegex.exec(version)?.[0] ?? '';
+    return {
+      major: version.split('.')[0],
+      version: version.replace(buildRegex, ''),
+      build,
+    };
+  }
+
+  return null;
+}
+
+/**
+ * Builds the pseudo-version of a commit, following the rules of the `go` command:
+ *
+ * - `vX.0.0-yyyymmddhhmmss-abcdefabcdef` without a base version
+ * - `vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef` for a prerelease base version `vX.Y.Z-pre`
+ * - `vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef` for a release base version `vX.Y.Z`
+ *
+ * @see https://go.dev/ref/mod#pseudo-versions
+ */
+export function buildPseudoVersion(
+  { major, version, build }: PseudoVersionBase,
+  commitTime: string,
+  hash: string,
+): string {
+  const timestamp = DateTime.fromISO(commitTime, { zone: 'utc' }).toFormat(
+    'yyyyMMddHHmmss',
+  );
+  const revision = `${timestamp}-${hash.substring(0, 12)}`;
+  if (!version) {
+    return `${major}.0.0-${revision}${build}`;
+  }
+  if (version.includes('-')) {
+    return `${version}.0.${revision}${build}`;
+  }
+  const [minor, patch] = version.split('.').slice(1);
+  return `${major}.${minor}.${parseInt(patch, 10) + 1}-0.${revision}${build}`;
+}
@@ -13,6 +13,14 @@ const getDatasourceSpy = vi.spyOn(BaseGoDatasource, 'getDatasource');
 describe('modules/datasource/go/releases-direct', () => {
   const gitGetTags = vi.spyOn(GitTagsDatasource.prototype, 'getReleases');
   const githubGetTags = vi.spyOn(GithubTagsDatasource.prototype, 'getReleases');
+  const retrieveSourceModFileMetadata = vi.spyOn(
+    GoDirectDatasource.prototype,
+    'retrieveSourceModFileMetadata',
+  );
+
+  beforeEach(() => {
+    retrieveSourceModFileMetadata.mockResolvedValue({ retractions: [] });
+  });
 
   describe('getReleases', () => {
     it('returns null for null getDatasource result', async () => {
@@ -32,6 +40,199 @@ describe('modules/datasource/go/releases-direct', () => {
       ).rejects.toThrow('unknown');
     });
 
+    it('looks up modules served by a module proxy', async () => {
+      const modProxyLookup = vi.fn().mockResolvedValueOnce({
+        releases: [{ version: 'v1.0.0' }],
+      });
+      const proxiedDatasource = new GoDirectDatasource(modProxyLookup);
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'go-proxy',
+        packageName: 'go.example.com/lib',
+        registryUrl: 'https://proxy.example.com',
+      });
+
+      const res = await proxiedDatasource.getReleases({
+        packageName: 'go.example.com/lib',
+      });
+
+      expect(res).toEqual({ releases: [{ version: 'v1.0.0' }] });
+      expect(modProxyLookup).toHaveBeenCalledExactlyOnceWith(
+        'https://proxy.example.com',
+        { packageName: 'go.example.com/lib' },
+      );
+    });
+
+    it('returns null for module proxies without a lookup', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'go-proxy',
+        packageName: 'go.example.com/lib',
+        registryUrl: 'https://proxy.example.com',
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'go.example.com/lib',
+      });
+
+      expect(res).toBeNull();
+    });
+
+    it('builds the pseudo-version of the head of followBranch', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'github-tags',
+        packageName: 'golang/text',
+        registryUrl: 'https://github.com',
+      });
+      githubGetTags.mockResolvedValueOnce({
+        releases: [
+          { gitRef: 'v1.4.0', version: 'v1.4.0' },
+          { gitRef: 'v1.5.0', version: 'v1.5.0' },
+          { gitRef: 'v2.0.0', version: 'v2.0.0' },
+          { gitRef: 'latest', version: 'latest' },
+        ],
+      });
+      const sha = '0123456789abcdef0123456789abcdef01234567';
+      httpMock
+        .scope('https://api.github.com')
+        .get('/repos/golang/text/commits/release-1.x')
+        .reply(200, {
+          sha,
+          commit: { committer: { date: '2024-06-01T10:30:45Z' } },
+        })
+        .get(`/repos/golang/text/compare/v1.5.0...${sha}`)
+        .reply(200, { status: 'diverged' })
+        .get(`/repos/golang/text/compare/v1.4.0...${sha}`)
+        .reply(200, { status: 'ahead' });
+
+      const res = await datasource.getReleases({
+        packageName: 'golang.org/x/text',
+        currentValue: 'v1.5.1-0.20240101000000-abcdef123456',
+        followBranch: 'release-1.x',
+      });
+
+      expect(res).toEqual({
+        releases: [
+          {
+            version: 'v1.4.1-0.20240601103045-0123456789ab',
+            newDigest: '0123456789ab',
+            releaseTimestamp: '2024-06-01T10:30:45.000Z',
+          },
+        ],
+        sourceUrl: 'https://github.com/golang/text',
+      });
+    });
+
+    it('follows a branch of a gitlab repository', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'gitlab-tags',
+        packageName: 'group/lib',
+        registryUrl: 'https://gitlab.com',
+      });
+      const sha = '0123456789abcdef0123456789abcdef01234567';
+      httpMock
+        .scope('https://gitlab.com/api/v4/projects/group%2Flib')
+        .get('/repository/commits/main')
+        .reply(200, { id: sha, committed_date: '2024-06-01T10:30:45Z' })
+        .get('/repository/tags?per_page=100')
+        .reply(200, [
+          { name: 'v2.1.0-rc.1', commit: {} },
+          { name: 'v2.2.0', commit: {} },
+        ])
+        .get(`/repository/compare?from=${sha}&to=v2.2.0`)
+        .reply(200, { commits: [{ id: 'abc' }] })
+        .get(`/repository/compare?from=${sha}&to=v2.1.0-rc.1`)
+        .reply(200, { commits: [] });
+
+      const res = await datasource.getReleases({
+        packageName: 'gitlab.com/group/lib/v2',
+        followBranch: 'main',
+      });
+
+      expect(res?.releases).toEqual([
+        {
+          version: 'v2.1.0-rc.1.0.20240601103045-0123456789ab',
+          newDigest: '0123456789ab',
+          releaseTimestamp: '2024-06-01T10:30:45.000Z',
+        },
+      ]);
+    });
+
+    it('uses a v0.0.0 base if no tag is reachable from followBranch', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'github-tags',
+        packageName: 'golang/text',
+        registryUrl: 'https://github.com',
+      });
+      githubGetTags.mockResolvedValueOnce({ releases: [] });
+      httpMock
+        .scope('https://api.github.com')
+        .get('/repos/golang/text/commits/main')
+        .reply(200, {
+          sha: '0123456789abcdef0123456789abcdef01234567',
+          commit: { committer: { date: '2024-06-01T10:30:45Z' } },
+        });
+
+      const res = await datasource.getReleases({
+        packageName: 'golang.org/x/text',
+        followBranch: 'main',
+      });
+
+      expect(res?.releases[0].version).toBe(
+        'v0.0.0-20240601103045-0123456789ab',
+      );
+    });
+
+    it('compares only the latest tags with the head of followBranch', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'github-tags',
+        packageName: 'golang/text',
+        registryUrl: 'https://github.com',
+      });
+      githubGetTags.mockResolvedValueOnce({
+        releases: Array.from({ length: 12 }, (_, minor) => ({
+          gitRef: `v1.${minor}.0`,
+          version: `v1.${minor}.0`,
+        })),
+      });
+      const sha = '0123456789abcdef0123456789abcdef01234567';
+      const scope = httpMock
+        .scope('https://api.github.com')
+        .get('/repos/golang/text/commits/main')
+        .reply(200, {
+          sha,
+          commit: { committer: { date: '2024-06-01T10:30:45Z' } },
+        });
+      for (let minor = 11; minor > 1; minor -= 1) {
+        scope
+          .get(`/repos/golang/text/compare/v1.${minor}.0...${sha}`)
+          .reply(200, { status: 'diverged' });
+      }
+
+      const res = await datasource.getReleases({
+        packageName: 'golang.org/x/text',
+        followBranch: 'main',
+      });
+
+      expect(res?.releases[0].version).toBe(
+        'v0.0.0-20240601103045-0123456789ab',
+      );
+    });
+
+    it('returns null for followBranch on other hosts', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'gitea-tags',
+        packageName: 'golang/text',
+        registryUrl: 'https://gitea.com',
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'gitea.com/golang/text',
+        currentValue: 'v1.4.1-0.20240101000000-abcdef123456',
+        followBranch: 'release-1.x',
+      });
+
+      expect(res).toBeNull();
+    });
+
     it('processes real data', async () => {
       getDatasourceSpy.mockResolvedValueOnce({
         datasource: 'github-tags',
@@ -422,5 +623,138 @@ describe('modules/datasource/go/releases-direct', () => {
         { version: 'v3.0.0', gitRef: 'b/v3.0.0' },
       ]);
     });
+
+    it('marks modules deprecated in the go.mod of their latest release', async () => {
+      const source = {
+        datasource: 'github-tags',
+        packageName: 'x/text',
+        registryUrl: 'https://github.com',
+      };
+      getDatasourceSpy.mockResolvedValueOnce(source);
+      githubGetTags.mockResolvedValueOnce({
+        releases: [
+          { version: 'b/v1.0.0', gitRef: 'b/v1.0.0' },
+          { version: 'b/v1.1.0', gitRef: 'b/v1.1.0' },
+          { version: 'b/v1.2.0-rc.1', gitRef: 'b/v1.2.0-rc.1' },
+          { version: 'b/v2.0.0', gitRef: 'b/v2.0.0' },
+        ],
+      });
+      retrieveSourceModFileMetadata.mockResolvedValueOnce({
+        retractions: [],
+        module: 'github.com/x/text/b',
+        deprecation: 'use github.com/x/text/c instead',
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/x/text/b',
+      });
+
+      expect(res?.deprecationMessage).toBe(
+        'The Go module `github.com/x/text/b` has been deprecated by its author:\n\n`use github.com/x/text/c instead`',
+      );
+      expect(res?.releases[0]).toEqual({
+        version: 'v1.0.0',
+        gitRef: 'b/v1.0.0',
+      });
+      expect(retrieveSourceModFileMetadata).toHaveBeenCalledExactlyOnceWith(
+        source,
+        'b/v1.1.0',
+        'b/',
+      );
+    });
+
+    it('ignores the go.mod of another module', async () => {
+      getDatasourceSpy.mockResolvedValueOnce({
+        datasource: 'github-tags',
+        packageName: 'x/text',
+        registryUrl: 'https://github.com',
+      });
+      githubGetTags.mockResolvedValueOnce({
+        releases: [{ version: 'v1.0.0', gitRef: 'v1.0.0' }],
+      });
+      retrieveSourceModFileMetadata.mockResolvedValueOnce({
+        retractions: [],
+        module: 'github.com/y/text',
+        deprecation: 'unmaintained',
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/x/text',
+      });
+
+      expect(res?.deprecationMessage).toBeUndefined();
+    });
+  });
+
+  describe('retrieveSourceModFileMetadata', () => {
+    beforeEach(() => {
+      retrieveSourceModFileMetadata.mockReset();
+    });
+
+    it('reads go.mod at the given tag', async () => {
+      httpMock
+        .scope('https://api.github.com')
+        .get('/repos/x/text/contents/b/go.mod?ref=b%2Fv1.1.0')
+        .reply(
+          200,
+          '// Deprecated: use github.com/x/text/c instead\nmodule github.com/x/text/b\n',
+        );
+
+      const res = await datasource.retrieveSourceModFileMetadata(
+        {
+          datasource: 'github-tags',
+          packageName: 'x/text',
+          registryUrl: 'https://github.com',
+        },
+        'b/v1.1.0',
+        'b/',
+      );
+
+      expect(res).toEqual({
+        retractions: [],
+        module: 'github.com/x/text/b',
+        deprecation: 'use github.com/x/text/c instead',
+      });
+    });
+
+    it('reads go.mod of a gitlab repository at the given tag', async () => {
+      httpMock
+        .scope('https://gitlab.com/api/v4/projects/group%2Flib')
+        .get('/repository/files/go.mod/raw?ref=v1.1.0')
+        .reply(
+          200,
+          'module gitlab.com/group/lib // Deprecated: unmaintained\n',
+        );
+
+      const res = await datasource.retrieveSourceModFileMetadata(
+        {
+          datasource: 'gitlab-tags',
+          packageName: 'group/lib',
+          registryUrl: 'https://gitlab.com',
+        },
+        'v1.1.0',
+        '',
+      );
+
+      expect(res).toEqual({
+        retractions: [],
+        module: 'gitlab.com/group/lib',
+        deprecation: 'unmaintained',
+      });
+    });
+
+    it('returns null for other hosts', async () => {
+      const res = await datasource.retrieveSourceModFileMetadata(
+        {
+          datasource: 'gitea-tags',
+          packageName: 'group/lib',
+          registryUrl: 'https://gitea.com',
+        },
+        'v1.1.0',
+        '',
+      );
+
+      expect(res).toBeNull();
+    });
   });
 });
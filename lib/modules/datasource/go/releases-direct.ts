@@ -1,6 +1,11 @@
+import semver from 'semver';
 import { logger } from '../../../logger/index.ts';
 import { withCache } from '../../../util/cache/package/with-cache.ts';
+import { getApiBaseUrl } from '../../../util/github/url.ts';
+import { GitlabHttp } from '../../../util/http/gitlab.ts';
 import { regEx } from '../../../util/regex.ts';
+import { asTimestamp } from '../../../util/timestamp.ts';
+import { joinUrlParts } from '../../../util/url.ts';
 import { BitbucketTagsDatasource } from '../bitbucket-tags/index.ts';
 import { Datasource } from '../datasource.ts';
 import { ForgejoTagsDatasource } from '../forgejo-tags/index.ts';
@@ -8,9 +13,40 @@ import { GitTagsDatasource } from '../git-tags/index.ts';
 import { GiteaTagsDatasource } from '../gitea-tags/index.ts';
 import { GithubTagsDatasource } from '../github-tags/index.ts';
 import { GitlabTagsDatasource } from '../gitlab-tags/index.ts';
+import { getDepHost as getGitlabDepHost } from '../gitlab-tags/util.ts';
 import type { GetReleasesConfig, Release, ReleaseResult } from '../types.ts';
 import { BaseGoDatasource } from './base.ts';
-import { getSourceUrl } from './common.ts';
+import { getSourceUrl, goProxyDatasourceId } from './common.ts';
+import { applyDeprecation, parseModFileMetadata } from './mod-file.ts';
+import type { PseudoVersionBase } from './pseudo-version.ts';
+import { buildPseudoVersion, getPseudoVersionBase } from './pseudo-version.ts';
+import {
+  GithubCommit,
+  GithubComparison,
+  GitlabCommit,
+  GitlabComparison,
+} from './schema.ts';
+import type { DataSource, GoModFileMetadata } from './types.ts';
+
+const majorSuffixRegex = regEx(/\/v(?<major>\d+)$/);
+
+/**
+ * Each candidate tag costs a compare API call, and tags which aren't reachable are usually on release branches, so only the latest tags are compared.
+ */
+const maxReachableTagCandidates = 10;
+
+interface BranchHead {
+  sha: string;
+  commitTime: string;
+}
+
+/**
+ * Looks up the releases of a module from the Go module proxy at `registryUrl`.
+ */
+export type GoModProxyLookup = (
+  registryUrl: string,
+  config: GetReleasesConfig,
+) => Promise<ReleaseResult | null>;
 
 /**
  * This function tries to select tags with longest prefix could be constructed from `packageName`.
@@ -58,6 +94,26 @@ function filterByPrefix(packageName: string, releases: Release[]): Release[] {
   return releases.filter((release) => release.version.startsWith('v'));
 }
 
+/**
+ * Returns the latest stable release within the major version of the module path.
+ */
+function getLatestRelease(
+  packageName: string,
+  releases: Release[],
+): Release | undefined {
+  const major = majorSuffixRegex.exec(packageName)?.groups?.major;
+  return releases
+    .filter(({ version }) => {
+      if (!semver.valid(version) || semver.prerelease(version)) {
+        return false;
+      }
+      const releaseMajor = semver.major(version);
+      return major ? releaseMajor === parseInt(major, 10) : releaseMajor < 2;
+    })
+    .sort((a, b) => semver.compare(a.version, b.version))
+    .at(-1);
+}
+
 export class GoDirectDatasource extends Datasource {
   static readonly id = 'go-direct';
 
@@ -68,8 +124,13 @@ export class GoDirectDatasource extends Datasource {
   gitlab: GitlabTagsDatasource;
   bitbucket: BitbucketTagsDatasource;
 
-  constructor() {
+  private readonly gitlabHttp = new GitlabHttp(GitlabTagsDatasource.id);
+
+  private readonly modProxyLookup: GoModProxyLookup | undefined;
+
+  constructor(modProxyLookup?: GoModProxyLookup) {
     super(GoDirectDatasource.id);
+    this.modProxyLookup = modProxyLookup;
     this.git = new GitTagsDatasource();
     this.github = new GithubTagsDatasource();
     this.gitlab = new GitlabTagsDatasource();
@@ -105,6 +166,18 @@ export class GoDirectDatasource extends Datasource {
       return null;
     }
 
+    if (source.datasource === goProxyDatasourceId) {
+      // `go-import` meta with the `mod` VCS: the module proxy knows the module's own versions
+      if (!this.modProxyLookup || !source.registryUrl) {
+        return null;
+      }
+      return await this.modProxyLookup(source.registryUrl, config);
+    }
+
+    if (config.followBranch) {
+      return this.getBranchReleases(source, config);
+    }
+
     switch (source.datasource) {
       case ForgejoTagsDatasource.id: {
         res = await this.forgejo.getReleases(source);
@@ -143,18 +216,235 @@ export class GoDirectDatasource extends Datasource {
 
     const sourceUrl = res.sourceUrl ?? getSourceUrl(source) ?? null;
 
-    return {
+    const result: ReleaseResult = {
       ...res,
       releases: filterByPrefix(packageName, res.releases),
       sourceUrl,
     };
+    await this.applySourceDeprecation(source, packageName, result);
+    return result;
+  }
+
+  /**
+   * Retrieve the metadata of the `go.mod` file in `directory` of a repository, at the tag `gitRef`.
+   *
+   * Only GitHub and GitLab repositories are supported, `null` is returned for other hosts.
+   */
+  async retrieveSourceModFileMetadata(
+    source: DataSource,
+    gitRef: string,
+    directory: string,
+  ): Promise<GoModFileMetadata | null> {
+    const ref = encodeURIComponent(gitRef);
+    switch (source.datasource) {
+      case GithubTagsDatasource.id: {
+        const { registryUrl, packageName: repo } = source;
+        const url = `${getApiBaseUrl(registryUrl)}repos/${repo}/contents/${directory}go.mod?ref=${ref}`;
+        const { body } = await this.github.http.getRawTextFile(url);
+        return parseModFileMetadata(body);
+      }
+      case GitlabTagsDatasource.id: {
+        const file = encodeURIComponent(`${directory}go.mod`);
+        const url = `${this.getGitlabProjectUrl(source)}/repository/files/${file}/raw?ref=${ref}`;
+        const { body } = await this.gitlabHttp.getText(url);
+        return parseModFileMetadata(body);
+      }
+      default: {
+        return null;
+      }
+    }
+  }
+
+  /**
+   * Reads the deprecation notice of a module from the `go.mod` file of its latest release.
+   */
+  private async applySourceDeprecation(
+    source: DataSource,
+    packageName: string,
+    result: ReleaseResult,
+  ): Promise<void> {
+    const latest = getLatestRelease(packageName, result.releases);
+    if (!latest?.gitRef) {
+      return;
+    }
+
+    // the tag prefix of a module is the directory it lives in
+    const directory = latest.gitRef.slice(0, -latest.version.length);
+    try {
+      const metadata = await this.retrieveSourceModFileMetadata(
+        source,
+        latest.gitRef,
+        directory,
+      );
+      if (metadata?.module === packageName) {
+        applyDeprecation(result, metadata);
+      }
+    } catch (err) {
+      logger.trace(
+        { err, packageName },
+        `Can't obtain \`go.mod\` of ${latest.gitRef}`,
+      );
+    }
+  }
+
+  private async getGithubBranchHead(
+    { registryUrl, packageName: repo }: DataSource,
+    branch: string,
+  ): Promise<BranchHead> {
+    const url = `${getApiBaseUrl(registryUrl)}repos/${repo}/commits/${encodeURIComponent(branch)}`;
+    const { body } = await this.github.http.getJson(url, GithubCommit);
+    return { sha: body.sha, commitTime: body.commit.committer.date };
+  }
+
+  private async isGithubTagReachable(
+    { registryUrl, packageName: repo }: DataSource,
+    gitRef: string,
+    sha: string,
+  ): Promise<boolean> {
+    const url = `${getApiBaseUrl(registryUrl)}repos/${repo}/compare/${encodeURIComponent(gitRef)}...${sha}`;
+    const { body } = await this.github.http.getJson(url, GithubComparison);
+    return body.status === 'ahead' || body.status === 'identical';
+  }
+
+  private getGitlabProjectUrl({
+    registryUrl,
+    packageName,
+  }: DataSource): string {
+    return joinUrlParts(
+      getGitlabDepHost(registryUrl),
+      'api/v4/projects',
+      encodeURIComponent(packageName),
+    );
+  }
+
+  private async getGitlabBranchHead(
+    source: DataSource,
+    branch: string,
+  ): Promise<BranchHead> {
+    const url = `${this.getGitlabProjectUrl(source)}/repository/commits/${encodeURIComponent(branch)}`;
+    const { body } = await this.gitlabHttp.getJson(url, GitlabCommit);
+    return { sha: body.id, commitTime: body.committed_date };
+  }
+
+  private async isGitlabTagReachable(
+    source: DataSource,
+    gitRef: string,
+    sha: string,
+  ): Promise<boolean> {
+    // the commits of the tag which the head doesn't contain
+    const url = `${this.getGitlabProjectUrl(source)}/repository/compare?from=${sha}&to=${encodeURIComponent(gitRef)}`;
+    const { body } = await this.gitlabHttp.getJson(url, GitlabComparison);
+    return body.commits.length === 0;
+  }
+
+  private async _getReachableTag(
+    source: DataSource,
+    packageName: string,
+    sha: string,
+  ): Promise<Release | null> {
+    const isGithub = source.datasource === GithubTagsDatasource.id;
+    const res = isGithub
+      ? await this.github.getReleases(source)
+      : await this.gitlab.getReleases(source);
+    const major = majorSuffixRegex.exec(packageName)?.groups?.major;
+    const tags = filterByPrefix(packageName, res?.releases ?? [])
+      .filter(({ version, gitRef }) => {
+        if (!gitRef || !version.startsWith('v') || !semver.valid(version)) {
+          return false;
+        }
+        const releaseMajor = semver.major(version);
+        return major ? releaseMajor === parseInt(major, 10) : releaseMajor < 2;
+      })
+      .sort((a, b) => semver.rcompare(a.version, b.version));
+
+    for (const tag of tags.slice(0, maxReachableTagCandidates)) {
+      const reachable = isGithub
+        ? await this.isGithubTagReachable(source, tag.gitRef!, sha)
+        : await this.isGitlabTagReachable(source, tag.gitRef!, sha);
+      if (reachable) {
+        return tag;
+      }
+    }
+    if (tags.length > maxReachableTagCandidates) {
+      logger.debug(
+        { packageName, tags: tags.length },
+        `go: none of the latest ${maxReachableTagCandidates} tags is reachable from ${sha}, skipping older tags`,
+      );
+    }
+    return null;
+  }
+
+  /**
+   * Returns the latest tag of the module which is reachable from the commit `sha`.
+   */
+  private getReachableTag(
+    source: DataSource,
+    packageName: string,
+    sha: string,
+  ): Promise<Release | null> {
+    return withCache(
+      {
+        namespace: `datasource-${GoDirectDatasource.id}`,
+        key: `reachable-tag:${source.registryUrl}:${source.packageName}:${packageName}@${sha}`,
+        ttlMinutes: 24 * 60,
+      },
+      () => this._getReachableTag(source, packageName, sha),
+    );
+  }
+
+  /**
+   * Builds the pseudo-version of the head of `followBranch`.
+   *
+   * Like the `go` command, the base version is the latest tag of the module which is reachable from the head, so the commits of the branch are compared with the tags.
+   * Without a module proxy, this is only supported for GitHub and GitLab repositories.
+   */
+  private async getBranchReleases(
+    source: DataSource,
+    { packageName, followBranch }: GetReleasesConfig,
+  ): Promise<ReleaseResult | null> {
+    let head: BranchHead;
+    if (source.datasource === GithubTagsDatasource.id) {
+      head = await this.getGithubBranchHead(source, followBranch!);
+    } else if (source.datasource === GitlabTagsDatasource.id) {
+      head = await this.getGitlabBranchHead(source, followBranch!);
+    } else {
+      logger.debug(
+        { packageName, datasource: source.datasource },
+        'go: following a branch without a module proxy is only supported for GitHub and GitLab',
+      );
+      return null;
+    }
+
+    const tag = await this.getReachableTag(source, packageName, head.sha);
+    const base: PseudoVersionBase = tag
+      ? getPseudoVersionBase(tag.version)!
+      : {
+          major: `v${majorSuffixRegex.exec(packageName)?.groups?.major ?? 0}`,
+          build: '',
+        };
+
+    const release: Release = {
+      version: buildPseudoVersion(base, head.commitTime, head.sha),
+      newDigest: head.sha.substring(0, 12),
+    };
+    const releaseTimestamp = asTimestamp(head.commitTime);
+    if (releaseTimestamp) {
+      release.releaseTimestamp = releaseTimestamp;
+    }
+    return {
+      releases: [release],
+      sourceUrl: getSourceUrl(source),
+    };
   }
 
   getReleases(config: GetReleasesConfig): Promise<ReleaseResult | null> {
+    const followBranchKey = config.followBranch
+      ? `@@branch=${config.followBranch}`
+      : '';
     return withCache(
       {
         namespace: `datasource-${GoDirectDatasource.id}`,
-        key: config.packageName,
+        key: `${config.packageName}${followBranchKey}`,
         fallback: true,
       },
       () => this._getReleases(config),
@@ -7,6 +7,7 @@ import * as githubGraphql from '../../../util/github/graphql/index.ts';
 import type { Timestamp } from '../../../util/timestamp.ts';
 import { GithubReleasesDatasource } from '../github-releases/index.ts';
 import { GithubTagsDatasource } from '../github-tags/index.ts';
+import { GoDirectDatasource } from './releases-direct.ts';
 import { GoProxyDatasource, getTagPrefix } from './releases-goproxy.ts';
 
 const datasource = new GoProxyDatasource();
@@ -88,16 +89,141 @@ describe('modules/datasource/go/releases-goproxy', () => {
         releaseTimestamp: '2017-06-08T17:28:36.000Z',
       });
     });
+
+    it('retrieveModFileMetadata', async () => {
+      httpMock
+        .scope(baseUrl)
+        .get('/github.com/go-kit/kit/@v/v0.6.0.mod')
+        .reply(
+          200,
+          codeBlock`
+            module github.com/go-kit/kit
+
+            retract v0.5.0 // Published too early.
+          `,
+        );
+
+      const metadata = await datasource.retrieveModFileMetadata(
+        baseUrl,
+        packageName,
+        'v0.6.0',
+      );
+
+      expect(metadata).toEqual({
+        retractions: [
+          { low: 'v0.5.0', high: 'v0.5.0', rationale: 'Published too early.' },
+        ],
+      });
+    });
+  });
+
+  describe('resolveModulePath', () => {
+    const baseUrl = 'https://proxy.golang.org';
+    const info = { Version: 'v1.59.1', Time: '2024-06-09T08:19:52Z' };
+
+    afterEach(() => {
+      delete process.env.GOPROXY;
+      delete process.env.GOPRIVATE;
+    });
+
+    it('returns the longest module prefix of a package path', async () => {
+      httpMock
+        .scope(baseUrl)
+        .get(
+          '/github.com/golangci/golangci-lint/cmd/golangci-lint/@v/v1.59.1.info',
+        )
+        .reply(404)
+        .get('/github.com/golangci/golangci-lint/cmd/@v/v1.59.1.info')
+        .reply(410)
+        .get('/github.com/golangci/golangci-lint/@v/v1.59.1.info')
+        .reply(200, info);
+
+      expect(
+        await datasource.resolveModulePath(
+          'github.com/golangci/golangci-lint/cmd/golangci-lint',
+          'v1.59.1',
+        ),
+      ).toBe('github.com/golangci/golangci-lint');
+    });
+
+    it('encodes upper case letters', async () => {
+      httpMock
+        .scope(baseUrl)
+        .get('/github.com/!burnt!sushi/toml/@v/v1.59.1.info')
+        .reply(200, info);
+
+      expect(
+        await datasource.resolveModulePath(
+          'github.com/BurntSushi/toml',
+          'v1.59.1',
+        ),
+      ).toBe('github.com/BurntSushi/toml');
+    });
+
+    it('returns null if no prefix has the version', async () => {
+      httpMock
+        .scope(baseUrl)
+        .get('/example.com/foo/bar/@v/v1.0.0.info')
+        .reply(404)
+        .get('/example.com/foo/@v/v1.0.0.info')
+        .reply(404);
+
+      expect(
+        await datasource.resolveModulePath('example.com/foo/bar', 'v1.0.0'),
+      ).toBeNull();
+    });
+
+    it('returns null on proxy errors', async () => {
+      httpMock
+        .scope(baseUrl)
+        .get('/example.com/foo/bar/@v/v1.0.0.info')
+        .reply(500);
+
+      expect(
+        await datasource.resolveModulePath('example.com/foo/bar', 'v1.0.0'),
+      ).toBeNull();
+    });
+
+    it('returns null for private modules', async () => {
+      process.env.GOPRIVATE = 'example.com/private';
+
+      expect(
+        await datasource.resolveModulePath(
+          'example.com/private/tool',
+          'v1.0.0',
+        ),
+      ).toBeNull();
+    });
+
+    it('returns null without a module proxy', async () => {
+      process.env.GOPROXY = 'direct';
+
+      expect(
+        await datasource.resolveModulePath('example.com/foo/bar', 'v1.0.0'),
+      ).toBeNull();
+    });
   });
 
   describe('getReleases', () => {
     const baseUrl = 'https://proxy.golang.org';
 
     let githubQueryReleases: MockInstance<typeof githubGraphql.queryReleases>;
+    let retrieveModFileMetadata: MockInstance<
+      typeof datasource.retrieveModFileMetadata
+    >;
 
     beforeEach(() => {
       githubQueryReleases = vi.spyOn(githubGraphql, 'queryReleases');
       githubQueryReleases.mockResolvedValue([]);
+      retrieveModFileMetadata = vi.spyOn(
+        GoProxyDatasource.prototype,
+        'retrieveModFileMetadata',
+      );
+      retrieveModFileMetadata.mockResolvedValue({ retractions: [] });
+      vi.spyOn(
+        GoDirectDatasource.prototype,
+        'retrieveGithubModFileMetadata',
+      ).mockResolvedValue({ retractions: [] });
     });
 
     afterEach(() => {
@@ -200,6 +326,183 @@ describe('modules/datasource/go/releases-goproxy', () => {
       });
     });
 
+    it('resolves the head of followBranch', async () => {
+      process.env.GOPROXY = baseUrl;
+
+      httpMock
+        .scope(`${baseUrl}/github.com/google/btree`)
+        .get('/@v/release%2F1.x.info')
+        .reply(200, {
+          Version: 'v1.0.2-0.20240601103045-0123456789ab',
+          Time: '2024-06-01T10:30:45Z',
+          Origin: {
+            VCS: 'git',
+            URL: 'https://github.com/google/btree',
+            Ref: 'refs/heads/release/1.x',
+            Hash: '0123456789abcdef0123456789abcdef01234567',
+          },
+        });
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/google/btree',
+        followBranch: 'release/1.x',
+      });
+
+      expect(res).toEqual({
+        releases: [
+          {
+            version: 'v1.0.2-0.20240601103045-0123456789ab',
+            newDigest: '0123456789ab',
+            releaseTimestamp: '2024-06-01T10:30:45.000Z',
+          },
+        ],
+        sourceUrl: 'https://github.com/google/btree',
+      });
+    });
+
+    it('resolves a tagged head of followBranch', async () => {
+      process.env.GOPROXY = baseUrl;
+
+      httpMock
+        .scope(`${baseUrl}/github.com/google/btree`)
+        .get('/@v/main.info')
+        .reply(200, {
+          Version: 'v1.1.0',
+          Time: '2024-06-01T10:30:45Z',
+          Origin: { Hash: '0123456789abcdef0123456789abcdef01234567' },
+        });
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/google/btree',
+        followBranch: 'main',
+      });
+
+      expect(res?.releases).toEqual([
+        {
+          version: 'v1.1.0',
+          newDigest: '0123456789ab',
+          releaseTimestamp: '2024-06-01T10:30:45.000Z',
+        },
+      ]);
+    });
+
+    it('marks versions retracted by the latest go.mod as deprecated', async () => {
+      process.env.GOPROXY = baseUrl;
+
+      httpMock
+        .scope(`${baseUrl}/github.com/google/btree`)
+        .get('/@v/list')
+        .reply(
+          200,
+          codeBlock`
+            v1.0.0 2018-08-13T15:31:12Z
+            v1.0.1 2019-10-16T16:15:28Z
+            v1.0.2 2019-10-17T16:15:28Z
+          `,
+        )
+        .get('/@latest')
+        .reply(200, { Version: 'v1.0.2' })
+        .get('/v2/@v/list')
+        .reply(404);
+      retrieveModFileMetadata.mockResolvedValueOnce({
+        retractions: [
+          { low: 'v1.0.1', high: 'v1.0.1', rationale: 'Contains a data race.' },
+        ],
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/google/btree',
+      });
+
+      expect(retrieveModFileMetadata).toHaveBeenCalledWith(
+        baseUrl,
+        'github.com/google/btree',
+        'v1.0.2',
+      );
+      expect(res?.releases).toEqual([
+        {
+          version: 'v1.0.0',
+          releaseTimestamp: '2018-08-13T15:31:12.000Z',
+        },
+        {
+          version: 'v1.0.1',
+          releaseTimestamp: '2019-10-16T16:15:28.000Z',
+          isDeprecated: true,
+          deprecationMessage:
+            'Retracted by the module author: Contains a data race.',
+        },
+        {
+          version: 'v1.0.2',
+          releaseTimestamp: '2019-10-17T16:15:28.000Z',
+        },
+      ]);
+    });
+
+    it('marks modules deprecated by the latest go.mod', async () => {
+      process.env.GOPROXY = baseUrl;
+
+      httpMock
+        .scope(`${baseUrl}/github.com/google/btree`)
+        .get('/@v/list')
+        .reply(200, 'v1.0.0 2018-08-13T15:31:12Z')
+        .get('/@latest')
+        .reply(200, { Version: 'v1.0.0' })
+        .get('/v2/@v/list')
+        .reply(200, 'v2.0.0 2019-10-16T16:15:28Z')
+        .get('/v2/@latest')
+        .reply(200, { Version: 'v2.0.0' })
+        .get('/v3/@v/list')
+        .reply(404);
+      retrieveModFileMetadata.mockResolvedValueOnce({
+        retractions: [],
+        module: 'github.com/google/btree',
+        deprecation: 'use github.com/google/btree/v2',
+      });
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/google/btree',
+      });
+
+      expect(res?.deprecationMessage).toBe(
+        'The Go module `github.com/google/btree` has been deprecated by its author:\n\n`use github.com/google/btree/v2`',
+      );
+      expect(res?.releases).toEqual([
+        {
+          version: 'v1.0.0',
+          releaseTimestamp: '2018-08-13T15:31:12.000Z',
+        },
+        {
+          version: 'v2.0.0',
+          releaseTimestamp: '2019-10-16T16:15:28.000Z',
+        },
+      ]);
+    });
+
+    it('ignores errors fetching go.mod metadata', async () => {
+      process.env.GOPROXY = baseUrl;
+
+      httpMock
+        .scope(`${baseUrl}/github.com/google/btree`)
+        .get('/@v/list')
+        .reply(200, 'v1.0.0 2018-08-13T15:31:12Z')
+        .get('/@latest')
+        .reply(200, { Version: 'v1.0.0' })
+        .get('/v2/@v/list')
+        .reply(404);
+      retrieveModFileMetadata.mockRejectedValueOnce(new Error('unknown'));
+
+      const res = await datasource.getReleases({
+        packageName: 'github.com/google/btree',
+      });
+
+      expect(res?.releases).toEqual([
+        {
+          version: 'v1.0.0',
+          releaseTimestamp: '2018-08-13T15:31:12.000Z',
+        },
+      ]);
+    });
+
     it('resolves sourceUrl from goproxy Origin without calling the vanity domain', async () => {
       process.env.GOPROXY = baseUrl;
 
@@ -13,11 +13,13 @@ import { filterMap } from '../../../util/filter-map.ts';
 import { queryReleases } from '../../../util/github/graphql/index.ts';
 import { GithubHttp } from '../../../util/http/github.ts';
 import { HttpError } from '../../../util/http/index.ts';
+import type { HttpOptions } from '../../../util/http/types.ts';
 import * as p from '../../../util/promises.ts';
 import { newlineRegex, regEx } from '../../../util/regex.ts';
 import type { Timestamp } from '../../../util/timestamp.ts';
 import { asTimestamp } from '../../../util/timestamp.ts';
 import {
+  isHttpUrl,
   joinUrlParts,
   parseUrl,
   trimLeadingSlash,
@@ -28,10 +30,17 @@ import { Datasource } from '../datasource.ts';
 import { GithubReleasesDatasource } from '../github-releases/index.ts';
 import type { GetReleasesConfig, Release, ReleaseResult } from '../types.ts';
 import { BaseGoDatasource } from './base.ts';
-import { getSourceUrl } from './common.ts';
+import { getSourceUrl, goProxyDatasourceId } from './common.ts';
+import { getGoAuthHeaders } from './goauth.ts';
 import { parseGoproxy, parseNoproxy } from './goproxy-parser.ts';
 import { GoDirectDatasource } from './releases-direct.ts';
+import {
+  applyDeprecation,
+  applyRetractions,
+  parseModFileMetadata,
+} from './mod-file.ts';
 import { VersionInfo } from './schema.ts';
+import type { GoModFileMetadata } from './types.ts';
 
 /** TODO #42566 */
 const goVersionRegex = regEx(/^\s*go\s+(?<version>[^\s]+)\s*$/);
@@ -103,13 +112,15 @@ export function getTagPrefix(goModule: string, tags: Iterable<string>): string {
 }
 
 export class GoProxyDatasource extends Datasource {
-  static readonly id = 'go-proxy';
+  static readonly id = goProxyDatasourceId;
 
   constructor() {
     super(GoProxyDatasource.id);
   }
 
-  readonly direct = new GoDirectDatasource();
+  readonly direct = new GoDirectDatasource((registryUrl, config) =>
+    this.getProxyReleases(registryUrl, config),
+  );
 
   private readonly githubHttp = new GithubHttp(GithubReleasesDatasource.id);
 
@@ -143,11 +154,7 @@ export class GoProxyDatasource extends Datasource {
           break;
         }
 
-        const res = await this.getVersionsWithInfo(
-          url,
-          packageName,
-          config.constraintsFiltering,
-        );
+        const res = await this.getProxyReleases(url, config);
         if (res.releases.length) {
           result = res;
           servedByProxy = true;
@@ -192,6 +199,16 @@ export class GoProxyDatasource extends Datasource {
     return result;
   }
 
+  private getProxyReleases(
+    baseUrl: string,
+    { packageName, constraintsFiltering, followBranch }: GetReleasesConfig,
+  ): Promise<ReleaseResult> {
+    if (followBranch) {
+      return this.getBranchReleases(baseUrl, packageName, followBranch);
+    }
+    return this.getVersionsWithInfo(baseUrl, packageName, constraintsFiltering);
+  }
+
   /**
    * A Go proxy reports the commit time of the tagged commit as a version's `Time`, which can be much earlier than the point at which that version was released.
    *
@@ -270,6 +287,14 @@ export class GoProxyDatasource extends Datasource {
     return input.replace(regEx(/([A-Z])/g), (x) => `!${x.toLowerCase()}`);
   }
 
+  /**
+   * Returns the credentials which `GOAUTH` configures for a proxy URL.
+   */
+  private async getHttpOptions(url: string): Promise<HttpOptions> {
+    const headers = await getGoAuthHeaders(url, GoProxyDatasource.id);
+    return headers ? { headers } : {};
+  }
+
   async listVersions(baseUrl: string, packageName: string): Promise<Release[]> {
     const url = joinUrlParts(
       baseUrl,
@@ -277,7 +302,10 @@ export class GoProxyDatasource extends Datasource {
       '@v',
       'list',
     );
-    const { body } = await this.http.getText(url);
+    const { body } = await this.http.getText(
+      url,
+      await this.getHttpOptions(url),
+    );
     return filterMap(body.split(newlineRegex), (str) => {
       if (!isNonEmptyStringAndNotWhitespace(str)) {
         return null;
@@ -306,7 +334,11 @@ export class GoProxyDatasource extends Datasource {
       '@v',
       `${version}.info`,
     );
-    const res = await this.http.getJson(url, VersionInfo);
+    const res = await this.http.getJson(
+      url,
+      await this.getHttpOptions(url),
+      VersionInfo,
+    );
 
     const result: Release = {
       version: res.body.Version,
@@ -320,6 +352,97 @@ export class GoProxyDatasource extends Datasource {
     return result;
   }
 
+  /**
+   * Resolves the head of a branch with a version query, which the proxy answers with the pseudo-version of the branch's latest commit - or with its tag, if that commit is tagged.
+   *
+   * @see https://go.dev/ref/mod#version-queries
+   */
+  async getBranchReleases(
+    baseUrl: string,
+    packageName: string,
+    branch: string,
+  ): Promise<ReleaseResult> {
+    const url = joinUrlParts(
+      baseUrl,
+      this.encodeCase(packageName),
+      '@v',
+      `${encodeURIComponent(branch)}.info`,
+    );
+    const { body } = await this.http.getJson(
+      url,
+      await this.getHttpOptions(url),
+      VersionInfo,
+    );
+
+    const release: Release = pseudoVersionToRelease(body.Version) ?? {
+      version: body.Version,
+    };
+    if (body.Origin?.Hash) {
+      release.newDigest = body.Origin.Hash.substring(0, 12);
+    }
+    const releaseTimestamp = asTimestamp(body.Time);
+    if (releaseTimestamp) {
+      release.releaseTimestamp = releaseTimestamp;
+    }
+    return { releases: [release] };
+  }
+
+  private async _resolveModulePath(
+    packagePath: string,
+    version: string,
+  ): Promise<string | null> {
+    if (parseNoproxy()?.test(packagePath)) {
+      return null;
+    }
+    const [proxy] = parseGoproxy(
+      getEnv().GOPROXY ?? 'https://proxy.golang.org,direct',
+    );
+    if (!proxy || !isHttpUrl(proxy.url)) {
+      return null;
+    }
+    const elements = packagePath.split('/');
+    // the first element is a domain name, which is never a module on its own
+    for (let length = elements.length; length > 1; length -= 1) {
+      const modulePath = elements.slice(0, length).join('/');
+      try {
+        await this.versionInfo(proxy.url, modulePath, version);
+        return modulePath;
+      } catch (err) {
+        const statusCode =
+          err instanceof HttpError ? err.response?.statusCode : undefined;
+        if (statusCode !== 404 && statusCode !== 410) {
+          logger.debug(
+            { err, packagePath },
+            'goproxy: failed to resolve module path',
+          );
+          return null;
+        }
+      }
+    }
+    return null;
+  }
+
+  /**
+   * Finds the module which provides a package at a version, like the `go` command does: the longest prefix of the package path which the first proxy in `GOPROXY` serves at that version.
+   *
+   * Returns `null` if the package isn't served by a module proxy, for instance because it matches `GONOPROXY`.
+   *
+   * @see https://go.dev/ref/mod#resolve-pkg-mod
+   */
+  resolveModulePath(
+    packagePath: string,
+    version: string,
+  ): Promise<string | null> {
+    return withCache(
+      {
+        namespace: 'datasource-go-module-path',
+        key: `${packagePath}@${version}`,
+        ttlMinutes: 24 * 60,
+      },
+      () => this._resolveModulePath(packagePath, version),
+    );
+  }
+
   /**
    * Retrieve the `go` directive for a given Go Module.
    *
@@ -352,7 +475,7 @@ export class GoProxyDatasource extends Datasource {
       '@v',
       `${version}.mod`,
     );
-    const res = await this.http.getText(url);
+    const res = await this.http.getText(url, await this.getHttpOptions(url));
 
     let goDirective: string | undefined = undefined;
 
@@ -379,6 +502,41 @@ export class GoProxyDatasource extends Datasource {
     return `${parts[0]}.${parts[1]}.${parts[2]}`;
   }
 
+  /**
+   * Retrieve the metadata, such as `retract` directives, which the given version of a Go Module publishes in its `go.mod`.
+   *
+   * This is read from the latest version of a module, and applies to all of its versions.
+   *
+   * @see https://go.dev/ref/mod#go-mod-file-retract
+   */
+  async retrieveModFileMetadata(
+    baseUrl: string,
+    packageName: string,
+    version: string,
+  ): Promise<GoModFileMetadata> {
+    return withCache(
+      {
+        namespace: `datasource-${GoProxyDatasource.id}`,
+        key: `${GoProxyDatasource.getVersionedCacheKey(packageName, version)}@@modfile`,
+        // as with the `go` directive, a published `go.mod` never changes
+        ttlMinutes: 100 * 24 * 60,
+      },
+      async () => {
+        const url = joinUrlParts(
+          baseUrl,
+          this.encodeCase(packageName),
+          '@v',
+          `${version}.mod`,
+        );
+        const res = await this.http.getText(
+          url,
+          await this.getHttpOptions(url),
+        );
+        return parseModFileMetadata(res.body);
+      },
+    );
+  }
+
   async getLatestVersion(
     baseUrl: string,
     packageName: string,
@@ -389,7 +547,11 @@ export class GoProxyDatasource extends Datasource {
         this.encodeCase(packageName),
         '@latest',
       );
-      const res = await this.http.getJson(url, VersionInfo);
+      const res = await this.http.getJson(
+        url,
+        await this.getHttpOptions(url),
+        VersionInfo,
+      );
       const { Version: version, Origin: origin } = res.body;
       // Extract sourceUrl from GOPROXY Origin when present, avoiding go-get to
       // vanity hosts (https://github.com/renovatebot/renovate/discussions/44898)
@@ -508,6 +670,25 @@ export class GoProxyDatasource extends Datasource {
         if (sourceUrl) {
           result.sourceUrl ??= sourceUrl;
         }
+        if (releases.length) {
+          try {
+            const metadata = await this.retrieveModFileMetadata(
+              baseUrl,
+              pkg,
+              latestVersion,
+            );
+            applyRetractions(releases, metadata.retractions);
+            // other major versions are different modules, which are deprecated separately
+            if (pkg === packageName) {
+              applyDeprecation(result, metadata);
+            }
+          } catch (err) {
+            logger.trace(
+              { err },
+              `Can't obtain \`go.mod\` metadata from ${baseUrl}`,
+            );
+          }
+        }
         if (!result.releases.length) {
           const releaseFromLatest = pseudoVersionToRelease(latestVersion);
           if (releaseFromLatest) {
@@ -527,6 +708,7 @@ export class GoProxyDatasource extends Datasource {
   static getCacheKey({
     packageName,
     constraintsFiltering,
+    followBranch,
   }: GetReleasesConfig): string {
     const goproxy = getEnv().GOPROXY;
     const noproxy = parseNoproxy();
@@ -534,8 +716,9 @@ export class GoProxyDatasource extends Datasource {
       constraintsFiltering && constraintsFiltering !== 'none'
         ? `@@${constraintsFiltering}`
         : '';
+    const followBranchKey = followBranch ? `@@branch=${followBranch}` : '';
     // TODO: types (#22198)
-    return `${packageName}@@${goproxy}@@${noproxy?.toString()}${constraintsFilteringKey}`;
+    return `${packageName}@@${goproxy}@@${noproxy?.toString()}${constraintsFilteringKey}${followBranchKey}`;
   }
 
   static getVersionedCacheKey(packageName: string, version: string): string {
@@ -10,8 +10,36 @@ export const VersionInfo = z.object({
     .object({
       VCS: z.string().optional(),
       URL: z.string().optional(),
+      Ref: z.string().optional(),
+      Hash: z.string().optional(),
     })
     .optional(),
 });
 
 export type VersionInfo = z.infer<typeof VersionInfo>;
+
+// https://docs.github.com/en/rest/commits/commits#get-a-commit
+export const GithubCommit = z.object({
+  sha: z.string(),
+  commit: z.object({
+    committer: z.object({
+      date: z.string(),
+    }),
+  }),
+});
+
+// https://docs.github.com/en/rest/commits/commits#compare-two-commits
+export const GithubComparison = z.object({
+  status: z.string(),
+});
+
+// https://docs.gitlab.com/api/commits/#get-a-single-commit
+export const GitlabCommit = z.object({
+  id: z.string(),
+  committed_date: z.string(),
+});
+
+// https://docs.gitlab.com/api/repositories/#compare-branches-tags-or-commits
+export const GitlabComparison = z.object({
+  commits: z.array(z.unknown()),
+});
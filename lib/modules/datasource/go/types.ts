@@ -6,7 +6,30 @@ export interface DataSource {
   packageName: string;
 }
 
+/**
+ * A `go-import` meta tag, mapping an import path prefix to its repository or module proxy.
+ */
+export interface GoImport {
+  prefix: string;
+  vcs: string;
+  repoRoot: string;
+}
+
 export interface GoproxyItem {
   url: string;
   fallback: GoproxyFallback;
 }
+
+export interface GoRetraction {
+  low: string;
+  high: string;
+  rationale?: string;
+}
+
+export interface GoModFileMetadata {
+  retractions: GoRetraction[];
+  /** the path of the `module` directive */
+  module?: string;
+  /** the deprecation notice of the module, without its `Deprecated:` prefix */
+  deprecation?: string;
+}
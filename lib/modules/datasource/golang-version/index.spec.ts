@@ -2,7 +2,11 @@ import { Fixtures } from '~test/fixtures.ts';
 import * as httpMock from '~test/http-mock.ts';
 import { ExternalHostError } from '../../../types/errors/external-host-error.ts';
 import { getPkgReleases } from '../index.ts';
-import { GolangVersionDatasource } from './index.ts';
+import {
+  GolangVersionDatasource,
+  getDownloadsUrl,
+  toSemver,
+} from './index.ts';
 
 const golangReleasesContent = Fixtures.get('releases.go');
 const golangReleasesInvalidContent = Fixtures.get('releases-invalid.go');
@@ -11,6 +15,14 @@ const golangReleasesInvalidContent3 = Fixtures.get('releases-invalid3.go');
 const golangReleasesInvalidContent4 = Fixtures.get('releases-invalid4.go');
 const golangReleasesInvalidContent5 = Fixtures.get('releases-invalid5.go');
 const golangReleasesInvalidContent6 = Fixtures.get('releases-invalid6.go');
+const golangDownloadsContent = Fixtures.get('releases.json');
+const golangHistoryContent = [
+  'var Releases = []*Release{',
+  '\t{',
+  '\t\tDate: Date{2024, 2, 6}, Version: Version{1, 22, 0},',
+  '\t},',
+  '}',
+].join('\n');
 
 const datasource = GolangVersionDatasource.id;
 
@@ -27,6 +39,7 @@ describe('modules/datasource/golang-version/index', () => {
       });
       expect(res?.releases).toHaveLength(132);
       expect(res?.releases[0]).toEqual({
+        isUnsupported: true,
         releaseTimestamp: '2012-03-28T00:00:00.000Z',
         version: '1.0.0',
       });
@@ -48,6 +61,7 @@ describe('modules/datasource/golang-version/index', () => {
       });
       expect(res?.releases).toHaveLength(132);
       expect(res?.releases[0]).toEqual({
+        isUnsupported: true,
         releaseTimestamp: '2012-03-28T00:00:00.000Z',
         version: '1.0.0',
       });
@@ -138,5 +152,120 @@ describe('modules/datasource/golang-version/index', () => {
         getPkgReleases({ datasource, packageName: 'golang' }),
       ).rejects.toThrow(ExternalHostError);
     });
+
+    describe('go.dev/dl', () => {
+      it('parses releases with checksums', async () => {
+        httpMock
+          .scope('https://go.dev')
+          .get('/dl/?mode=json&include=all')
+          .reply(200, golangDownloadsContent);
+        httpMock
+          .scope('https://raw.githubusercontent.com')
+          .get('/golang/website/HEAD/internal/history/release.go')
+          .reply(200, golangHistoryContent);
+
+        const res = await getPkgReleases({
+          datasource,
+          packageName: 'golang',
+          registryUrls: ['https://go.dev/dl/'],
+        });
+
+        expect(res).toEqual({
+          homepage: 'https://go.dev/',
+          registryUrl: 'https://go.dev/dl',
+          sourceUrl: 'https://github.com/golang/go',
+          releases: [
+            { version: '1.20.0', isStable: true },
+            {
+              version: '1.22.0',
+              isStable: true,
+              releaseTimestamp: '2024-02-06T00:00:00.000Z',
+              checksums: {
+                'src.tar.gz':
+                  '4d196c3d41a0d6c1dfc64d04e3cc1f608b0c436bd87b7060ce3e23234e1f4d5c',
+                'linux-amd64.tar.gz':
+                  'f6c8a87aa03b92c4b0bf3d558e28ea03006eb29db78917daec5cfb6ec1046265',
+              },
+            },
+            {
+              version: '1.23.0-rc.1',
+              isStable: false,
+              checksums: {
+                'linux-amd64.tar.gz':
+                  '2b1f3d0ab1c9e1a7a1b2c5e4e2b25d0e1a84ed6fa3ba62bdb7f14d0a4c02bf8a',
+              },
+            },
+          ],
+        });
+      });
+
+      it('reads a mirror without release dates', async () => {
+        httpMock
+          .scope('https://mirror.example.com')
+          .get('/go/releases.json')
+          .reply(200, golangDownloadsContent);
+        httpMock
+          .scope('https://raw.githubusercontent.com')
+          .get('/golang/website/HEAD/internal/history/release.go')
+          .reply(404);
+
+        const res = await getPkgReleases({
+          datasource,
+          packageName: 'golang',
+          registryUrls: ['https://mirror.example.com/go/releases.json'],
+        });
+
+        expect(res?.releases).toHaveLength(3);
+        expect(res?.releases[1]).not.toHaveProperty('releaseTimestamp');
+      });
+
+      it('throws ExternalHostError for zero releases', async () => {
+        httpMock
+          .scope('https://go.dev')
+          .get('/dl/?mode=json&include=all')
+          .reply(200, []);
+        httpMock
+          .scope('https://raw.githubusercontent.com')
+          .get('/golang/website/HEAD/internal/history/release.go')
+          .reply(200, golangHistoryContent);
+
+        await expect(
+          getPkgReleases({
+            datasource,
+            packageName: 'golang',
+            registryUrls: ['https://go.dev/dl'],
+          }),
+        ).rejects.toThrow(ExternalHostError);
+      });
+    });
+  });
+
+  describe('getDownloadsUrl()', () => {
+    it.each`
+      registryUrl                                   | expected
+      ${'https://go.dev/dl'}                        | ${'https://go.dev/dl/?mode=json&include=all'}
+      ${'https://mirror.example.com/golang/dl'}     | ${'https://mirror.example.com/golang/dl/?mode=json&include=all'}
+      ${'https://go.dev/dl/?mode=json'}             | ${'https://go.dev/dl/?mode=json'}
+      ${'https://mirror.example.com/releases.json'} | ${'https://mirror.example.com/releases.json'}
+      ${'https://raw.githubusercontent.com/golang'} | ${null}
+      ${'not a url'}                                | ${null}
+    `('getDownloadsUrl("$registryUrl")', ({ registryUrl, expected }) => {
+      expect(getDownloadsUrl(registryUrl)).toBe(expected);
+    });
+  });
+
+  describe('toSemver()', () => {
+    it.each`
+      goVersion              | expected
+      ${'go1'}               | ${'1.0.0'}
+      ${'go1.20'}            | ${'1.20.0'}
+      ${'go1.22.3'}          | ${'1.22.3'}
+      ${'go1.21rc2'}         | ${'1.21.0-rc.2'}
+      ${'go1.9.2rc2'}        | ${'1.9.2-rc.2'}
+      ${'go1.22beta1'}       | ${'1.22.0-beta.1'}
+      ${'weekly.2012-03-27'} | ${null}
+    `('toSemver("$goVersion")', ({ goVersion, expected }) => {
+      expect(toSemver(goVersion)).toBe(expected);
+    });
   });
 });
@@ -1,14 +1,17 @@
+import { logger } from '../../../logger/index.ts';
 import { ExternalHostError } from '../../../types/errors/external-host-error.ts';
 import { withCache } from '../../../util/cache/package/with-cache.ts';
 import { regEx } from '../../../util/regex.ts';
 import { asTimestamp } from '../../../util/timestamp.ts';
-import { joinUrlParts } from '../../../util/url.ts';
+import { joinUrlParts, parseUrl } from '../../../util/url.ts';
 import {
   isVersion,
   id as semverVersioningId,
 } from '../../versioning/semver/index.ts';
 import { Datasource } from '../datasource.ts';
 import type { GetReleasesConfig, Release, ReleaseResult } from '../types.ts';
+import { GoDownloadReleases } from './schema.ts';
+import { markUnsupportedReleases } from './support.ts';
 
 const lineTerminationRegex = regEx(`\r?\n`);
 const releaseBeginningChar = '\t{';
@@ -20,6 +23,46 @@ const releaseVersionRegex = regEx(
   `Version\\{(?<versionMajor>\\d+),\\s+(?<versionMinor>\\d+),\\s+(?<patch>\\d+)\\}`,
 );
 const releaseFutureRegex = regEx(`Future:\\s+true`);
+const downloadPathRegex = regEx(/\/dl\/?$/);
+const downloadVersionRegex = regEx(
+  /^go(?<major>\d+)(?:\.(?<minor>\d+))?(?:\.(?<patch>\d+))?(?:(?<pre>beta|rc)(?<preNum>\d+))?$/,
+);
+
+/**
+ * Returns the URL of the JSON release list for a registry URL which points to `go.dev/dl`, or to a mirror of its JSON output.
+ */
+export function getDownloadsUrl(registryUrl: string): string | null {
+  const url = parseUrl(registryUrl);
+  if (!url) {
+    return null;
+  }
+  if (
+    url.searchParams.get('mode') === 'json' ||
+    url.pathname.endsWith('.json')
+  ) {
+    return registryUrl;
+  }
+  if (downloadPathRegex.test(url.pathname)) {
+    url.pathname = url.pathname.replace(downloadPathRegex, '/dl/');
+    url.searchParams.set('mode', 'json');
+    url.searchParams.set('include', 'all');
+    return url.toString();
+  }
+  return null;
+}
+
+/**
+ * Converts a Go release name such as `go1.21rc2` to SemVer, i.e. `1.21.0-rc.2`.
+ */
+export function toSemver(goVersion: string): string | null {
+  const groups = downloadVersionRegex.exec(goVersion)?.groups;
+  if (!groups) {
+    return null;
+  }
+  const { major, minor = '0', patch = '0', pre, preNum } = groups;
+  const version = `${major}.${minor}.${patch}`;
+  return pre ? `${version}-${pre}.${preNum}` : version;
+}
 
 export class GolangVersionDatasource extends Datasource {
   static readonly id = 'golang-version';
@@ -38,7 +81,7 @@ export class GolangVersionDatasource extends Datasource {
 
   override readonly releaseTimestampSupport = true;
   override readonly releaseTimestampNote =
-    'The release timestamp is determined from the `Date` field in the results.';
+    'The release timestamp is determined from the `Date` field in the results, also when reading releases from `go.dev/dl`.';
   override readonly sourceUrlSupport = 'package';
   override readonly sourceUrlNote =
     'We use the URL: https://github.com/golang/go.';
@@ -51,6 +94,20 @@ export class GolangVersionDatasource extends Datasource {
       return null;
     }
 
+    const downloadsUrl = getDownloadsUrl(registryUrl);
+    const res = downloadsUrl
+      ? await this.getDownloadReleases(downloadsUrl)
+      : await this.getHistoryReleases(registryUrl);
+    markUnsupportedReleases(res.releases);
+    return res;
+  }
+
+  /**
+   * Reads the release list of the Go website, which contains every stable release and its date.
+   */
+  private async getHistoryReleases(
+    registryUrl: string,
+  ): Promise<ReleaseResult> {
     const res: ReleaseResult = {
       homepage: 'https://go.dev/',
       sourceUrl: 'https://github.com/golang/go',
@@ -139,11 +196,75 @@ export class GolangVersionDatasource extends Datasource {
     return res;
   }
 
+  /**
+   * Reads the JSON release list of `go.dev/dl`, which contains unstable releases and the SHA256 checksums of each release's files.
+   *
+   * It has no release dates, so these are taken from the Go website's release history where possible.
+   */
+  private async getDownloadReleases(url: string): Promise<ReleaseResult> {
+    const { body } = await this.http.getJson(url, GoDownloadReleases);
+
+    const timestamps = new Map<string, Release['releaseTimestamp']>();
+    try {
+      const history = await this.getHistoryReleases(
+        this.defaultRegistryUrls[0],
+      );
+      for (const { version, releaseTimestamp } of history.releases) {
+        timestamps.set(version, releaseTimestamp);
+      }
+    } catch (err) {
+      logger.debug({ err }, 'golang-version: failed to read release dates');
+    }
+
+    const releases: Release[] = [];
+    for (const { version: goVersion, stable, files } of body) {
+      const version = toSemver(goVersion);
+      if (!version) {
+        logger.trace(
+          { goVersion },
+          'golang-version: skipping unknown version',
+        );
+        continue;
+      }
+      const release: Release = { version, isStable: stable };
+      const releaseTimestamp = timestamps.get(version);
+      if (releaseTimestamp) {
+        release.releaseTimestamp = releaseTimestamp;
+      }
+      if (files.length) {
+        // keyed without the release name, e.g. `linux-amd64.tar.gz`, so that
+        // templates can look up the same archive for every version
+        const prefix = `${goVersion}.`;
+        release.checksums = Object.fromEntries(
+          files.map(({ filename, sha256 }) => [
+            filename.startsWith(prefix)
+              ? filename.slice(prefix.length)
+              : filename,
+            sha256,
+          ]),
+        );
+      }
+      releases.push(release);
+    }
+
+    if (releases.length === 0) {
+      throw new ExternalHostError(
+        new Error(`Invalid file - zero releases extracted`),
+      );
+    }
+
+    return {
+      homepage: 'https://go.dev/',
+      sourceUrl: 'https://github.com/golang/go',
+      releases,
+    };
+  }
+
   getReleases(config: GetReleasesConfig): Promise<ReleaseResult | null> {
     return withCache(
       {
         namespace: `datasource-${GolangVersionDatasource.id}`,
-        key: 'all',
+        key: `${config.registryUrl}`,
         fallback: true,
       },
       () => this._getReleases(config),
@@ -0,0 +1,18 @@
+import { z } from 'zod/v4';
+import { LooseArray } from '../../../util/schema-utils/index.ts';
+
+// https://go.dev/dl/?mode=json&include=all
+export const GoDownloadFile = z.object({
+  filename: z.string(),
+  sha256: z.string(),
+});
+
+export const GoDownloadRelease = z.object({
+  version: z.string(),
+  stable: z.boolean(),
+  files: LooseArray(GoDownloadFile).catch([]),
+});
+
+export type GoDownloadRelease = z.infer<typeof GoDownloadRelease>;
+
+export const GoDownloadReleases = LooseArray(GoDownloadRelease);
@@ -0,0 +1,58 @@
+import type { Timestamp } from '../../../util/timestamp.ts';
+import type { Release } from '../types.ts';
+import { markUnsupportedReleases } from './support.ts';
+
+describe('modules/datasource/golang-version/support', () => {
+  beforeEach(() => {
+    vi.useFakeTimers();
+    vi.setSystemTime(new Date('2024-08-20T00:00:00.000Z'));
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+  });
+
+  it('supports the latest two released minor versions', () => {
+    const releases: Release[] = [
+      { version: '1.20.14' },
+      { version: '1.21.0' },
+      { version: '1.21.13' },
+      { version: '1.22.0' },
+      { version: '1.23.0-rc.1', isStable: false },
+      {
+        version: '1.23.0',
+        releaseTimestamp: '2024-08-13T00:00:00.000Z' as Timestamp,
+      },
+      {
+        version: '1.24.0',
+        releaseTimestamp: '2025-02-11T00:00:00.000Z' as Timestamp,
+      },
+    ];
+
+    markUnsupportedReleases(releases);
+
+    expect(
+      releases.map(({ version, isUnsupported }) => [version, !!isUnsupported]),
+    ).toEqual([
+      ['1.20.14', true],
+      ['1.21.0', true],
+      ['1.21.13', true],
+      ['1.22.0', false],
+      ['1.23.0-rc.1', false],
+      ['1.23.0', false],
+      ['1.24.0', false],
+    ]);
+  });
+
+  it('ignores unstable minor versions', () => {
+    const releases: Release[] = [
+      { version: '1.21.0' },
+      { version: '1.22.0-rc.1', isStable: false },
+      { version: '1.22.0-rc.2' },
+    ];
+
+    markUnsupportedReleases(releases);
+
+    expect(releases.some(({ isUnsupported }) => isUnsupported)).toBeFalse();
+  });
+});
@@ -0,0 +1,42 @@
+import { DateTime } from 'luxon';
+import { api as semver } from '../../versioning/semver/index.ts';
+import type { Release } from '../types.ts';
+
+/**
+ * Marks the releases of Go minor versions which are out of support.
+ *
+ * Each minor version is supported until there are two newer minor versions, so only the latest two released minor versions are supported.
+ *
+ * @see https://go.dev/doc/devel/release#policy
+ */
+export function markUnsupportedReleases(releases: Release[]): void {
+  const now = DateTime.now();
+  const minors = new Set<number>();
+  for (const { version, isStable, releaseTimestamp } of releases) {
+    if (
+      isStable === false ||
+      !semver.isStable(version) ||
+      semver.getMajor(version) !== 1
+    ) {
+      continue;
+    }
+    if (releaseTimestamp && DateTime.fromISO(releaseTimestamp) > now) {
+      continue;
+    }
+    minors.add(semver.getMinor(version)!);
+  }
+
+  const [, oldestSupported] = [...minors].sort((a, b) => b - a);
+  if (oldestSupported === undefined) {
+    return;
+  }
+
+  for (const release of releases) {
+    if (
+      semver.getMajor(release.version) === 1 &&
+      semver.getMinor(release.version)! < oldestSupported
+    ) {
+      release.isUnsupported = true;
+    }
+  }
+}
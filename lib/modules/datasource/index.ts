@@ -443,10 +443,10 @@ async function fetchReleases(
 function fetchCachedReleases(
   config: GetReleasesInternalConfig,
 ): Promise<ReleaseResult | null> {
-  const { datasource, packageName, registryUrls } = config;
+  const { datasource, packageName, registryUrls, followBranch } = config;
   const cacheKey = `datasource-mem:releases:${datasource}:${packageName}:${config.registryStrategy}:${String(
     registryUrls,
-  )}`;
+  )}${followBranch ? `:${followBranch}` : ''}`;
   // By returning a Promise and reusing it, we should only fetch each package at most once
   const cachedResult = memCache.get<Promise<ReleaseResult | null>>(cacheKey);
   // istanbul ignore if
@@ -526,7 +526,7 @@ function getDigestConfig(
   datasource: DatasourceApi,
   config: GetDigestInputConfig,
 ): DigestConfig {
-  const { lookupName, currentValue, currentDigest } = config;
+  const { lookupName, currentValue, currentDigest, followBranch } = config;
   const packageName = config.replacementName ?? config.packageName;
   // Prefer registryUrl from getReleases() lookup if it has been passed
   const registryUrl =
@@ -537,7 +537,14 @@ function getDigestConfig(
       config.registryUrls,
       config.additionalRegistryUrls,
     )[0];
-  return { lookupName, packageName, registryUrl, currentValue, currentDigest };
+  return {
+    lookupName,
+    packageName,
+    registryUrl,
+    currentValue,
+    currentDigest,
+    followBranch,
+  };
 }
 
 export function getDigest(
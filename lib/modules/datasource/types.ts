@@ -20,6 +20,7 @@ export interface GetDigestInputConfig {
   currentValue?: string;
   currentDigest?: string;
   replacementName?: string;
+  followBranch?: string;
 }
 
 export interface DigestConfig {
@@ -28,6 +29,7 @@ export interface DigestConfig {
   registryUrl?: string;
   currentValue?: string;
   currentDigest?: string;
+  followBranch?: string;
 }
 
 export interface GetReleasesConfig {
@@ -42,6 +44,9 @@ export interface GetReleasesConfig {
    */
   constraintsVersioning?: Partial<Record<AdditionalConstraintName, string>>;
   constraintsFiltering?: ConstraintsFilter;
+  followBranch?: string;
+  /** Whether `packageName` is a Go package path, whose module is resolved with the module proxy */
+  goPackagePath?: boolean;
 }
 
 export interface GetPkgReleasesConfig {
@@ -66,16 +71,25 @@ export interface GetPkgReleasesConfig {
    */
   constraintsVersioning?: Partial<Record<AdditionalConstraintName, string>>;
   registryStrategy?: RegistryStrategy;
+  followBranch?: string;
+  /** Whether `packageName` is a Go package path, whose module is resolved with the module proxy */
+  goPackagePath?: boolean;
 }
 
 export interface Release {
   changelogContent?: string;
   changelogUrl?: string;
   checksumUrl?: string;
+  /** SHA256 checksums of the release's files, keyed by file name */
+  checksums?: Record<string, string>;
   downloadUrl?: string;
   gitRef?: string;
   isDeprecated?: boolean;
+  /** Why this specific release is deprecated, for instance a Go module retraction's rationale */
+  deprecationMessage?: string;
   isStable?: boolean;
+  /** Whether the release is out of its maintainers' support window, for instance a Go minor version older than the latest two */
+  isUnsupported?: boolean;
   releaseTimestamp?: Timestamp | null;
   version: string;
   /** The original value to which `extractVersion` was applied */
@@ -118,6 +132,7 @@ export interface ReleaseResult {
   packageScope?: string;
   mostRecentTimestamp?: Timestamp;
   isAbandoned?: boolean;
+  abandonmentReasons?: string[];
   respectLatest?: boolean;
 }
 
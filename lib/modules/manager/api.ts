@@ -45,7 +45,9 @@ import * as gitlabci from './gitlabci/index.ts';
 import * as gitlabciInclude from './gitlabci-include/index.ts';
 import * as glasskube from './glasskube/index.ts';
 import * as gleam from './gleam/index.ts';
+import * as goInstall from './go-install/index.ts';
 import * as gomod from './gomod/index.ts';
+import * as gowork from './gowork/index.ts';
 import * as gradle from './gradle/index.ts';
 import * as gradleWrapper from './gradle-wrapper/index.ts';
 import * as haskellCabal from './haskell-cabal/index.ts';
@@ -166,7 +168,9 @@ api.set('gitlabci', gitlabci);
 api.set('gitlabci-include', gitlabciInclude);
 api.set('glasskube', glasskube);
 api.set('gleam', gleam);
+api.set('go-install', goInstall);
 api.set('gomod', gomod);
+api.set('gowork', gowork);
 api.set('gradle', gradle);
 api.set('gradle-wrapper', gradleWrapper);
 api.set('haskell-cabal', haskellCabal);
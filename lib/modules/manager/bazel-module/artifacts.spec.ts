@@ -7,6 +7,7 @@ import type {
 } from '../../../config/types.ts';
 import type { UpdateArtifactsConfig } from '../types.ts';
 import { updateArtifacts } from './artifacts.ts';
+import * as goDeps from './go-deps.ts';
 
 vi.mock('../../../util/fs/index.ts');
 vi.mock('./lockfile.ts');
@@ -172,4 +173,52 @@ describe('modules/manager/bazel-module/artifacts', () => {
       },
     ]);
   });
+
+  it('returns MODULE.bazel with updated go_deps sums', async () => {
+    vi.spyOn(goDeps, 'updateGoDepsSums').mockResolvedValueOnce(
+      'go_deps.module(path = "github.com/foo/bar", sum = "h1:new", version = "v1.1.0")',
+    );
+    fs.getSiblingFileName.mockReturnValueOnce('MODULE.bazel.lock');
+    fs.readLocalFile.mockResolvedValueOnce(null);
+
+    const result = await updateArtifacts({
+      packageFileName: 'MODULE.bazel',
+      updatedDeps: [
+        { depName: 'github.com/foo/bar', depType: 'go_deps_module' },
+      ],
+      newPackageFileContent:
+        'go_deps.module(path = "github.com/foo/bar", sum = "h1:old", version = "v1.1.0")',
+      config,
+    });
+
+    expect(result).toEqual([
+      {
+        file: {
+          type: 'addition',
+          path: 'MODULE.bazel',
+          contents:
+            'go_deps.module(path = "github.com/foo/bar", sum = "h1:new", version = "v1.1.0")',
+        },
+      },
+    ]);
+  });
+
+  it('returns an artifact error if go_deps sums fail', async () => {
+    vi.spyOn(goDeps, 'updateGoDepsSums').mockRejectedValueOnce(
+      new Error('not found'),
+    );
+
+    const result = await updateArtifacts({
+      packageFileName: 'MODULE.bazel',
+      updatedDeps: [
+        { depName: 'github.com/foo/bar', depType: 'go_deps_module' },
+      ],
+      newPackageFileContent: '',
+      config,
+    });
+
+    expect(result).toEqual([
+      { artifactError: { fileName: 'MODULE.bazel', stderr: 'not found' } },
+    ]);
+  });
 });
@@ -1,10 +1,13 @@
+import { TEMPORARY_ERROR } from '../../../constants/error-messages.ts';
 import { logger } from '../../../logger/index.ts';
+import { coerceArray } from '../../../util/array.ts';
 import {
   getSiblingFileName,
   readLocalFile,
   writeLocalFile,
 } from '../../../util/fs/index.ts';
 import type { UpdateArtifact, UpdateArtifactsResult } from '../types.ts';
+import { updateGoDepsSums } from './go-deps.ts';
 import { updateBazelLockfile } from './lockfile.ts';
 
 export async function updateArtifacts({
@@ -21,20 +24,56 @@ export async function updateArtifacts({
     return null;
   }
 
+  const res: UpdateArtifactsResult[] = [];
+  let packageFileContent = newPackageFileContent;
+  try {
+    packageFileContent = await updateGoDepsSums(
+      newPackageFileContent,
+      updatedDeps,
+    );
+  } catch (err) {
+    if (err.message === TEMPORARY_ERROR) {
+      throw err;
+    }
+    logger.debug({ err }, 'Failed to update go_deps sums');
+    return [
+      {
+        artifactError: {
+          fileName: packageFileName,
+          stderr: err.message,
+        },
+      },
+    ];
+  }
+  if (packageFileContent !== newPackageFileContent) {
+    res.push({
+      file: {
+        type: 'addition',
+        path: packageFileName,
+        contents: packageFileContent,
+      },
+    });
+  }
+
   const lockFileName = getSiblingFileName(packageFileName, 'MODULE.bazel.lock');
 
   const existingLockContent = await readLocalFile(lockFileName, 'utf8');
   if (!existingLockContent) {
     logger.debug('No MODULE.bazel.lock found - skipping artifact update');
-    return null;
+    return res.length ? res : null;
   }
 
-  await writeLocalFile(packageFileName, newPackageFileContent);
+  await writeLocalFile(packageFileName, packageFileContent);
 
-  return await updateBazelLockfile(
-    lockFileName,
-    packageFileName,
-    isLockFileMaintenance,
-    config.constraints?.bazelisk,
+  res.push(
+    ...coerceArray(
+      await updateBazelLockfile(
+        lockFileName,
+        packageFileName,
+        isLockFileMaintenance,
+        config.constraints?.bazelisk,
+      ),
+    ),
   );
+  return res.length ? res : null;
 }
@@ -49,6 +49,11 @@ export const knownDepTypes = [
     description:
       'A Rust crate dependency via the `crate.spec` module extension tag',
   },
+  {
+    depType: 'go_deps_module',
+    description:
+      'A Go module dependency via the `go_deps.module` module extension tag',
+  },
   {
     depType: 'rules_img_pull',
     description:
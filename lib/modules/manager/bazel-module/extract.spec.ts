@@ -10,6 +10,7 @@ import { BazelDatasource } from '../../datasource/bazel/index.ts';
 import { CrateDatasource } from '../../datasource/crate/index.ts';
 import { DockerDatasource } from '../../datasource/docker/index.ts';
 import { GithubTagsDatasource } from '../../datasource/github-tags/index.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
 import { MavenDatasource } from '../../datasource/maven/index.ts';
 import { extractPackageFile } from './index.ts';
 import * as parser from './parser/index.ts';
@@ -1193,6 +1194,71 @@ describe('modules/manager/bazel-module/extract', () => {
       });
     });
 
+    it('returns go_deps.module dependencies', async () => {
+      const input = codeBlock`
+        go_deps = use_extension("@gazelle//:extensions.bzl", "go_deps")
+        go_deps.from_file(go_mod = "//:go.mod")
+        go_deps.module(
+            path = "github.com/pkg/errors",
+            sum = "h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=",
+            version = "v0.9.1",
+        )
+        go_deps.module(path = "golang.org/x/net", version = "v0.25.0")
+        use_repo(go_deps, "com_github_pkg_errors", "org_golang_x_net")
+      `;
+
+      const result = await extractPackageFile(input, 'MODULE.bazel');
+
+      expect(result).toEqual({
+        deps: [
+          {
+            datasource: GoDatasource.id,
+            depType: 'go_deps_module',
+            depName: 'github.com/pkg/errors',
+            currentValue: 'v0.9.1',
+            major: { enabled: false },
+            managerData: {
+              sum: 'h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=',
+            },
+            replaceString: codeBlock`
+              go_deps.module(
+                  path = "github.com/pkg/errors",
+                  sum = "h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=",
+                  version = "v0.9.1",
+              )
+            `,
+          },
+          {
+            datasource: GoDatasource.id,
+            depType: 'go_deps_module',
+            depName: 'golang.org/x/net',
+            currentValue: 'v0.25.0',
+            major: { enabled: false },
+            managerData: {},
+            replaceString:
+              'go_deps.module(path = "golang.org/x/net", version = "v0.25.0")',
+          },
+        ],
+      });
+    });
+
+    it('disables major updates of go_deps.module dependencies', async () => {
+      const input = codeBlock`
+        go_deps = use_extension("@gazelle//:extensions.bzl", "go_deps")
+        go_deps.module(path = "github.com/google/go-github/v62", version = "v62.0.0")
+      `;
+
+      const result = await extractPackageFile(input, 'MODULE.bazel');
+
+      expect(result?.deps).toMatchObject([
+        {
+          depName: 'github.com/google/go-github/v62',
+          currentValue: 'v62.0.0',
+          major: { enabled: false },
+        },
+      ]);
+    });
+
     it('ignores non-rules_img repo rules', async () => {
       const input = codeBlock`
         bazel_dep(name = "some_rules", version = "0.1.0")
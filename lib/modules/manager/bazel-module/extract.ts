@@ -12,6 +12,7 @@ import type {
 import * as bazelrc from './bazelrc.ts';
 import { RuleToCratePackageDep } from './parser/crate.ts';
 import type { ResultFragment } from './parser/fragments.ts';
+import { RuleToGoPackageDep } from './parser/go.ts';
 import { parse } from './parser/index.ts';
 import { RuleToMavenPackageDep, fillRegistryUrls } from './parser/maven.ts';
 import { RuleToDockerPackageDep } from './parser/oci.ts';
@@ -50,6 +51,7 @@ export async function extractPackageFile(
       .parse(records);
     const rulesImgDeps = transformRulesImgCalls(records);
     const crateDeps = LooseArray(RuleToCratePackageDep).parse(records);
+    const goDeps = LooseArray(RuleToGoPackageDep).parse(records);
 
     if (gitRepositoryDeps.length) {
       pfc.deps.push(...gitRepositoryDeps);
@@ -71,6 +73,10 @@ export async function extractPackageFile(
       pfc.deps.push(...crateDeps);
     }
 
+    if (goDeps.length) {
+      pfc.deps.push(...goDeps);
+    }
+
     return pfc.deps.length ? pfc : null;
   } catch (err) {
     logger.debug({ err, packageFile }, 'Failed to parse bazel module file.');
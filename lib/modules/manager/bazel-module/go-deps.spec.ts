@@ -0,0 +1,216 @@
+import { createWriteStream } from 'node:fs';
+import { readFile } from 'node:fs/promises';
+import { pipeline } from 'node:stream/promises';
+import { codeBlock } from 'common-tags';
+import type { DirectoryResult } from 'tmp-promise';
+import { dir } from 'tmp-promise';
+import upath from 'upath';
+import { ZipFile } from 'yazl';
+import * as httpMock from '~test/http-mock.ts';
+import { GlobalConfig } from '../../../config/global.ts';
+import { setCustomEnv } from '../../../util/env.ts';
+import * as fs from '../../../util/fs/index.ts';
+import {
+  resolveLabel,
+  updateGoDepsLockfile,
+  updateGoDepsSums,
+} from './go-deps.ts';
+import { updateBazelLockfile } from './lockfile.ts';
+
+vi.mock('./lockfile.ts');
+
+const moduleFile = codeBlock`
+  go_deps = use_extension("@gazelle//:extensions.bzl", "go_deps")
+  go_deps.from_file(go_mod = "//tools:go.mod")
+  go_deps.module(
+      path = "github.com/foo/bar",
+      sum = "h1:4fp3Fjl7V4UN7cNV8Vz/4Hxp2lw0Ub6LBRGNfm3rVy8=",
+      version = "v1.1.0",
+  )
+  go_deps.module(path = "github.com/foo/baz", version = "v1.1.0")
+`;
+
+async function getModuleZip(tmpDir: string): Promise<Buffer> {
+  const zipFile = upath.join(tmpDir, 'module.zip');
+  const archive = new ZipFile();
+  archive.addBuffer(
+    Buffer.from('module github.com/foo/bar\n'),
+    'github.com/foo/bar@v1.1.0/go.mod',
+  );
+  archive.addBuffer(
+    Buffer.from('package bar\n'),
+    'github.com/foo/bar@v1.1.0/bar.go',
+  );
+  const writePromise = pipeline(
+    archive.outputStream,
+    createWriteStream(zipFile),
+  );
+  archive.end();
+  await writePromise;
+  return readFile(zipFile);
+}
+
+describe('modules/manager/bazel-module/go-deps', () => {
+  let tmpDir: DirectoryResult;
+
+  beforeEach(async () => {
+    tmpDir = await dir({ unsafeCleanup: true });
+    GlobalConfig.set({
+      localDir: upath.join(tmpDir.path, 'repo'),
+      cacheDir: upath.join(tmpDir.path, 'cache'),
+    });
+    setCustomEnv({});
+  });
+
+  afterEach(async () => {
+    await tmpDir.cleanup();
+    GlobalConfig.reset();
+  });
+
+  describe('resolveLabel()', () => {
+    it.each`
+      moduleFileName           | label                | expected
+      ${'MODULE.bazel'}        | ${'//:go.mod'}       | ${'go.mod'}
+      ${'MODULE.bazel'}        | ${'@//tools:go.mod'} | ${'tools/go.mod'}
+      ${'nested/MODULE.bazel'} | ${'//tools:go.mod'}  | ${'nested/tools/go.mod'}
+      ${'MODULE.bazel'}        | ${'go.mod'}          | ${null}
+    `('resolves $label', ({ moduleFileName, label, expected }) => {
+      expect(resolveLabel(moduleFileName, label)).toBe(expected);
+    });
+  });
+
+  describe('updateGoDepsSums()', () => {
+    it('returns the content without go_deps updates', async () => {
+      expect(
+        await updateGoDepsSums(moduleFile, [{ depType: 'bazel_dep' }]),
+      ).toBe(moduleFile);
+    });
+
+    it('recomputes the sum from the module zip', async () => {
+      httpMock
+        .scope('https://proxy.golang.org')
+        .get('/github.com/foo/bar/@v/v1.1.0.zip')
+        .reply(200, await getModuleZip(tmpDir.path));
+
+      const res = await updateGoDepsSums(moduleFile, [
+        {
+          depType: 'go_deps_module',
+          depName: 'github.com/foo/bar',
+          newValue: 'v1.1.0',
+        },
+        {
+          depType: 'go_deps_module',
+          depName: 'github.com/foo/baz',
+          newValue: 'v1.1.0',
+        },
+      ]);
+
+      expect(res).toBe(
+        moduleFile.replace(
+          'h1:4fp3Fjl7V4UN7cNV8Vz/4Hxp2lw0Ub6LBRGNfm3rVy8=',
+          'h1:BxXi/JZmaPJIwmxnR6opAIr7nBlVuNlY6v/E/VcXMDk=',
+        ),
+      );
+    });
+
+    it('uses GOPROXY', async () => {
+      setCustomEnv({ GOPROXY: 'https://goproxy.example.com,direct' });
+      httpMock
+        .scope('https://goproxy.example.com')
+        .get('/github.com/foo/bar/@v/v1.1.0.zip')
+        .reply(200, await getModuleZip(tmpDir.path));
+
+      const res = await updateGoDepsSums(moduleFile, [
+        {
+          depType: 'go_deps_module',
+          depName: 'github.com/foo/bar',
+          newValue: 'v1.1.0',
+        },
+      ]);
+
+      expect(res).toContain('h1:BxXi/JZmaPJIwmxnR6opAIr7nBlVuNlY6v/E/VcXMDk=');
+    });
+
+    it('throws for private modules', async () => {
+      setCustomEnv({ GOPRIVATE: 'github.com/foo' });
+
+      await expect(
+        updateGoDepsSums(moduleFile, [
+          {
+            depType: 'go_deps_module',
+            depName: 'github.com/foo/bar',
+            newValue: 'v1.1.0',
+          },
+        ]),
+      ).rejects.toThrow(
+        'Cannot compute the sum of github.com/foo/bar@v1.1.0 without a module proxy',
+      );
+    });
+  });
+
+  describe('updateGoDepsLockfile()', () => {
+    it('ignores go.mod files not used by go_deps', async () => {
+      await fs.writeLocalFile('MODULE.bazel', moduleFile);
+      await fs.writeLocalFile('MODULE.bazel.lock', '{}');
+
+      expect(
+        await updateGoDepsLockfile('go.mod', 'module foo\n', undefined),
+      ).toEqual([]);
+      expect(updateBazelLockfile).not.toHaveBeenCalled();
+    });
+
+    it('returns empty without MODULE.bazel', async () => {
+      expect(
+        await updateGoDepsLockfile('tools/go.mod', 'module foo\n', undefined),
+      ).toEqual([]);
+    });
+
+    it('returns empty without MODULE.bazel.lock', async () => {
+      await fs.writeLocalFile('MODULE.bazel', moduleFile);
+
+      expect(
+        await updateGoDepsLockfile('tools/go.mod', 'module foo\n', undefined),
+      ).toEqual([]);
+      expect(updateBazelLockfile).not.toHaveBeenCalled();
+    });
+
+    it('updates MODULE.bazel.lock', async () => {
+      await fs.writeLocalFile('MODULE.bazel', moduleFile);
+      await fs.writeLocalFile('MODULE.bazel.lock', '{}');
+      vi.mocked(updateBazelLockfile).mockResolvedValueOnce([
+        {
+          file: {
+            type: 'addition',
+            path: 'MODULE.bazel.lock',
+            contents: 'new lock content',
+          },
+        },
+      ]);
+
+      const res = await updateGoDepsLockfile(
+        'tools/go.mod',
+        'module foo\n',
+        '>=1.18.0',
+      );
+
+      expect(res).toEqual([
+        {
+          file: {
+            type: 'addition',
+            path: 'MODULE.bazel.lock',
+            contents: 'new lock content',
+          },
+        },
+      ]);
+      expect(updateBazelLockfile).toHaveBeenCalledExactlyOnceWith(
+        'MODULE.bazel.lock',
+        'MODULE.bazel',
+        false,
+        '>=1.18.0',
+      );
+      expect(await fs.readLocalFile('tools/go.mod', 'utf8')).toBe(
+        'module foo\n',
+      );
+    });
+  });
+});
@@ -0,0 +1,205 @@
+import crypto from 'node:crypto';
+import upath from 'upath';
+import { logger } from '../../../logger/index.ts';
+import { coerceArray } from '../../../util/array.ts';
+import { withCache } from '../../../util/cache/package/with-cache.ts';
+import { getEnv } from '../../../util/env.ts';
+import * as fs from '../../../util/fs/index.ts';
+import { Http } from '../../../util/http/index.ts';
+import { regEx } from '../../../util/regex.ts';
+import { LooseArray } from '../../../util/schema-utils/index.ts';
+import { isHttpUrl, joinUrlParts } from '../../../util/url.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
+import {
+  parseGoproxy,
+  parseNoproxy,
+} from '../../datasource/go/goproxy-parser.ts';
+import { TerraformProviderHash } from '../terraform/lockfile/hash.ts';
+import type { Upgrade, UpdateArtifactsResult } from '../types.ts';
+import { updateBazelLockfile } from './lockfile.ts';
+import { GoDepsFromFileLabel, RuleToGoPackageDep } from './parser/go.ts';
+import { parse } from './parser/index.ts';
+
+const http = new Http(GoDatasource.id);
+
+const hashCacheTTL = 10080; // in minutes == 1 week
+
+const labelRegex = regEx(/^@?\/\/(?<pkg>[^:]*):(?<name>[^:]+)$/);
+
+function encodeCase(input: string): string {
+  return input.replace(regEx(/([A-Z])/g), (x) => `!${x.toLowerCase()}`);
+}
+
+/**
+ * Returns the `GOPROXY` entry which serves the module zip.
+ */
+function getProxyUrl(path: string): string | null {
+  const env = getEnv();
+  const noProxy = parseNoproxy(env.GONOPROXY ?? env.GOPRIVATE ?? '');
+  if (noProxy?.test(path)) {
+    return null;
+  }
+  // `direct` and `off` don't serve module zips
+  const [proxy] = parseGoproxy(
+    env.GOPROXY ?? 'https://proxy.golang.org,direct',
+  );
+  return proxy && isHttpUrl(proxy.url) ? proxy.url : null;
+}
+
+async function _calculateModuleHash(
+  proxyUrl: string,
+  path: string,
+  version: string,
+): Promise<string> {
+  const cacheDir = await fs.ensureCacheDir('bazel-module');
+  const downloadFileName = upath.join(cacheDir, crypto.randomUUID());
+  const url = joinUrlParts(
+    proxyUrl,
+    encodeCase(path),
+    '@v',
+    `${encodeCase(version)}.zip`,
+  );
+  logger.trace(`Downloading module zip for ${path}@${version}`);
+  try {
+    await fs.pipeline(
+      http.stream(url),
+      fs.createCacheWriteStream(downloadFileName),
+    );
+    // module zips are hashed like directories, with `dirhash.Hash1`
+    const hash =
+      await TerraformProviderHash.hashOfZipContent(downloadFileName);
+    return `h1:${hash}`;
+  } finally {
+    await fs.rmCache(downloadFileName);
+  }
+}
+
+/**
+ * Computes the `go.sum` hash of a module zip, as used by the `sum` attribute of `go_deps.module`.
+ */
+export function calculateModuleHash(
+  proxyUrl: string,
+  path: string,
+  version: string,
+): Promise<string> {
+  return withCache(
+    {
+      namespace: 'go-module-hash',
+      key: `${proxyUrl}:${path}@${version}`,
+      ttlMinutes: hashCacheTTL,
+    },
+    () => _calculateModuleHash(proxyUrl, path, version),
+  );
+}
+
+/**
+ * Recomputes the `sum` of updated `go_deps.module` tags.
+ *
+ * @throws if a module zip can't be downloaded
+ */
+export async function updateGoDepsSums(
+  content: string,
+  updatedDeps: Upgrade[],
+): Promise<string> {
+  const updatedGoDeps = updatedDeps.filter(
+    ({ depType }) => depType === 'go_deps_module',
+  );
+  if (!updatedGoDeps.length) {
+    return content;
+  }
+
+  let newContent = content;
+  const deps = LooseArray(RuleToGoPackageDep).parse(parse(content));
+  for (const { depName, newValue } of updatedGoDeps) {
+    const dep = deps.find(
+      (d) => d.depName === depName && d.currentValue === newValue,
+    );
+    const sum = dep?.managerData?.sum;
+    if (!dep?.replaceString || !sum || !newValue) {
+      continue;
+    }
+    const proxyUrl = getProxyUrl(depName!);
+    if (!proxyUrl) {
+      throw new Error(
+        `Cannot compute the sum of ${depName}@${newValue} without a module proxy`,
+      );
+    }
+    const newSum = await calculateModuleHash(proxyUrl, depName!, newValue);
+    if (newSum !== sum) {
+      logger.debug(`Updating sum of ${depName}@${newValue}`);
+      newContent = newContent.replace(
+        dep.replaceString,
+        dep.replaceString.replace(sum, newSum),
+      );
+    }
+  }
+  return newContent;
+}
+
+/**
+ * Returns the file referenced by a label, relative to the repository root.
+ *
+ * @example
+ * resolveLabel('MODULE.bazel', '//tools:go.mod')
+ * // 'tools/go.mod'
+ */
+export function resolveLabel(
+  moduleFileName: string,
+  label: string,
+): string | null {
+  const match = labelRegex.exec(label);
+  if (!match?.groups) {
+    return null;
+  }
+  const { pkg, name } = match.groups;
+  return upath.join(fs.getParentDir(moduleFileName), pkg, name);
+}
+
+/**
+ * Updates `MODULE.bazel.lock` when a `go.mod` used by `go_deps.from_file` has changed.
+ *
+ * The `go_deps` extension reads `go.mod` and `go.sum`, so the lock file records them as extension inputs.
+ */
+export async function updateGoDepsLockfile(
+  goModFileName: string,
+  goModContent: string,
+  bazeliskConstraint: string | undefined,
+): Promise<UpdateArtifactsResult[]> {
+  const moduleFileName = await fs.findLocalSiblingOrParent(
+    goModFileName,
+    'MODULE.bazel',
+  );
+  if (!moduleFileName) {
+    return [];
+  }
+  const moduleFileContent = await fs.readLocalFile(moduleFileName, 'utf8');
+  if (!moduleFileContent) {
+    return [];
+  }
+  const goModFiles = LooseArray(GoDepsFromFileLabel)
+    .parse(parse(moduleFileContent))
+    .map((label) => resolveLabel(moduleFileName, label));
+  if (!goModFiles.includes(goModFileName)) {
+    return [];
+  }
+
+  const lockFileName = fs.getSiblingFileName(
+    moduleFileName,
+    'MODULE.bazel.lock',
+  );
+  if (!(await fs.localPathExists(lockFileName))) {
+    logger.debug('No MODULE.bazel.lock found - skipping go_deps update');
+    return [];
+  }
+
+  logger.debug(`Updating ${lockFileName} for go_deps.from_file`);
+  await fs.writeLocalFile(goModFileName, goModContent);
+  return coerceArray(
+    await updateBazelLockfile(
+      lockFileName,
+      moduleFileName,
+      false,
+      bazeliskConstraint,
+    ),
+  );
+}
@@ -3,6 +3,7 @@ import { BazelDatasource } from '../../datasource/bazel/index.ts';
 import { CrateDatasource } from '../../datasource/crate/index.ts';
 import { DockerDatasource } from '../../datasource/docker/index.ts';
 import { GithubTagsDatasource } from '../../datasource/github-tags/index.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
 import { MavenDatasource } from '../../datasource/maven/index.ts';
 
 export { updateArtifacts } from './artifacts.ts';
@@ -21,6 +22,7 @@ export const supportedDatasources = [
   CrateDatasource.id,
   DockerDatasource.id,
   GithubTagsDatasource.id,
+  GoDatasource.id,
   MavenDatasource.id,
 ];
 
@@ -4,6 +4,7 @@ import { kvParams } from './common.ts';
 import type { Ctx } from './context.ts';
 
 import { crateExtensionPrefix, crateExtensionTags } from './crate.ts';
+import { goDepsExtensionPrefix, goDepsExtensionTags } from './go.ts';
 import { mavenExtensionPrefix, mavenExtensionTags } from './maven.ts';
 import { ociExtensionPrefix, ociExtensionTags } from './oci.ts';
 
@@ -21,11 +22,12 @@ import { ociExtensionPrefix, ociExtensionTags } from './oci.ts';
 // by assuming the extension names start with well-known prefixes.
 
 const supportedExtensionRegex = regEx(
-  `^(${crateExtensionPrefix}|${ociExtensionPrefix}|${mavenExtensionPrefix}).*$`,
+  `^(${crateExtensionPrefix}|${goDepsExtensionPrefix}|${ociExtensionPrefix}|${mavenExtensionPrefix}).*$`,
 );
 
 const supportedExtensionTags = [
   ...crateExtensionTags,
+  ...goDepsExtensionTags,
   ...mavenExtensionTags,
   ...ociExtensionTags,
 ];
@@ -0,0 +1,51 @@
+import { z } from 'zod/v4';
+import { GoDatasource } from '../../../datasource/go/index.ts';
+import type { PackageDependency } from '../../types.ts';
+import { ExtensionTagFragment, StringFragment } from './fragments.ts';
+
+export const goDepsExtensionPrefix = 'go_deps';
+
+const moduleTag = 'module';
+const fromFileTag = 'from_file';
+
+export const goDepsExtensionTags = [moduleTag, fromFileTag];
+
+export interface GoDepsManagerData {
+  sum?: string;
+}
+
+export const RuleToGoPackageDep = ExtensionTagFragment.extend({
+  extension: z.literal(goDepsExtensionPrefix),
+  tag: z.literal(moduleTag),
+  children: z.object({
+    path: StringFragment,
+    version: StringFragment,
+    sum: StringFragment.optional(),
+  }),
+}).transform(
+  ({
+    rawString,
+    children: { path, version, sum },
+  }): PackageDependency<GoDepsManagerData> => ({
+    datasource: GoDatasource.id,
+    depType: 'go_deps_module',
+    depName: path.value,
+    currentValue: version.value,
+    managerData: { sum: sum?.value },
+    // A major update changes the module path to `/vN`, which replacing the version can't do.
+    major: { enabled: false },
+    // The sum is recomputed by updateArtifacts after the version is replaced.
+    replaceString: rawString,
+  }),
+);
+
+/**
+ * The `go_mod` label of a `go_deps.from_file` tag, like `//:go.mod`.
+ */
+export const GoDepsFromFileLabel = ExtensionTagFragment.extend({
+  extension: z.literal(goDepsExtensionPrefix),
+  tag: z.literal(fromFileTag),
+  children: z.object({
+    go_mod: StringFragment,
+  }),
+}).transform(({ children: { go_mod } }) => go_mod.value);
@@ -0,0 +1,16 @@
+import type { DepTypeMetadata } from '../types.ts';
+
+export const knownDepTypes = [
+  {
+    depType: 'install',
+    description: 'A package installed with `go install <package>@<version>`',
+  },
+  {
+    depType: 'run',
+    description: 'A package run with `go run <package>@<version>`',
+  },
+  {
+    depType: 'get',
+    description: 'A module added with `go get <package>@<version>`',
+  },
+] as const satisfies readonly DepTypeMetadata[];
@@ -0,0 +1,216 @@
+import { codeBlock } from 'common-tags';
+import { extractPackageFile, getModulePath } from './extract.ts';
+describe('modules/manager/go-install/extract', () => {
+  describe('getModulePath()', () => {
+    it.each`
+      packagePath                                              | expected
+      ${'golang.org/x/tools/cmd/stringer'}                     | ${'golang.org/x/tools/cmd/stringer'}
+      ${'github.com/vektra/mockery/v2'}                        | ${'github.com/vektra/mockery/v2'}
+      ${'github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen'} | ${'github.com/deepmap/oapi-codegen/v2'}
+      ${'golang.org/x/tools/...'}                              | ${'golang.org/x/tools'}
+      ${'example.com/v1/cmd/tool'}                             | ${'example.com/v1/cmd/tool'}
+    `('returns $expected for $packagePath', ({ packagePath, expected }) => {
+      expect(getModulePath(packagePath)).toBe(expected);
+    });
+  });
+
+  describe('extractPackageFile()', () => {
+    it('returns null for empty', () => {
+      expect(
+        extractPackageFile('go build ./...\ngo run ./cmd/foo\n'),
+      ).toBeNull();
+    });
+
+    it('extracts Makefile recipes', () => {
+      const content = codeBlock`
+        GOBIN ?= $(shell go env GOPATH)/bin
+
+        .PHONY: tools
+        tools:
+          go install golang.org/x/tools/cmd/stringer@v0.21.0
+          go install github.com/golangci/golangci-lint/cmd/golangci-lint@v1.59.1 && echo done
+          go install mvdan.cc/gofumpt@latest
+          go install github.com/vektra/mockery/v2@v2.43.2
+          go install $(TOOL)@$(VERSION)
+
+        generate:
+          go run github.com/99designs/gqlgen@v0.17.49 generate
+      `;
+      expect(extractPackageFile(content)).toEqual({
+        deps: [
+          {
+            datasource: 'go',
+            depType: 'install',
+            depName: 'golang.org/x/tools/cmd/stringer',
+            currentValue: 'v0.21.0',
+            goPackagePath: true,
+            managerData: { lineNumber: 4 },
+          },
+          {
+            datasource: 'go',
+            depType: 'install',
+            depName: 'github.com/golangci/golangci-lint/cmd/golangci-lint',
+            currentValue: 'v1.59.1',
+            goPackagePath: true,
+            managerData: { lineNumber: 5 },
+          },
+          {
+            datasource: 'go',
+            depType: 'install',
+            depName: 'mvdan.cc/gofumpt',
+            currentValue: 'latest',
+            managerData: { lineNumber: 6 },
+            skipReason: 'unspecified-version',
+          },
+          {
+            datasource: 'go',
+            depType: 'install',
+            depName: 'github.com/vektra/mockery/v2',
+            currentValue: 'v2.43.2',
+            managerData: { lineNumber: 7 },
+          },
+          {
+            datasource: 'go',
+            depType: 'run',
+            depName: 'github.com/99designs/gqlgen',
+            currentValue: 'v0.17.49',
+            goPackagePath: true,
+            managerData: { lineNumber: 11 },
+          },
+        ],
+      });
+    });
+
+    it('extracts shell commands with line continuations', () => {
+      const content = codeBlock`
+        #!/bin/sh
+        set -e
+
+        go install \
+          github.com/bufbuild/buf/cmd/buf@v1.32.2 \
+          google.golang.org/protobuf/cmd/protoc-gen-go@v1.34.1
+        go get -u golang.org/x/tools/...@v0.21.0; go build ./...
+      `;
+      expect(extractPackageFile(content)?.deps).toMatchObject([
+        {
+          depType: 'install',
+          depName: 'github.com/bufbuild/buf/cmd/buf',
+          currentValue: 'v1.32.2',
+          goPackagePath: true,
+          managerData: { lineNumber: 4 },
+        },
+        {
+          depType: 'install',
+          depName: 'google.golang.org/protobuf/cmd/protoc-gen-go',
+          currentValue: 'v1.34.1',
+          goPackagePath: true,
+          managerData: { lineNumber: 5 },
+        },
+        {
+          depType: 'get',
+          depName: 'golang.org/x/tools/...',
+          packageName: 'golang.org/x/tools',
+          currentValue: 'v0.21.0',
+          goPackagePath: true,
+          managerData: { lineNumber: 6 },
+        },
+      ]);
+    });
+
+    it('extracts Dockerfile instructions', () => {
+      const content = codeBlock`
+        FROM golang:1.22.4 AS build
+
+        RUN go install github.com/go-delve/delve/cmd/dlv@v1.22.1 \
+          && go install honnef.co/go/tools/cmd/staticcheck@2023.1.7
+
+        RUN go install golang.org/x/vuln/cmd/govulncheck@v0.0.0-20240515164805-fa8c3bd5b2b9
+      `;
+      expect(extractPackageFile(content)?.deps).toEqual([
+        {
+          datasource: 'go',
+          depType: 'install',
+          depName: 'github.com/go-delve/delve/cmd/dlv',
+          currentValue: 'v1.22.1',
+          goPackagePath: true,
+          managerData: { lineNumber: 2 },
+        },
+        {
+          datasource: 'go',
+          depType: 'install',
+          depName: 'honnef.co/go/tools/cmd/staticcheck',
+          currentValue: '2023.1.7',
+          goPackagePath: true,
+          managerData: { lineNumber: 3 },
+        },
+        {
+          datasource: 'go',
+          depType: 'install',
+          depName: 'golang.org/x/vuln/cmd/govulncheck',
+          currentValue: 'v0.0.0-20240515164805-fa8c3bd5b2b9',
+          goPackagePath: true,
+          currentDigest: 'fa8c3bd5b2b9',
+          digestOneAndOnly: true,
+          versioning: 'loose',
+          managerData: { lineNumber: 5 },
+        },
+      ]);
+    });
+
+    it('extracts go:generate directives', () => {
+      const content = codeBlock`
+        package main
+
+        //go:generate go run golang.org/x/tools/cmd/stringer@v0.21.0 -type=Pill
+        //go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen@master --config=cfg.yaml api.yaml
+        //go:generate go run github.com/example/tool@9c1bde4a
+
+        type Pill int
+      `;
+      expect(extractPackageFile(content)?.deps).toEqual([
+        {
+          datasource: 'go',
+          depType: 'run',
+          depName: 'golang.org/x/tools/cmd/stringer',
+          currentValue: 'v0.21.0',
+          goPackagePath: true,
+          managerData: { lineNumber: 2 },
+        },
+        {
+          datasource: 'go',
+          depType: 'run',
+          depName: 'github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen',
+          packageName: 'github.com/deepmap/oapi-codegen/v2',
+          currentValue: 'master',
+          managerData: { lineNumber: 3 },
+          skipReason: 'unversioned-reference',
+        },
+        {
+          datasource: 'go',
+          depType: 'run',
+          depName: 'github.com/example/tool',
+          currentValue: '9c1bde4a',
+          managerData: { lineNumber: 4 },
+          skipReason: 'unversioned-reference',
+        },
+      ]);
+    });
+
+    it('uses the path up to a major version element as module path', () => {
+      expect(
+        extractPackageFile(
+          'go install github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen@v2.1.0',
+        )?.deps,
+      ).toEqual([
+        {
+          datasource: 'go',
+          depType: 'install',
+          depName: 'github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen',
+          packageName: 'github.com/deepmap/oapi-codegen/v2',
+          currentValue: 'v2.1.0',
+          managerData: { lineNumber: 0 },
+        },
+      ]);
+    });
+  });
+});
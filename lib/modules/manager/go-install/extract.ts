@@ -0,0 +1,98 @@
+import { regEx } from '../../../util/regex.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
+import { isVersion } from '../../versioning/semver/index.ts';
+import type { PackageDependency, PackageFileContent } from '../types.ts';
+
+// `go install`, `go run` and `go get`, with their arguments up to the end of the shell command
+const goCommandRegex = regEx(
+  /\bgo\s+(?<command>install|run|get)\s(?<args>(?:\\\r?\n|[^\n;&|])*)/g,
+);
+
+// the first element of a package path is a domain name
+const packageQueryRegex = regEx(
+  /(?:^|[\s"'])(?<packagePath>[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)+(?:\/[a-zA-Z0-9._~+-]+)*)@(?<query>[a-zA-Z0-9._+-]+)/g,
+);
+
+const majorVersionElementRegex = regEx(/^v(?:[2-9]|[1-9]\d+)$/);
+
+const pseudoVersionRegex = regEx(GoDatasource.pversionRegexp);
+
+// module queries which don't name a version
+const unversionedQueries = new Set(['latest', 'upgrade', 'patch']);
+
+/**
+ * Returns the path of the module providing the package, where it can be told from the package path alone.
+ *
+ * Modules of major version 2 and later end with a `/vN` element, so anything after it is a package within the module.
+ * Otherwise the package path is returned, and the Go datasource resolves the module with the module proxy.
+ */
+export function getModulePath(packagePath: string): string {
+  // `example.com/mod/...` matches all packages of a module
+  const elements = packagePath.replace(regEx(/\/\.\.\.$/), '').split('/');
+  const majorIndex = elements.findLastIndex(
+    (element, index) => index > 0 && majorVersionElementRegex.test(element),
+  );
+  if (majorIndex === -1) {
+    return elements.join('/');
+  }
+  return elements.slice(0, majorIndex + 1).join('/');
+}
+
+function applyQuery(dep: PackageDependency, query: string): void {
+  if (unversionedQueries.has(query)) {
+    dep.skipReason = 'unspecified-version';
+  } else if (!isVersion(query)) {
+    // branch names and commit hashes
+    dep.skipReason = 'unversioned-reference';
+  } else {
+    const digest = pseudoVersionRegex.exec(query)?.groups?.digest;
+    if (digest) {
+      dep.currentDigest = digest;
+      dep.digestOneAndOnly = true;
+      dep.versioning = 'loose';
+    }
+  }
+}
+
+function applyModulePath(dep: PackageDependency, packagePath: string): void {
+  const modulePath = getModulePath(packagePath);
+  const lastElement = modulePath.split('/').at(-1)!;
+  if (!dep.skipReason && !majorVersionElementRegex.test(lastElement)) {
+    dep.goPackagePath = true;
+  }
+  if (modulePath !== packagePath) {
+    dep.packageName = modulePath;
+  }
+}
+
+export function extractPackageFile(
+  content: string,
+): PackageFileContent | null {
+  const deps: PackageDependency[] = [];
+  for (const commandMatch of content.matchAll(goCommandRegex)) {
+    const { command, args } = commandMatch.groups!;
+    const argsOffset =
+      commandMatch.index + commandMatch[0].length - args.length;
+    for (const queryMatch of args.matchAll(packageQueryRegex)) {
+      const { packagePath, query } = queryMatch.groups!;
+      const offset =
+        argsOffset + queryMatch.index + queryMatch[0].indexOf(packagePath);
+      const dep: PackageDependency = {
+        datasource: GoDatasource.id,
+        depType: command,
+        depName: packagePath,
+        currentValue: query,
+        managerData: {
+          lineNumber: content.slice(0, offset).split('\n').length - 1,
+        },
+      };
+      applyQuery(dep, query);
+      applyModulePath(dep, packagePath);
+      deps.push(dep);
+    }
+  }
+  if (!deps.length) {
+    return null;
+  }
+  return { deps };
+}
@@ -0,0 +1,27 @@
+import type { Category } from '../../../constants/index.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
+import { extractPackageFile } from './extract.ts';
+import { updateDependency } from './update.ts';
+
+export { knownDepTypes } from './dep-types.ts';
+export { extractPackageFile, updateDependency };
+
+export const displayName = 'go install';
+export const url = 'https://go.dev/ref/mod#go-install';
+export const categories: Category[] = ['golang'];
+
+export const defaultConfig = {
+  commitMessageTopic: 'Go tool {{depName}}',
+  enabled: false,
+  managerFilePatterns: [
+    '/(^|/)(GNU)?[Mm]akefile$/',
+    '/\\.mk$/',
+    '/\\.sh$/',
+    '/(^|/|\\.)([Dd]ocker|[Cc]ontainer)file$/',
+    '/(^|/)([Dd]ocker|[Cc]ontainer)file[^/]*$/',
+    '/\\.go$/',
+  ],
+  pinDigests: false,
+};
+
+export const supportedDatasources = [GoDatasource.id];
@@ -0,0 +1,207 @@
+import { codeBlock } from 'common-tags';
+import { updateDependency } from './update.ts';
+
+const makefile = codeBlock`
+  tools:
+    go install golang.org/x/tools/cmd/stringer@v0.21.0
+    go install github.com/vektra/mockery/v2@v2.43.2
+    go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen@v2.1.0 api.yaml
+    go install github.com/go-delve/delve@v1.22.1
+    go install golang.org/x/vuln/cmd/govulncheck@v0.0.0-20240515164805-fa8c3bd5b2b9
+`;
+
+describe('modules/manager/go-install/update', () => {
+  describe('updateDependency()', () => {
+    it('updates the version', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/tools/cmd/stringer',
+          currentValue: 'v0.21.0',
+          newValue: 'v0.22.0',
+          managerData: { lineNumber: 1 },
+        },
+      });
+      expect(res).toBe(makefile.replace('v0.21.0', 'v0.22.0'));
+    });
+
+    it('returns the content if already updated', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/tools/cmd/stringer',
+          currentValue: 'v0.20.0',
+          newValue: 'v0.21.0',
+          managerData: { lineNumber: 1 },
+        },
+      });
+      expect(res).toBe(makefile);
+    });
+
+    it('returns null if the line does not contain the dependency', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/tools/cmd/stringer',
+          currentValue: 'v0.21.0',
+          newValue: 'v0.22.0',
+          managerData: { lineNumber: 2 },
+        },
+      });
+      expect(res).toBeNull();
+    });
+
+    it('returns null if the line no longer exists', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/tools/cmd/stringer',
+          currentValue: 'v0.21.0',
+          newValue: 'v0.22.0',
+          managerData: { lineNumber: 10 },
+        },
+      });
+      expect(res).toBeNull();
+    });
+
+    it('updates pseudo-versions', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/vuln/cmd/govulncheck',
+          currentValue: 'v0.0.0-20240515164805-fa8c3bd5b2b9',
+          currentDigest: 'fa8c3bd5b2b9',
+          newValue: 'v0.0.0-20240612181238-a3b5ac9e8b51',
+          newDigest: 'a3b5ac9e8b51',
+          updateType: 'digest',
+          managerData: { lineNumber: 5 },
+        },
+      });
+      expect(res).toBe(
+        makefile.replace(
+          'v0.0.0-20240515164805-fa8c3bd5b2b9',
+          'v0.0.0-20240612181238-a3b5ac9e8b51',
+        ),
+      );
+    });
+
+    it('returns null for digest updates without a pseudo-version', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/vuln/cmd/govulncheck',
+          currentValue: 'v0.0.0-20240515164805-fa8c3bd5b2b9',
+          currentDigest: 'fa8c3bd5b2b9',
+          newValue: 'v0.0.0-20240515164805-fa8c3bd5b2b9',
+          newDigest: 'a3b5ac9e8b51',
+          updateType: 'digest',
+          managerData: { lineNumber: 5 },
+        },
+      });
+      expect(res).toBeNull();
+    });
+
+    it('updates the major version of a module path', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'github.com/vektra/mockery/v2',
+          currentValue: 'v2.43.2',
+          newValue: 'v3.0.0',
+          newMajor: 3,
+          updateType: 'major',
+          managerData: { lineNumber: 2 },
+        },
+      });
+      expect(res).toBe(
+        makefile.replace('mockery/v2@v2.43.2', 'mockery/v3@v3.0.0'),
+      );
+    });
+
+    it('updates the major version of a package path', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen',
+          packageName: 'github.com/deepmap/oapi-codegen/v2',
+          currentValue: 'v2.1.0',
+          newValue: 'v3.0.0',
+          newMajor: 3,
+          updateType: 'major',
+          managerData: { lineNumber: 3 },
+        },
+      });
+      expect(res).toBe(
+        makefile.replace(
+          'oapi-codegen/v2/cmd/oapi-codegen@v2.1.0',
+          'oapi-codegen/v3/cmd/oapi-codegen@v3.0.0',
+        ),
+      );
+    });
+
+    it('adds the major version to a repository root', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'github.com/go-delve/delve',
+          currentValue: 'v1.22.1',
+          newValue: 'v2.0.0',
+          newMajor: 2,
+          updateType: 'major',
+          managerData: { lineNumber: 4 },
+        },
+      });
+      expect(res).toBe(
+        makefile.replace('delve/delve@v1.22.1', 'delve/delve/v2@v2.0.0'),
+      );
+    });
+
+    it('adds the major version to a resolved module path', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'golang.org/x/tools/cmd/stringer',
+          goPackagePath: true,
+          lookupName: 'golang.org/x/tools',
+          currentValue: 'v0.21.0',
+          newValue: 'v2.0.0',
+          newMajor: 2,
+          updateType: 'major',
+          managerData: { lineNumber: 1 },
+        },
+      });
+      expect(res).toBe(
+        makefile.replace(
+          'x/tools/cmd/stringer@v0.21.0',
+          'x/tools/v2/cmd/stringer@v2.0.0',
+        ),
+      );
+    });
+
+    it('keeps the path for +incompatible versions', () => {
+      const res = updateDependency({
+        fileContent: makefile,
+        packageFile: 'Makefile',
+        upgrade: {
+          depName: 'github.com/go-delve/delve',
+          currentValue: 'v1.22.1',
+          newValue: 'v2.0.0+incompatible',
+          newMajor: 2,
+          updateType: 'major',
+          managerData: { lineNumber: 4 },
+        },
+      });
+      expect(res).toBe(makefile.replace('v1.22.1', 'v2.0.0+incompatible'));
+    });
+  });
+});
@@ -0,0 +1,80 @@
+import { logger } from '../../../logger/index.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
+import { getNewMajorModulePath } from '../gomod/import-paths.ts';
+import type { UpdateDependencyConfig, Upgrade } from '../types.ts';
+
+function getNewVersion(upgrade: Upgrade): string | null {
+  const { currentValue, newValue, updateType } = upgrade;
+  if (updateType === 'digest') {
+    // the go datasource returns the pseudo-version of newDigest as newValue
+    if (
+      newValue &&
+      newValue !== currentValue &&
+      GoDatasource.pversionRegexp.test(newValue)
+    ) {
+      return newValue;
+    }
+    return null;
+  }
+  return newValue ?? null;
+}
+
+function getNewPackagePath(upgrade: Upgrade): string {
+  const { depName, lookupName, packageName, newMajor, newValue, updateType } =
+    upgrade;
+  if (
+    updateType !== 'major' ||
+    !newMajor ||
+    newMajor < 2 ||
+    newValue?.endsWith('+incompatible')
+  ) {
+    return depName!;
+  }
+  // the Go datasource resolves the module of a package path, otherwise it leaves out major updates
+  const modulePath = lookupName ?? packageName ?? depName!;
+  const newModulePath = getNewMajorModulePath(modulePath, newMajor);
+  return `${newModulePath}${depName!.slice(modulePath.length)}`;
+}
+
+export function updateDependency({
+  fileContent,
+  upgrade,
+}: UpdateDependencyConfig): string | null {
+  const { depName, currentValue } = upgrade;
+  logger.debug(`go-install.updateDependency: ${depName}@${upgrade.newValue}`);
+  /* v8 ignore next -- should never happen */
+  if (!depName || !currentValue || !upgrade.managerData) {
+    return null;
+  }
+  const lines = fileContent.split('\n');
+  const { lineNumber } = upgrade.managerData;
+  const line = lines[lineNumber];
+  if (line === undefined) {
+    logger.debug(`go-install: line ${lineNumber} no longer exists`);
+    return null;
+  }
+
+  const newVersion = getNewVersion(upgrade);
+  const newPackagePath = getNewPackagePath(upgrade);
+  if (!newVersion) {
+    logger.debug(
+      { depName, lineNumber, newValue: upgrade.newValue },
+      'go-install: cannot apply update',
+    );
+    return null;
+  }
+  const current = `${depName}@${currentValue}`;
+  const updated = `${newPackagePath}@${newVersion}`;
+  if (line.includes(updated)) {
+    return fileContent;
+  }
+  if (!line.includes(current)) {
+    logger.debug(
+      { depName, lineNumber },
+      "go-install: current line doesn't contain dependency",
+    );
+    return null;
+  }
+  lines[lineNumber] = line.replace(current, updated);
+  return lines.join('\n');
+}
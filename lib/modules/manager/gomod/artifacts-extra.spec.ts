@@ -138,6 +138,35 @@ describe('modules/manager/gomod/artifacts-extra', () => {
       );
     });
 
+    it('returns a notice for lock file maintenance', () => {
+      const excludeDeps = ['go'];
+
+      const res = getExtraDepsNotice(
+        goModBefore,
+        goModAfter,
+        excludeDeps,
+        true,
+      );
+
+      expect(res).toEqual(
+        [
+          'In order to refresh the indirect requirements, Renovate ran the `go get` and `go mod tidy` commands, which resulted in the following change(s):',
+          '',
+          '',
+          '- 2 dependencies were updated',
+          '',
+          '',
+          'Details:',
+          '',
+          '',
+          '| **Package**          | **Change**           |',
+          '| :------------------- | :------------------- |',
+          '| `github.com/foo/foo` | `v1.0.0` -> `v1.1.1` |',
+          '| `github.com/bar/bar` | `v2.0.0` -> `v2.2.2` |',
+        ].join('\n'),
+      );
+    });
+
     it('adds special notice for updated `go` version', () => {
       const excludeDeps = ['github.com/foo/foo'];
 
@@ -84,6 +84,7 @@ export function getExtraDepsNotice(
   goModBefore: string | null,
   goModAfter: string | null,
   excludeDeps: string[],
+  isLockFileMaintenance = false,
 ): string | null {
   if (!goModBefore || !goModAfter) {
     return null;
@@ -95,9 +96,12 @@ export function getExtraDepsNotice(
   }
 
   const noticeLines: string[] = [
-    'In order to perform the update(s) described in the table above, Renovate ran the `go get` command, which resulted in the following additional change(s):',
+    isLockFileMaintenance
+      ? 'In order to refresh the indirect requirements, Renovate ran the `go get` and `go mod tidy` commands, which resulted in the following change(s):'
+      : 'In order to perform the update(s) described in the table above, Renovate ran the `go get` command, which resulted in the following additional change(s):',
     '\n',
   ];
+  const additional = isLockFileMaintenance ? '' : 'additional ';
 
   const goUpdated = extraDeps.some(({ depName }) => depName === 'go');
   const toolchainUpdated = extraDeps.some(
@@ -107,10 +111,12 @@ export function getExtraDepsNotice(
     extraDeps.length - (goUpdated ? 1 : 0) - (toolchainUpdated ? 1 : 0);
 
   if (otherDepsCount === 1) {
-    noticeLines.push(`- ${otherDepsCount} additional dependency was updated`);
+    noticeLines.push(
+      `- ${otherDepsCount} ${additional}dependency was updated`,
+    );
   } else if (otherDepsCount > 1) {
     noticeLines.push(
-      `- ${otherDepsCount} additional dependencies were updated`,
+      `- ${otherDepsCount} ${additional}dependencies were updated`,
     );
   }
 
@@ -1,22 +1,29 @@
 import { codeBlock } from 'common-tags';
 import upath from 'upath';
 import { mockDeep } from 'vitest-mock-extended';
-import { envMock, mockExecAll } from '~test/exec-util.ts';
-import { env, fs, git, partial } from '~test/util.ts';
+import { envMock, exec, mockExecAll } from '~test/exec-util.ts';
+import { env, fs, git, partial, scm } from '~test/util.ts';
 import { GlobalConfig } from '../../../config/global.ts';
 import type {
   InternalGlobalConfigOptions,
   RepoGlobalConfig,
 } from '../../../config/types.ts';
 import { TEMPORARY_ERROR } from '../../../constants/error-messages.ts';
+import * as memCache from '../../../util/cache/memory/index.ts';
+import { setUserEnv } from '../../../util/env.ts';
 import * as docker from '../../../util/exec/docker/index.ts';
 import type { StatusResult } from '../../../util/git/types.ts';
 import * as hostRules from '../../../util/host-rules.ts';
 import * as _datasource from '../../datasource/index.ts';
+import * as bazelGoDeps from '../bazel-module/go-deps.ts';
 import type { UpdateArtifactsConfig } from '../types.ts';
-import { deriveGoToolchainConstraints } from './artifacts.ts';
+import {
+  deriveGoToolchainConstraints,
+  getIndirectModules,
+} from './artifacts.ts';
 import * as _artifactsExtra from './artifacts-extra.ts';
 import * as gomod from './index.ts';
+import * as _sumdb from './sumdb.ts';
 
 type FS = typeof import('../../../util/fs/index.ts');
 
@@ -31,11 +38,13 @@ vi.mock('../../../util/fs/index.ts', async () => {
 });
 vi.mock('../../datasource/index.ts', () => mockDeep());
 vi.mock('./artifacts-extra.ts', () => mockDeep());
+vi.mock('./sumdb.ts');
 
 process.env.CONTAINERBASE = 'true';
 
 const datasource = vi.mocked(_datasource);
 const artifactsExtra = vi.mocked(_artifactsExtra);
+const sumdb = vi.mocked(_sumdb);
 
 const gomod1 = codeBlock`
   module github.com/renovate-tests/gomod1
@@ -88,6 +97,7 @@ describe('modules/manager/gomod/artifacts', () => {
     GlobalConfig.set(adminConfig);
     docker.resetPrefetchedImages();
     hostRules.clear();
+    scm.getFileList.mockResolvedValue([]);
   });
 
   afterEach(() => {
@@ -107,6 +117,81 @@ describe('modules/manager/gomod/artifacts', () => {
     expect(execSnapshots).toBeEmptyArray();
   });
 
+  it('syncs go and toolchain directives if no go.sum found', async () => {
+    const execSnapshots = mockExecAll();
+    const goMod = codeBlock`
+      module github.com/renovate-tests/gomod1
+
+      go 1.22.3
+
+      toolchain go1.22.1
+    `;
+
+    const res = await gomod.updateArtifacts({
+      packageFileName: 'go.mod',
+      updatedDeps: [{ depName: 'go', depType: 'golang' }],
+      newPackageFileContent: goMod,
+      config: { ...config, postUpdateOptions: ['goToolchainSync'] },
+    });
+
+    const syncedGoMod = codeBlock`
+      module github.com/renovate-tests/gomod1
+
+      go 1.22.3
+    `;
+    expect(res).toEqual([
+      {
+        file: {
+          type: 'addition',
+          path: 'go.mod',
+          contents: `${syncedGoMod}\n`,
+        },
+      },
+    ]);
+    expect(fs.writeLocalFile).toHaveBeenCalledExactlyOnceWith(
+      'go.mod',
+      `${syncedGoMod}\n`,
+    );
+    expect(execSnapshots).toBeEmptyArray();
+  });
+
+  it('writes the synced .go-version before running go', async () => {
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce(null); // go.work
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce('.go-version');
+    fs.readLocalFile.mockResolvedValueOnce('1.21\n');
+    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+    const execSnapshots = mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: [],
+      }),
+    );
+
+    const res = await gomod.updateArtifacts({
+      packageFileName: 'go.mod',
+      updatedDeps: [{ depName: 'go', depType: 'golang' }],
+      newPackageFileContent: `go 1.22.0\n\n${gomod1}`,
+      config: { ...config, postUpdateOptions: ['goToolchainSync'] },
+    });
+
+    expect(res).toEqual([
+      {
+        file: {
+          type: 'addition',
+          path: '.go-version',
+          contents: '1.22\n',
+        },
+      },
+    ]);
+    expect(execSnapshots).not.toBeEmptyArray();
+    const goVersionWrite = fs.writeLocalFile.mock.calls.findIndex(
+      ([file]) => file === '.go-version',
+    );
+    expect(
+      fs.writeLocalFile.mock.invocationCallOrder[goVersionWrite],
+    ).toBeLessThan(exec.mock.invocationCallOrder[0]);
+  });
+
   it('returns null if unchanged', async () => {
     fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
     fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
@@ -145,6 +230,71 @@ describe('modules/manager/gomod/artifacts', () => {
     ]);
   });
 
+  it('passes GOAUTH and NETRC to go', async () => {
+    process.env.GOAUTH = 'netrc';
+    process.env.NETRC = '/tmp/renovate/.netrc';
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
+    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    const execSnapshots = mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: [],
+      }),
+    );
+
+    expect(
+      await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [],
+        newPackageFileContent: gomod1,
+        config,
+      }),
+    ).toBeNull();
+    expect(execSnapshots).toMatchObject([
+      {
+        cmd: 'go get -d -t ./...',
+        options: {
+          env: {
+            GOAUTH: 'netrc',
+            NETRC: '/tmp/renovate/.netrc',
+          },
+        },
+      },
+    ]);
+
+    delete process.env.GOAUTH;
+    delete process.env.NETRC;
+  });
+
+  it('ignores GOAUTH and NETRC from the repository env', async () => {
+    memCache.init();
+    setUserEnv({ GOAUTH: 'auth-helper', NETRC: '/repo/.netrc' });
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
+    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    const execSnapshots = mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: [],
+      }),
+    );
+
+    expect(
+      await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [],
+        newPackageFileContent: gomod1,
+        config,
+      }),
+    ).toBeNull();
+    expect(execSnapshots).toHaveLength(1);
+    expect(execSnapshots[0].options?.env).not.toHaveProperty('GOAUTH');
+    expect(execSnapshots[0].options?.env).not.toHaveProperty('NETRC');
+
+    memCache.reset();
+  });
+
   it('returns updated go.sum', async () => {
     fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
     fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
@@ -192,6 +342,128 @@ describe('modules/manager/gomod/artifacts', () => {
     ]);
   });
 
+  it('returns updated MODULE.bazel.lock for go_deps.from_file', async () => {
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
+    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: ['go.sum'],
+      }),
+    );
+    fs.readLocalFile.mockResolvedValueOnce('New go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(gomod1);
+    const updateGoDepsLockfile = vi
+      .spyOn(bazelGoDeps, 'updateGoDepsLockfile')
+      .mockResolvedValueOnce([
+        {
+          file: {
+            type: 'addition',
+            path: 'MODULE.bazel.lock',
+            contents: 'New MODULE.bazel.lock',
+          },
+        },
+      ]);
+
+    expect(
+      await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [],
+        newPackageFileContent: gomod1,
+        config: { ...config, constraints: { bazelisk: '1.20.0' } },
+      }),
+    ).toEqual([
+      {
+        file: {
+          contents: 'New go.sum',
+          path: 'go.sum',
+          type: 'addition',
+        },
+      },
+      {
+        file: {
+          contents: 'New MODULE.bazel.lock',
+          path: 'MODULE.bazel.lock',
+          type: 'addition',
+        },
+      },
+    ]);
+    expect(updateGoDepsLockfile).toHaveBeenCalledExactlyOnceWith(
+      'go.mod',
+      gomod1,
+      '1.20.0',
+    );
+  });
+
+  it('verifies go.sum with gomodVerifySumdb', async () => {
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
+    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: ['go.sum'],
+      }),
+    );
+    fs.readLocalFile.mockResolvedValueOnce('New go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(gomod1);
+
+    const res = await gomod.updateArtifacts({
+      packageFileName: 'go.mod',
+      updatedDeps: [],
+      newPackageFileContent: gomod1,
+      config: { ...config, postUpdateOptions: ['gomodVerifySumdb'] },
+    });
+
+    expect(res).toEqual([
+      {
+        file: {
+          contents: 'New go.sum',
+          path: 'go.sum',
+          type: 'addition',
+        },
+      },
+    ]);
+    expect(sumdb.verifyGoSum).toHaveBeenCalledExactlyOnceWith(
+      'Current go.sum',
+      'New go.sum',
+      expect.any(Object),
+    );
+  });
+
+  it('returns an artifact error if go.sum verification fails', async () => {
+    fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
+    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: ['go.sum'],
+      }),
+    );
+    fs.readLocalFile.mockResolvedValueOnce('New go.sum');
+    sumdb.verifyGoSum.mockRejectedValueOnce(
+      new Error('go.sum lines do not match the checksum database'),
+    );
+
+    const res = await gomod.updateArtifacts({
+      packageFileName: 'go.mod',
+      updatedDeps: [],
+      newPackageFileContent: gomod1,
+      config: { ...config, postUpdateOptions: ['gomodVerifySumdb'] },
+    });
+
+    expect(res).toEqual([
+      {
+        artifactError: {
+          fileName: 'go.sum',
+          stderr: 'go.sum lines do not match the checksum database',
+        },
+      },
+    ]);
+  });
+
   it('runs go mod vendor with gomodVendor', async () => {
     fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
     fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
@@ -1743,6 +2015,15 @@ describe('modules/manager/gomod/artifacts', () => {
     fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
     fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
     fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    scm.getFileList.mockResolvedValueOnce(['go.mod', 'go.sum', 'main.go']);
+    fs.readLocalFile.mockResolvedValueOnce(codeBlock`
+      package main
+
+      import (
+      	"github.com/google/go-github/v24/github"
+      	gh "github.com/google/go-github/v24"
+      )
+    `);
     const execSnapshots = mockExecAll();
     git.getRepoStatus.mockResolvedValueOnce(
       partial<StatusResult>({
@@ -1771,19 +2052,22 @@ describe('modules/manager/gomod/artifacts', () => {
       { file: { type: 'addition', path: 'main.go', contents: 'New main.go' } },
       { file: { type: 'addition', path: 'go.mod', contents: 'New go.mod' } },
     ]);
+    expect(fs.writeLocalFile).toHaveBeenCalledWith(
+      'main.go',
+      codeBlock`
+        package main
+
+        import (
+        	"github.com/google/go-github/v28/github"
+        	gh "github.com/google/go-github/v28"
+        )
+      `,
+    );
     expect(execSnapshots).toMatchObject([
       {
         cmd: 'go get -d -t ./...',
         options: { cwd: '/tmp/github/some/repo' },
       },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@latest',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
       {
         cmd: 'go mod tidy',
         options: { cwd: '/tmp/github/some/repo' },
@@ -1795,10 +2079,14 @@ describe('modules/manager/gomod/artifacts', () => {
     ]);
   });
 
-  it('quotes a depName containing shell metacharacters when updating import paths', async () => {
+  it('rewrites import paths and tidies on replacement updates', async () => {
     fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
     fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
     fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
+    scm.getFileList.mockResolvedValueOnce(['go.mod', 'go.sum', 'main.go']);
+    fs.readLocalFile.mockResolvedValueOnce(
+      'package main\n\nimport "github.com/pkg/errors"\n',
+    );
     const execSnapshots = mockExecAll();
     git.getRepoStatus.mockResolvedValueOnce(
       partial<StatusResult>({
@@ -1809,41 +2097,38 @@ describe('modules/manager/gomod/artifacts', () => {
       .mockResolvedValueOnce('New go.sum')
       .mockResolvedValueOnce('New main.go')
       .mockResolvedValueOnce('New go.mod');
-    await gomod.updateArtifacts({
-      packageFileName: 'go.mod',
-      updatedDeps: [
-        // depName as it would be parsed from an attacker-controlled `require`
-        // line in go.mod
-        { depName: 'github.com/foo;id', newVersion: 'v28.0.0' },
-      ],
-      newPackageFileContent: gomod1,
-      config: {
-        ...config,
-        updateType: 'major',
-        postUpdateOptions: ['gomodUpdateImportPaths'],
-      },
-    });
+    expect(
+      await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [
+          {
+            depName: 'github.com/pkg/errors',
+            depType: 'require',
+            newName: 'github.com/go-errors/errors',
+            newVersion: 'v1.5.1',
+            updateType: 'replacement',
+          },
+        ],
+        newPackageFileContent: gomod1,
+        config: {
+          ...config,
+          updateType: 'replacement',
+          postUpdateOptions: [],
+        },
+      }),
+    ).toEqual([
+      { file: { type: 'addition', path: 'go.sum', contents: 'New go.sum' } },
+      { file: { type: 'addition', path: 'main.go', contents: 'New main.go' } },
+      { file: { type: 'addition', path: 'go.mod', contents: 'New go.mod' } },
+    ]);
+    expect(fs.writeLocalFile).toHaveBeenCalledWith(
+      'main.go',
+      'package main\n\nimport "github.com/go-errors/errors"\n',
+    );
     expect(execSnapshots).toMatchObject([
-      {
-        cmd: 'go get -d -t ./...',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@latest',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: "mod upgrade --mod-name='github.com/foo;id' -t=28",
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'go mod tidy',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'go mod tidy',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
+      { cmd: 'go get -d -t ./...' },
+      { cmd: 'go mod tidy' },
+      { cmd: 'go mod tidy' },
     ]);
   });
 
@@ -1885,18 +2170,6 @@ describe('modules/manager/gomod/artifacts', () => {
         cmd: 'go get -d -t ./...',
         options: { cwd: '/tmp/github/some/repo' },
       },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@latest',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=github.com/caarlos0/env/v6 -t=7',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
       {
         cmd: 'go mod tidy',
         options: { cwd: '/tmp/github/some/repo' },
@@ -2125,7 +2398,7 @@ describe('modules/manager/gomod/artifacts', () => {
     ]);
   });
 
-  it('updates import paths with specific tool version from constraint', async () => {
+  it('ignores the gomodMod constraint when updating import paths', async () => {
     fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
     fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
     fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
@@ -2166,74 +2439,6 @@ describe('modules/manager/gomod/artifacts', () => {
         cmd: 'go get -d -t ./...',
         options: { cwd: '/tmp/github/some/repo' },
       },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@v1.2.3',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'go mod tidy',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'go mod tidy',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-    ]);
-  });
-
-  it('updates import paths with latest tool version on invalid version constraint', async () => {
-    fs.findLocalSiblingOrParent.mockResolvedValueOnce('vendor');
-    fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
-    fs.readLocalFile.mockResolvedValueOnce(null); // vendor modules filename
-    const execSnapshots = mockExecAll();
-    git.getRepoStatus.mockResolvedValueOnce(
-      partial<StatusResult>({
-        modified: ['go.sum', 'main.go'],
-      }),
-    );
-    fs.readLocalFile
-      .mockResolvedValueOnce('New go.sum')
-      .mockResolvedValueOnce('New main.go')
-      .mockResolvedValueOnce('New go.mod');
-    expect(
-      await gomod.updateArtifacts({
-        packageFileName: 'go.mod',
-        updatedDeps: [
-          { depName: 'github.com/google/go-github/v24', newVersion: 'v28.0.0' },
-        ],
-        newPackageFileContent: gomod1,
-        config: {
-          ...config,
-          updateType: 'major',
-          postUpdateOptions: ['gomodUpdateImportPaths'],
-          constraints: {
-            gomodMod: 'a.b.c',
-            go: '1.17.0',
-          },
-        },
-      }),
-    ).toEqual([
-      { file: { type: 'addition', path: 'go.sum', contents: 'New go.sum' } },
-      { file: { type: 'addition', path: 'main.go', contents: 'New main.go' } },
-      { file: { type: 'addition', path: 'go.mod', contents: 'New go.mod' } },
-    ]);
-    expect(execSnapshots).toMatchObject([
-      {
-        cmd: 'go get -d -t ./...',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@latest',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
       {
         cmd: 'go mod tidy',
         options: { cwd: '/tmp/github/some/repo' },
@@ -2281,18 +2486,6 @@ describe('modules/manager/gomod/artifacts', () => {
         cmd: 'go get -d -t ./...',
         options: { cwd: '/tmp/github/some/repo' },
       },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@latest',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=gopkg.in/yaml.v2 -t=28',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=gopkg.in/foo.v0 -t=1',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
       {
         cmd: 'go mod tidy',
         options: { cwd: '/tmp/github/some/repo' },
@@ -2344,14 +2537,6 @@ describe('modules/manager/gomod/artifacts', () => {
         cmd: 'go get -t ./...',
         options: { cwd: '/tmp/github/some/repo' },
       },
-      {
-        cmd: 'go install github.com/marwan-at-work/mod/cmd/mod@latest',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
-      {
-        cmd: 'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28',
-        options: { cwd: '/tmp/github/some/repo' },
-      },
       {
         cmd: 'go mod tidy',
         options: { cwd: '/tmp/github/some/repo' },
@@ -2423,10 +2608,6 @@ describe('modules/manager/gomod/artifacts', () => {
           ' && ' +
           'go get -t ./...' +
           ' && ' +
-          'go install github.com/marwan-at-work/mod/cmd/mod@latest' +
-          ' && ' +
-          'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28' +
-          ' && ' +
           'go mod tidy ' +
           '&& ' +
           'go mod tidy' +
@@ -2621,10 +2802,6 @@ describe('modules/manager/gomod/artifacts', () => {
           ' && ' +
           'go get -d -t ./...' +
           ' && ' +
-          'go install github.com/marwan-at-work/mod/cmd/mod@latest' +
-          ' && ' +
-          'mod upgrade --mod-name=github.com/google/go-github/v24 -t=28' +
-          ' && ' +
           'go mod tidy ' +
           '&& ' +
           'go mod tidy' +
@@ -2888,6 +3065,126 @@ describe('modules/manager/gomod/artifacts', () => {
     ]);
   });
 
+  describe('lock file maintenance', () => {
+    const goMod = codeBlock`
+      module github.com/renovate-tests/gomod1
+
+      go 1.22.0
+
+      require github.com/pkg/errors v0.9.1
+
+      require (
+        golang.org/x/sys v0.20.0 // indirect
+        golang.org/x/text v0.15.0 // indirect
+        golang.org/x/net v0.25.0 // indirect
+      )
+
+      replace golang.org/x/net => ../net
+    `;
+
+    it('refreshes indirect requirements', async () => {
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce(null);
+      fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+      const execSnapshots = mockExecAll();
+      git.getRepoStatus.mockResolvedValueOnce(
+        partial<StatusResult>({
+          modified: ['go.sum', 'go.mod'],
+        }),
+      );
+      fs.readLocalFile
+        .mockResolvedValueOnce('New go.sum')
+        .mockResolvedValueOnce('New go.mod');
+      artifactsExtra.getExtraDepsNotice.mockReturnValueOnce('refreshed deps');
+
+      const res = await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [],
+        newPackageFileContent: goMod,
+        config: {
+          ...config,
+          postUpdateOptions: [],
+          updateType: 'lockFileMaintenance',
+          isLockFileMaintenance: true,
+        },
+      });
+
+      expect(res).toEqual([
+        { file: { type: 'addition', path: 'go.sum', contents: 'New go.sum' } },
+        {
+          file: { type: 'addition', path: 'go.mod', contents: 'New go.mod' },
+          notice: { file: 'go.mod', message: 'refreshed deps' },
+        },
+      ]);
+      expect(execSnapshots).toMatchObject([
+        { cmd: 'go get -d -u=patch golang.org/x/sys golang.org/x/text' },
+        { cmd: 'go mod tidy' },
+        { cmd: 'go mod tidy' },
+      ]);
+      expect(artifactsExtra.getExtraDepsNotice).toHaveBeenCalledWith(
+        goMod,
+        'New go.mod',
+        [],
+        true,
+      );
+    });
+
+    it('updates to minor releases with gomodLockFileMaintenanceMinor', async () => {
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce(null);
+      fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+      const execSnapshots = mockExecAll();
+      git.getRepoStatus.mockResolvedValueOnce(
+        partial<StatusResult>({
+          modified: [],
+        }),
+      );
+
+      const res = await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [],
+        newPackageFileContent: goMod,
+        config: {
+          constraints: { go: '1.22' },
+          postUpdateOptions: ['gomodLockFileMaintenanceMinor'],
+          updateType: 'lockFileMaintenance',
+          isLockFileMaintenance: true,
+        },
+      });
+
+      expect(res).toBeNull();
+      expect(execSnapshots).toMatchObject([
+        { cmd: 'go get -u golang.org/x/sys golang.org/x/text' },
+        { cmd: 'go mod tidy' },
+        { cmd: 'go mod tidy' },
+      ]);
+    });
+
+    it('skips files without indirect requirements', async () => {
+      fs.readLocalFile.mockResolvedValueOnce('Current go.sum');
+      const execSnapshots = mockExecAll();
+
+      const res = await gomod.updateArtifacts({
+        packageFileName: 'go.mod',
+        updatedDeps: [],
+        newPackageFileContent: gomod1,
+        config: {
+          ...config,
+          updateType: 'lockFileMaintenance',
+          isLockFileMaintenance: true,
+        },
+      });
+
+      expect(res).toBeNull();
+      expect(execSnapshots).toBeEmptyArray();
+    });
+
+    it('returns the indirect modules', () => {
+      expect(getIndirectModules(goMod)).toEqual([
+        'golang.org/x/sys',
+        'golang.org/x/text',
+      ]);
+    });
+  });
+
   describe('deriveGoToolchainConstraints', () => {
     it('returns config constraint when set', () => {
       expect(
@@ -19,86 +19,26 @@ import {
 import { withGitEnvironment } from '../../../util/git/exec.ts';
 import { getRepoStatus } from '../../../util/git/index.ts';
 import { regEx } from '../../../util/regex.ts';
-import { isValid } from '../../versioning/semver/index.ts';
+import { getGoAuthEnv } from '../../datasource/go/goauth.ts';
+import { updateGoDepsLockfile } from '../bazel-module/go-deps.ts';
 import type {
-  PackageDependency,
   UpdateArtifact,
   UpdateArtifactsConfig,
   UpdateArtifactsResult,
 } from '../types.ts';
 import { getExtraDepsNotice } from './artifacts-extra.ts';
+import {
+  getMajorImportPathRewrites,
+  getReplacementImportPathRewrites,
+  rewriteImportPaths,
+} from './import-paths.ts';
 import { getGoModulesInTidyOrder } from './package-tree.ts';
+import { getDirectives, isIndirect, parseGoMod } from './parser.ts';
+import { verifyGoSum } from './sumdb.ts';
+import { isGoToolchainUpdate, syncGoToolchainFiles } from './toolchain.ts';
 
-const { major, valid } = semver;
 const gitExec = withGitEnvironment(['go']);
 
-function getUpdateImportPathCmds(
-  updatedDeps: PackageDependency[],
-  { constraints }: UpdateArtifactsConfig,
-): string[] {
-  // Check if we fail to parse any major versions and log that they're skipped
-  const invalidMajorDeps = updatedDeps.filter(
-    ({ newVersion }) => !valid(newVersion),
-  );
-  if (invalidMajorDeps.length > 0) {
-    invalidMajorDeps.forEach(({ depName }) =>
-      logger.warn(
-        { depName },
-        'Ignoring dependency: Could not get major version',
-      ),
-    );
-  }
-
-  const updateImportCommands = updatedDeps
-    .filter(
-      ({ newVersion }) =>
-        valid(newVersion) && !newVersion!.endsWith('+incompatible'),
-    )
-    .map(({ depName, newVersion }) => ({
-      depName: depName!,
-      newMajor: major(newVersion!),
-    }))
-    // Skip path updates going from v0 to v1
-    .filter(
-      ({ depName, newMajor }) =>
-        depName.startsWith('gopkg.in/') || newMajor > 1,
-    )
-
-    .map(
-      ({ depName, newMajor }) =>
-        `mod upgrade --mod-name=${quote(depName)} -t=${newMajor}`,
-    );
-
-  if (updateImportCommands.length > 0) {
-    let installMarwanModArgs =
-      'install github.com/marwan-at-work/mod/cmd/mod@latest';
-    const gomodModCompatibility = constraints?.gomodMod;
-    if (gomodModCompatibility) {
-      if (
-        gomodModCompatibility.startsWith('v') &&
-        isValid(gomodModCompatibility.replace(regEx(/^v/), ''))
-      ) {
-        installMarwanModArgs = installMarwanModArgs.replace(
-          regEx(/@latest$/),
-          `@${gomodModCompatibility}`,
-        );
-      } else {
-        logger.debug(
-          { gomodModCompatibility },
-          'marwan-at-work/mod compatibility range is not valid - skipping',
-        );
-      }
-    } else {
-      logger.debug(
-        'No marwan-at-work/mod compatibility range found - installing marwan-at-work/mod latest',
-      );
-    }
-    updateImportCommands.unshift(`go ${installMarwanModArgs}`);
-  }
-
-  return updateImportCommands;
-}
-
 function useModcacherw(goVersion: string | undefined): boolean {
   if (!isString(goVersion)) {
     return true;
@@ -107,6 +47,29 @@ function useModcacherw(goVersion: string | undefined): boolean {
   return semver.intersects(goVersion, `>=1.14`);
 }
 
+/**
+ * Returns the modules of the `// indirect` requirements, which lock file maintenance refreshes.
+ *
+ * Replaced modules are skipped, because their required version isn't used.
+ */
+export function getIndirectModules(goModContent: string): string[] {
+  const directives = getDirectives(parseGoMod(goModContent));
+  const replacedModules = new Set(
+    directives
+      .filter(({ verb, args }) => verb === 'replace' && args.length)
+      .map(({ args }) => args[0].value),
+  );
+  return directives
+    .filter(
+      (directive) =>
+        directive.verb === 'require' &&
+        directive.args.length === 2 &&
+        isIndirect(directive) &&
+        !replacedModules.has(directive.args[0].value),
+    )
+    .map(({ args }) => args[0].value);
+}
+
 export async function updateArtifacts({
   packageFileName: goModFileName,
   updatedDeps,
@@ -115,11 +78,46 @@ export async function updateArtifacts({
 }: UpdateArtifact): Promise<UpdateArtifactsResult[] | null> {
   logger.debug(`gomod.updateArtifacts(${goModFileName})`);
 
+  let syncedGoModContent = newGoModContent;
+  let toolchainSyncResults: UpdateArtifactsResult[] = [];
+  if (
+    config.postUpdateOptions?.includes('goToolchainSync') &&
+    isGoToolchainUpdate(updatedDeps)
+  ) {
+    ({ content: syncedGoModContent, results: toolchainSyncResults } =
+      await syncGoToolchainFiles(goModFileName, newGoModContent));
+  }
+
   const sumFileName = goModFileName.replace(regEx(/\.mod$/), '.sum');
   const existingGoSumContent = await readLocalFile(sumFileName);
+  // returned when no go command changes go.mod
+  const syncedGoModResults: UpdateArtifactsResult[] =
+    syncedGoModContent === newGoModContent
+      ? toolchainSyncResults
+      : [
+          {
+            file: {
+              type: 'addition',
+              path: goModFileName,
+              contents: syncedGoModContent,
+            },
+          },
+          ...toolchainSyncResults,
+        ];
   if (!existingGoSumContent) {
     logger.debug('No go.sum found');
-    return null;
+    if (syncedGoModContent !== newGoModContent) {
+      await writeLocalFile(goModFileName, syncedGoModContent);
+    }
+    return syncedGoModResults.length ? syncedGoModResults : null;
+  }
+  let indirectModules: string[] = [];
+  if (config.isLockFileMaintenance) {
+    indirectModules = getIndirectModules(syncedGoModContent);
+    if (!indirectModules.length) {
+      logger.debug('No indirect requirements to refresh');
+      return null;
+    }
   }
   const goModDir = upath.dirname(goModFileName);
   const goModFileBaseName = upath.basename(goModFileName);
@@ -137,7 +135,7 @@ export async function updateArtifacts({
     (!config.postUpdateOptions?.includes('gomodSkipVendor') &&
       vendorDir &&
       (await readLocalFile(vendorModulesFileName)) !== null);
-  let massagedGoMod = newGoModContent;
+  let massagedGoMod = syncedGoModContent;
   const useGoGenerate = !!config.postUpdateOptions?.includes('goGenerate');
   const allowedUnsafeExecutions = GlobalConfig.get('allowedUnsafeExecutions');
   const goGenerateAllowed = allowedUnsafeExecutions?.includes('goGenerate');
@@ -194,13 +192,17 @@ export async function updateArtifacts({
       );
     }
   }
-  const goConstraints = deriveGoToolchainConstraints(config, newGoModContent);
+  const goConstraints = deriveGoToolchainConstraints(
+    config,
+    syncedGoModContent,
+  );
 
   try {
     await writeLocalFile(goModFileName, massagedGoMod);
 
     const cmd = 'go';
     const env = getEnv();
+    const goAuthEnv = getGoAuthEnv();
     const execOptions: ExecOptions = {
       cwdFile: goModFileName,
       extraEnv: {
@@ -211,10 +213,14 @@ export async function updateArtifacts({
         GONOSUMDB: env.GONOSUMDB,
         GOSUMDB: env.GOSUMDB,
         GOINSECURE: env.GOINSECURE,
+        GOAUTH: goAuthEnv.GOAUTH,
+        NETRC: goAuthEnv.NETRC,
         /* v8 ignore next -- TODO: add test */
         GOFLAGS: useModcacherw(goConstraints) ? '-modcacherw' : null,
         CGO_ENABLED: GlobalConfig.get('binarySource') === 'docker' ? '0' : null,
       },
+      // overrides the `env` config of the repository, as GOAUTH runs commands
+      env: goAuthEnv,
       docker: {},
       toolConstraints: [
         {
@@ -252,7 +258,17 @@ export async function updateArtifacts({
       args += `-d `;
     }
 
-    args += `-t ${goGetDirs ?? './...'}`;
+    if (config.isLockFileMaintenance) {
+      // -u=patch keeps the refresh to patch releases unless asked otherwise
+      const updateFlag = config.postUpdateOptions?.includes(
+        'gomodLockFileMaintenanceMinor',
+      )
+        ? '-u'
+        : '-u=patch';
+      args += `${updateFlag} ${indirectModules.map(quote).join(' ')}`;
+    } else {
+      args += `-t ${goGetDirs ?? './...'}`;
+    }
     logger.trace({ cmd, args }, 'go get command included');
     execCommands.push(`${cmd} ${args}`);
 
@@ -261,13 +277,20 @@ export async function updateArtifacts({
       config.postUpdateOptions?.includes('gomodUpdateImportPaths') &&
       config.updateType === 'major';
 
+    // Replaced modules are imported by their new path from now on
+    const isReplacementUpdate = updatedDeps.some(
+      ({ updateType }) => updateType === 'replacement',
+    );
+    const importPathRewrites = getReplacementImportPathRewrites(updatedDeps);
     if (isImportPathUpdateRequired) {
-      const updateImportCmds = getUpdateImportPathCmds(updatedDeps, config);
-      if (updateImportCmds.length > 0) {
-        logger.debug(updateImportCmds, 'update import path commands included');
-        // The updates
-        execCommands.push(...updateImportCmds);
-      }
+      importPathRewrites.push(...getMajorImportPathRewrites(updatedDeps));
+    }
+    let rewrittenGoFiles: string[] = [];
+    if (importPathRewrites.length > 0) {
+      rewrittenGoFiles = await rewriteImportPaths(
+        goModFileName,
+        importPathRewrites,
+      );
     }
 
     const mustSkipGoModTidy =
@@ -294,6 +317,8 @@ export async function updateArtifacts({
         config.postUpdateOptions?.includes('gomodTidy1.17') === true ||
         config.postUpdateOptions?.includes('gomodTidyE') === true ||
         isGoModTidyAllRequired ||
+        isReplacementUpdate ||
+        !!config.isLockFileMaintenance ||
         (config.updateType === 'major' && isImportPathUpdateRequired));
     if (isGoModTidyRequired) {
       args = `mod tidy${modFileFlag}${tidyOpts}`;
@@ -381,17 +406,25 @@ export async function updateArtifacts({
       !status.modified.includes(goWorkSumFileName) &&
       !dependentFiles.some((f) => status.modified.includes(f))
     ) {
-      return null;
+      return syncedGoModResults.length ? syncedGoModResults : null;
     }
 
-    const res: UpdateArtifactsResult[] = [];
+    const res: UpdateArtifactsResult[] = [...toolchainSyncResults];
     if (status.modified.includes(sumFileName)) {
+      const newGoSumContent = await readLocalFile(sumFileName);
+      if (config.postUpdateOptions?.includes('gomodVerifySumdb')) {
+        await verifyGoSum(
+          existingGoSumContent.toString(),
+          newGoSumContent?.toString() ?? '',
+          env,
+        );
+      }
       logger.debug('Returning updated go.sum');
       res.push({
         file: {
           type: 'addition',
           path: sumFileName,
-          contents: await readLocalFile(sumFileName),
+          contents: newGoSumContent,
         },
       });
     }
@@ -421,7 +454,7 @@ export async function updateArtifacts({
     }
 
     // Include all the .go file import changes
-    if (isImportPathUpdateRequired) {
+    if (isImportPathUpdateRequired || rewrittenGoFiles.length > 0) {
       logger.debug('Returning updated go source files for import path changes');
       for (const f of status.modified) {
         if (f.endsWith('.go')) {
@@ -482,6 +515,7 @@ export async function updateArtifacts({
         newGoModContent,
         finalGoModContent,
         updatedDepNames,
+        !!config.isLockFileMaintenance,
       );
 
       if (extraDepsNotice) {
@@ -496,6 +530,18 @@ export async function updateArtifacts({
       alreadyAdded.add(goModFileName);
     }
 
+    // Bazel's go_deps extension may read this go.mod via `go_deps.from_file`
+    for (const bazelResult of await updateGoDepsLockfile(
+      goModFileName,
+      finalGoModContent,
+      config.constraints?.bazelisk,
+    )) {
+      if (bazelResult.file) {
+        alreadyAdded.add(bazelResult.file.path);
+      }
+      res.push(bazelResult);
+    }
+
     // add all files added when in `go generate` mode.
     // unfortunately there is not a good way as there is with vendoring or go import path updates to detect this.
     // Do this at the very very end to ensure we only capture files which would have been explicitly
@@ -425,6 +425,34 @@ describe('modules/manager/gomod/extract', () => {
     expect(res).toBeNull();
   });
 
+  it('extracts directives with trailing comments', () => {
+    const goMod = codeBlock`
+        module github.com/renovate-tests/gomod
+        go 1.23 // minimum supported version
+        require github.com/pkg/errors v0.9.1 // indirect; needed by tests
+      `;
+    const res = extractPackageFile(goMod);
+    expect(res?.deps).toEqual([
+      {
+        managerData: { lineNumber: 1 },
+        depName: 'go',
+        depType: 'golang',
+        currentValue: '1.23',
+        datasource: 'golang-version',
+        versioning: 'go-mod-directive',
+        commitMessageTopic: 'go module directive',
+      },
+      {
+        managerData: { lineNumber: 2 },
+        depName: 'github.com/pkg/errors',
+        depType: 'indirect',
+        enabled: false,
+        currentValue: 'v0.9.1',
+        datasource: 'go',
+      },
+    ]);
+  });
+
   it('marks placeholder pseudo versions with skipReason invalid-version', () => {
     const goMod = codeBlock`
         module github.com/renovate-tests/gomod
@@ -1,10 +1,6 @@
-import { newlineRegex } from '../../../util/regex.ts';
 import type { PackageDependency, PackageFileContent } from '../types.ts';
-import {
-  endBlockRegex,
-  excludeBlockStartRegex,
-  parseLine,
-} from './line-parser.ts';
+import { parseDirective } from './line-parser.ts';
+import { getDirectives, parseGoMod } from './parser.ts';
 
 function findMatchingModule(
   tool: PackageDependency,
@@ -29,24 +25,10 @@ function findMatchingModule(
 export function extractPackageFile(content: string): PackageFileContent | null {
   const deps: PackageDependency[] = [];
   const tools: PackageDependency[] = [];
-  let inExcludeBlock = false;
-
-  const lines = content.split(newlineRegex);
-  for (let lineNumber = 0; lineNumber < lines.length; lineNumber += 1) {
-    const line = lines[lineNumber];
-    const dep = parseLine(line);
-
-    if (inExcludeBlock) {
-      if (endBlockRegex.test(line)) {
-        inExcludeBlock = false;
-      }
-      continue;
-    }
 
+  for (const directive of getDirectives(parseGoMod(content))) {
+    const dep = parseDirective(directive);
     if (!dep) {
-      if (excludeBlockStartRegex.test(line)) {
-        inExcludeBlock = true;
-      }
       continue;
     }
 
@@ -56,7 +38,7 @@ export function extractPackageFile(content: string): PackageFileContent | null {
     }
 
     dep.managerData ??= {};
-    dep.managerData.lineNumber = lineNumber;
+    dep.managerData.lineNumber = directive.lineNumber;
 
     deps.push(dep);
   }
@@ -0,0 +1,249 @@
+import { codeBlock } from 'common-tags';
+import { fs, scm } from '~test/util.ts';
+import {
+  getMajorImportPathRewrites,
+  getNewMajorModulePath,
+  getReplacementImportPathRewrites,
+  rewriteImportPaths,
+  rewriteImports,
+} from './import-paths.ts';
+
+vi.mock('../../../util/fs/index.ts');
+
+const rewrites = [
+  { from: 'github.com/pkg/errors', to: 'github.com/go-errors/errors' },
+];
+
+describe('modules/manager/gomod/import-paths', () => {
+  describe('rewriteImports()', () => {
+    it('rewrites single and grouped imports', () => {
+      const content = codeBlock`
+        package main
+
+        import "github.com/pkg/errors"
+        import pkgerrors "github.com/pkg/errors/sub"
+
+        import (
+        	"fmt"
+
+        	_ "github.com/pkg/errors"
+        	"github.com/pkg/errorsx"
+        )
+
+        func main() {
+        	fmt.Println("github.com/pkg/errors")
+        }
+      `;
+
+      expect(rewriteImports(content, rewrites)).toBe(codeBlock`
+        package main
+
+        import "github.com/go-errors/errors"
+        import pkgerrors "github.com/go-errors/errors/sub"
+
+        import (
+        	"fmt"
+
+        	_ "github.com/go-errors/errors"
+        	"github.com/pkg/errorsx"
+        )
+
+        func main() {
+        	fmt.Println("github.com/pkg/errors")
+        }
+      `);
+    });
+
+    it('keeps imports of other major versions', () => {
+      const content = codeBlock`
+        import (
+        	"github.com/caarlos0/env"
+        	"github.com/caarlos0/env/v11"
+        )
+      `;
+
+      expect(
+        rewriteImports(content, [
+          { from: 'github.com/caarlos0/env', to: 'github.com/caarlos0/env/v6' },
+        ]),
+      ).toBe(codeBlock`
+        import (
+        	"github.com/caarlos0/env/v6"
+        	"github.com/caarlos0/env/v11"
+        )
+      `);
+    });
+
+    it('rewrites raw string imports', () => {
+      expect(rewriteImports('import `github.com/pkg/errors`', rewrites)).toBe(
+        'import `github.com/go-errors/errors`',
+      );
+    });
+
+    it('skips comments and strings in grouped imports', () => {
+      const content = codeBlock`
+        package main
+
+        // import "github.com/pkg/errors"
+        import (
+        	"fmt" // fmt.Println(x)
+        	/* errors (wrapped) */ "github.com/pkg/errors"
+        	pkgerrors "github.com/pkg/errors/sub"
+        )
+
+        var s = \`
+        import "github.com/pkg/errors"
+        \`
+      `;
+
+      expect(rewriteImports(content, rewrites)).toBe(codeBlock`
+        package main
+
+        // import "github.com/pkg/errors"
+        import (
+        	"fmt" // fmt.Println(x)
+        	/* errors (wrapped) */ "github.com/go-errors/errors"
+        	pkgerrors "github.com/go-errors/errors/sub"
+        )
+
+        var s = \`
+        import "github.com/pkg/errors"
+        \`
+      `);
+    });
+
+    it('returns content unchanged without matching imports', () => {
+      const content = 'package main\n\nimport "fmt"\n';
+      expect(rewriteImports(content, rewrites)).toBe(content);
+    });
+  });
+
+  describe('getNewMajorModulePath()', () => {
+    it.each`
+      modulePath                           | newMajor | expected
+      ${'github.com/google/go-github/v24'} | ${28}    | ${'github.com/google/go-github/v28'}
+      ${'github.com/caarlos0/env'}         | ${6}     | ${'github.com/caarlos0/env/v6'}
+      ${'github.com/caarlos0/env/v6'}      | ${1}     | ${'github.com/caarlos0/env'}
+      ${'gopkg.in/yaml.v2'}                | ${3}     | ${'gopkg.in/yaml.v3'}
+      ${'gopkg.in/foo.v0'}                 | ${1}     | ${'gopkg.in/foo.v1'}
+      ${'gopkg.in/gorethink/gorethink.v4'} | ${5}     | ${'gopkg.in/rethinkdb/rethinkdb-go.v5'}
+    `(
+      '$modulePath with major $newMajor is $expected',
+      ({
+        modulePath,
+        newMajor,
+        expected,
+      }: {
+        modulePath: string;
+        newMajor: number;
+        expected: string;
+      }) => {
+        expect(getNewMajorModulePath(modulePath, newMajor)).toBe(expected);
+      },
+    );
+  });
+
+  describe('getMajorImportPathRewrites()', () => {
+    it('returns rewrites for major updates', () => {
+      expect(
+        getMajorImportPathRewrites([
+          { depName: 'github.com/google/go-github/v24', newVersion: 'v28.0.0' },
+          { depName: 'gopkg.in/foo.v0', newVersion: 'v1.0.0' },
+          { depName: 'github.com/pkg/errors', newVersion: 'v1.0.0' },
+          { depName: 'github.com/foo/bar', newVersion: 'v2.0.0+incompatible' },
+          { depName: 'github.com/foo/baz', newVersion: 'vx.0.0' },
+          { newVersion: 'v2.0.0' },
+        ]),
+      ).toEqual([
+        {
+          from: 'github.com/google/go-github/v24',
+          to: 'github.com/google/go-github/v28',
+        },
+        { from: 'gopkg.in/foo.v0', to: 'gopkg.in/foo.v1' },
+      ]);
+    });
+  });
+
+  describe('getReplacementImportPathRewrites()', () => {
+    it('returns rewrites for replaced required modules only', () => {
+      expect(
+        getReplacementImportPathRewrites([
+          {
+            depName: 'github.com/pkg/errors',
+            depType: 'require',
+            newName: 'github.com/go-errors/errors',
+            updateType: 'replacement',
+          },
+          {
+            depName: 'github.com/pravesht/gocql',
+            depType: 'replace',
+            newName: 'github.com/gocql/gocql',
+            updateType: 'replacement',
+          },
+          {
+            depName: 'github.com/golang/protobuf',
+            newName: 'github.com/golang/protobuf',
+            updateType: 'replacement',
+          },
+          {
+            depName: 'github.com/aws/aws-sdk-go',
+            newValue: 'v1.15.36',
+            updateType: 'minor',
+          },
+        ]),
+      ).toEqual([
+        { from: 'github.com/pkg/errors', to: 'github.com/go-errors/errors' },
+      ]);
+    });
+  });
+
+  describe('rewriteImportPaths()', () => {
+    it('rewrites go files of the module', async () => {
+      scm.getFileList.mockResolvedValueOnce([
+        'go.mod',
+        'main.go',
+        'README.md',
+        'internal/util.go',
+        'vendor/github.com/pkg/errors/errors.go',
+        'tools/go.mod',
+        'tools/tools.go',
+      ]);
+      fs.readLocalFile.mockImplementation((file) =>
+        Promise.resolve(
+          file === 'internal/util.go'
+            ? 'package util\n\nimport "fmt"\n'
+            : 'package main\n\nimport "github.com/pkg/errors"\n',
+        ),
+      );
+
+      const res = await rewriteImportPaths('go.mod', rewrites);
+
+      expect(res).toEqual(['main.go']);
+      expect(fs.readLocalFile).toHaveBeenCalledTimes(2);
+      expect(fs.writeLocalFile).toHaveBeenCalledExactlyOnceWith(
+        'main.go',
+        'package main\n\nimport "github.com/go-errors/errors"\n',
+      );
+    });
+
+    it('rewrites go files of a nested module', async () => {
+      scm.getFileList.mockResolvedValueOnce([
+        'go.mod',
+        'main.go',
+        'tools/go.mod',
+        'tools/tools.go',
+        'tools/empty.go',
+      ]);
+      fs.readLocalFile.mockResolvedValueOnce(
+        'package tools\n\nimport _ "github.com/pkg/errors"\n',
+      );
+      fs.readLocalFile.mockResolvedValueOnce(null);
+
+      const res = await rewriteImportPaths('tools/go.mod', rewrites);
+
+      expect(res).toEqual(['tools/tools.go']);
+      expect(fs.readLocalFile).toHaveBeenCalledWith('tools/tools.go', 'utf8');
+      expect(fs.readLocalFile).toHaveBeenCalledWith('tools/empty.go', 'utf8');
+    });
+  });
+});
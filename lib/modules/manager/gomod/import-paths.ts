@@ -0,0 +1,290 @@
+import moo from 'moo';
+import semver from 'semver';
+import upath from 'upath';
+import { logger } from '../../../logger/index.ts';
+import { readLocalFile, writeLocalFile } from '../../../util/fs/index.ts';
+import { regEx } from '../../../util/regex.ts';
+import { scm } from '../../platform/scm.ts';
+import type { Upgrade } from '../types.ts';
+import type { GoImportSpec, ImportPathRewrite } from './types.ts';
+
+// https://go.dev/ref/spec#Lexical_elements
+/* oxlint-disable renovate/require-regex-util -- moo lexer patterns must be native RegExp: moo recompiles their source with the native engine and rejects RE2 instances (TODO #12870) */
+const lexer = moo.compile({
+  whitespace: { match: /\s+/, lineBreaks: true },
+  lineComment: /\/\/[^\n]*/,
+  blockComment: { match: /\/\*[^]*?\*\//, lineBreaks: true },
+  string: /"(?:\\.|[^"\\\n])*"/,
+  rawString: { match: /`[^`]*`/, lineBreaks: true },
+  rune: /'(?:\\.|[^'\\\n])+'/,
+  lparen: '(',
+  rparen: ')',
+  semicolon: ';',
+  word: /(?:[^\s"`'()/;]|\/(?![/*]))+/,
+  slash: '/',
+  error: moo.error,
+});
+/* oxlint-enable renovate/require-regex-util */
+
+function tokenize(content: string): moo.Token[] {
+  lexer.reset(content);
+  const tokens: moo.Token[] = [];
+  for (const token of lexer) {
+    if (token.type === 'error') {
+      break;
+    }
+    if (
+      token.type !== 'whitespace' &&
+      token.type !== 'lineComment' &&
+      token.type !== 'blockComment'
+    ) {
+      tokens.push(token);
+    }
+  }
+  lexer.reset();
+  return tokens;
+}
+
+/**
+ * Returns the import specs of a Go source file.
+ *
+ * Imports have to precede all other declarations, so scanning stops at the first one.
+ */
+export function getImportSpecs(content: string): GoImportSpec[] {
+  const tokens = tokenize(content);
+  const specs: GoImportSpec[] = [];
+
+  const parseSpec = (index: number): number => {
+    let name: string | undefined;
+    let token = tokens[index];
+    if (token?.type === 'word') {
+      name = token.value;
+      token = tokens[++index];
+    }
+    if (token?.type === 'string' || token?.type === 'rawString') {
+      specs.push({
+        ...(name && { name }),
+        path: token.value.slice(1, -1),
+        offset: token.offset,
+        text: token.value,
+      });
+      index++;
+    }
+    return index;
+  };
+
+  let index = 0;
+  while (index < tokens.length) {
+    const token = tokens[index];
+    if (token.type === 'semicolon') {
+      index++;
+    } else if (token.type === 'word' && token.value === 'package') {
+      index += 2;
+    } else if (token.type === 'word' && token.value === 'import') {
+      index++;
+      if (tokens[index]?.type !== 'lparen') {
+        index = parseSpec(index);
+        continue;
+      }
+      index++;
+      while (index < tokens.length && tokens[index].type !== 'rparen') {
+        const next = parseSpec(index);
+        // skip semicolons and anything which isn't an import spec
+        index = next === index ? index + 1 : next;
+      }
+      index++;
+    } else {
+      break;
+    }
+  }
+  return specs;
+}
+
+const majorSuffixRegex = regEx(/^\/v\d+(?:\/|$)/);
+
+function rewritePath(path: string, rewrites: ImportPathRewrite[]): string {
+  for (const { from, to } of rewrites) {
+    if (path !== from && !path.startsWith(`${from}/`)) {
+      continue;
+    }
+    const subPath = path.slice(from.length);
+    // `example.com/mod/v2` is a different module than `example.com/mod`
+    if (!majorSuffixRegex.test(subPath)) {
+      return `${to}${subPath}`;
+    }
+  }
+  return path;
+}
+
+/**
+ * Returns the path of a module for another major version.
+ *
+ * @example
+ * getNewMajorModulePath('github.com/google/go-github/v24', 28)
+ * // 'github.com/google/go-github/v28'
+ * getNewMajorModulePath('gopkg.in/yaml.v2', 3)
+ * // 'gopkg.in/yaml.v3'
+ */
+export function getNewMajorModulePath(
+  modulePath: string,
+  newMajor: number,
+): string {
+  if (modulePath.startsWith('gopkg.in/')) {
+    // gorethink has been renamed along with its v5 release
+    return modulePath
+      .replace(regEx(/\.v\d+$/), `.v${newMajor}`)
+      .replace('gorethink/gorethink.v5', 'rethinkdb/rethinkdb-go.v5');
+  }
+  const modulePathNoVersion = modulePath.replace(regEx(/\/v\d+$/), '');
+  return newMajor > 1
+    ? `${modulePathNoVersion}/v${newMajor}`
+    : modulePathNoVersion;
+}
+
+/**
+ * Rewrites the import paths of a Go source file.
+ *
+ * Packages within a rewritten module keep their path relative to the module root.
+ */
+export function rewriteImports(
+  content: string,
+  rewrites: ImportPathRewrite[],
+): string {
+  let result = '';
+  let lastOffset = 0;
+  for (const { path, offset, text } of getImportSpecs(content)) {
+    const newPath = rewritePath(path, rewrites);
+    if (newPath === path) {
+      continue;
+    }
+    const quote = text.charAt(0);
+    result += content.slice(lastOffset, offset) + quote + newPath + quote;
+    lastOffset = offset + text.length;
+  }
+  return result + content.slice(lastOffset);
+}
+
+/**
+ * Collects the import path rewrites needed for replacement updates.
+ *
+ * Replacement of a `replace` directive target keeps the original module path
+ * in the imports, so only required modules are considered.
+ */
+export function getReplacementImportPathRewrites(
+  updatedDeps: Upgrade[],
+): ImportPathRewrite[] {
+  const rewrites: ImportPathRewrite[] = [];
+  for (const dep of updatedDeps) {
+    const { depName, depType, newName, updateType } = dep;
+    if (
+      updateType === 'replacement' &&
+      depType !== 'replace' &&
+      depName &&
+      newName &&
+      depName !== newName
+    ) {
+      rewrites.push({ from: depName, to: newName });
+    }
+  }
+  return rewrites;
+}
+
+/**
+ * Collects the import path rewrites needed for major updates.
+ *
+ * Updates from v0 to v1 keep the module path, except for `gopkg.in` modules.
+ */
+export function getMajorImportPathRewrites(
+  updatedDeps: Upgrade[],
+): ImportPathRewrite[] {
+  const rewrites: ImportPathRewrite[] = [];
+  for (const { depName, newVersion } of updatedDeps) {
+    if (!depName) {
+      continue;
+    }
+    if (!semver.valid(newVersion)) {
+      logger.warn(
+        { depName },
+        'Ignoring dependency: Could not get major version',
+      );
+      continue;
+    }
+    if (newVersion!.endsWith('+incompatible')) {
+      continue;
+    }
+    const newMajor = semver.major(newVersion!);
+    if (!depName.startsWith('gopkg.in/') && newMajor <= 1) {
+      continue;
+    }
+    const newPath = getNewMajorModulePath(depName, newMajor);
+    if (newPath !== depName) {
+      rewrites.push({ from: depName, to: newPath });
+    }
+  }
+  return rewrites;
+}
+
+function getModuleDir(file: string, moduleDirs: string[]): string | undefined {
+  let result: string | undefined;
+  for (const dir of moduleDirs) {
+    const isWithin = dir === '.' || file.startsWith(`${dir}/`);
+    if (isWithin && (!result || dir.length > result.length)) {
+      result = dir;
+    }
+  }
+  return result;
+}
+
+/**
+ * Lists the `.go` files belonging to the module of `goModFileName`.
+ *
+ * Files in nested modules and in `vendor` directories are excluded.
+ */
+export async function getModuleGoFiles(
+  goModFileName: string,
+): Promise<string[]> {
+  const fileList = await scm.getFileList();
+  const moduleDirs = fileList
+    .filter((f) => upath.basename(f) === 'go.mod')
+    .map((f) => upath.dirname(f));
+  const goModDir = upath.dirname(goModFileName);
+
+  return fileList.filter(
+    (file) =>
+      file.endsWith('.go') &&
+      !file.split('/').includes('vendor') &&
+      getModuleDir(file, moduleDirs) === goModDir,
+  );
+}
+
+/**
+ * Rewrites import paths in all `.go` files belonging to the module of `goModFileName`.
+ *
+ * Files in nested modules and in `vendor` directories are left untouched.
+ *
+ * @returns the list of rewritten files
+ */
+export async function rewriteImportPaths(
+  goModFileName: string,
+  rewrites: ImportPathRewrite[],
+): Promise<string[]> {
+  const rewrittenFiles: string[] = [];
+  for (const file of await getModuleGoFiles(goModFileName)) {
+    const content = await readLocalFile(file, 'utf8');
+    if (!content) {
+      continue;
+    }
+
+    const newContent = rewriteImports(content, rewrites);
+    if (newContent !== content) {
+      await writeLocalFile(file, newContent);
+      rewrittenFiles.push(file);
+    }
+  }
+
+  logger.debug(
+    { rewrites, files: rewrittenFiles.length },
+    'gomod: rewrote import paths',
+  );
+  return rewrittenFiles;
+}
@@ -3,10 +3,23 @@ import { GoDatasource } from '../../datasource/go/index.ts';
 import { GolangVersionDatasource } from '../../datasource/golang-version/index.ts';
 import { updateArtifacts } from './artifacts.ts';
 import { extractPackageFile } from './extract.ts';
+import { getRangeStrategy } from './range.ts';
+import { updateLockedDependency } from './update-locked.ts';
 import { updateDependency } from './update.ts';
 
 export { knownDepTypes } from './dep-types.ts';
-export { extractPackageFile, updateArtifacts, updateDependency };
+export {
+  extractPackageFile,
+  getRangeStrategy,
+  updateArtifacts,
+  updateDependency,
+  updateLockedDependency,
+};
+
+export const supportsLockFileMaintenance = true;
+export const lockFileNames = ['go.sum'];
+export const lockFileMaintenanceIsDelegatedToPackageManager =
+  'Refreshes the `// indirect` requirements with `go get -u=patch` and `go mod tidy`.';
 
 export const displayName = 'Go Modules';
 export const url = 'https://go.dev/ref/mod';
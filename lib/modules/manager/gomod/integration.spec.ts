@@ -1,12 +1,30 @@
+import type { Osv } from '@renovatebot/osv-offline';
 import { codeBlock } from 'common-tags';
+import fs from 'fs-extra';
+import tmp from 'tmp-promise';
+import { envMock, mockExecSequence } from '~test/exec-util.ts';
 import * as httpMock from '~test/http-mock.ts';
-import { partial } from '~test/util.ts';
+import { env, partial } from '~test/util.ts';
 import { getConfig } from '../../../config/defaults.ts';
+import { GlobalConfig } from '../../../config/global.ts';
 import * as githubGraphql from '../../../util/github/graphql/index.ts';
 import { fetchUpdates } from '../../../workers/repository/process/fetch.ts';
 import type { LookupUpdateConfig } from '../../../workers/repository/process/lookup/types.ts';
+import { Vulnerabilities } from '../../../workers/repository/process/vulnerabilities.ts';
 import type { PackageFile } from '../types.ts';
 import { extractPackageFile } from './extract.ts';
+import { updateLockedDependency } from './update-locked.ts';
+
+const getVulnerabilitiesMock = vi.fn();
+
+vi.mock('../../../util/exec/env.ts');
+vi.mock('@renovatebot/osv-offline', () => ({
+  OsvOffline: class {
+    static create() {
+      return Promise.resolve({ getVulnerabilities: getVulnerabilitiesMock });
+    }
+  },
+}));
 
 describe('modules/manager/gomod/integration', () => {
   let baseConfig: LookupUpdateConfig;
@@ -141,4 +159,103 @@ describe('modules/manager/gomod/integration', () => {
       ]);
     });
   });
+
+  describe('when a module which is only in go.sum is vulnerable', () => {
+    let tmpDir: tmp.DirectoryResult;
+
+    beforeEach(async () => {
+      tmpDir = await tmp.dir({ unsafeCleanup: true });
+      GlobalConfig.set({
+        localDir: tmpDir.path,
+        cacheDir: tmpDir.path,
+        binarySource: 'global',
+      });
+      env.getChildProcessEnv.mockReturnValue(envMock.basic);
+    });
+
+    afterEach(async () => {
+      GlobalConfig.reset();
+      await tmpDir.cleanup();
+    });
+
+    it('requires its fixed version', async () => {
+      const goMod = codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.22.0
+
+        require github.com/pkg/errors v0.9.1
+      `;
+      const goSum = codeBlock`
+        github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+        github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+        golang.org/x/net v0.25.0 h1:d/OCCoBEUq33pjydKrGQhw7IlUPI2Oylr+8qLx49kac=
+        golang.org/x/net v0.25.0/go.mod h1:JkAGAh7GEvH74S6FOH42FLoXpXbE/aqXSrIQjXgsiwM=
+      `;
+      await fs.outputFile(`${tmpDir.path}/go.sum`, goSum);
+      const advisory: Osv.Vulnerability = {
+        id: 'GO-2024-3333',
+        modified: '',
+        affected: [
+          {
+            package: { name: 'golang.org/x/net', ecosystem: 'Go' },
+            ranges: [
+              {
+                type: 'SEMVER',
+                events: [{ introduced: '0' }, { fixed: '0.33.0' }],
+              },
+            ],
+          },
+        ],
+      };
+      getVulnerabilitiesMock.mockImplementation((_ecosystem, name) =>
+        Promise.resolve(name === 'golang.org/x/net' ? [advisory] : []),
+      );
+      const config = getConfig();
+      const packageFiles: Record<string, PackageFile[]> = {
+        gomod: [{ ...extractPackageFile(goMod)!, packageFile: 'go.mod' }],
+      };
+
+      const vulnerabilities = await Vulnerabilities.create();
+      await vulnerabilities.appendVulnerabilityPackageRules(
+        config,
+        packageFiles,
+      );
+      const [remediation] = (
+        config.remediations as Record<string, Record<string, string>[]>
+      )['go.sum'];
+
+      const buildList = codeBlock`
+        github.com/renovate-tests/gomod
+        github.com/pkg/errors v0.9.1
+        golang.org/x/net v0.25.0
+      `;
+      mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        {
+          stdout: 'github.com/pkg/errors@v0.9.1 golang.org/x/net@v0.25.0',
+          stderr: '',
+        },
+        { stdout: buildList.replace('v0.25.0', 'v0.33.0'), stderr: '' },
+      ]);
+      const res = await updateLockedDependency({
+        packageFile: 'go.mod',
+        packageFileContent: goMod,
+        lockFile: 'go.sum',
+        lockFileContent: goSum,
+        depName: remediation.depName,
+        currentVersion: remediation.currentVersion,
+        newVersion: remediation.newVersion,
+        allowParentUpdates: true,
+      });
+
+      expect(packageFiles.gomod[0].lockFiles).toEqual(['go.sum']);
+      expect(res).toEqual({
+        status: 'updated',
+        files: {
+          'go.mod': `${goMod}\n\nrequire golang.org/x/net v0.33.0 // indirect\n`,
+        },
+      });
+    });
+  });
 });
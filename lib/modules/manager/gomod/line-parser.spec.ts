@@ -84,6 +84,33 @@ describe('modules/manager/gomod/line-parser', () => {
     });
   });
 
+  it('should parse require definition with a branch to follow', () => {
+    const line =
+      'require foo/foo v1.4.1-0.20210101000000-000000000000 // renovate: branch=release-1.x';
+    const res = parseLine(line);
+    expect(res).toStrictEqual({
+      currentDigest: '000000000000',
+      currentValue: 'v1.4.1-0.20210101000000-000000000000',
+      datasource: 'go',
+      depName: 'foo/foo',
+      depType: 'require',
+      digestOneAndOnly: true,
+      followBranch: 'release-1.x',
+      versioning: 'loose',
+    });
+  });
+
+  it('should ignore a branch to follow without pseudo-version', () => {
+    const line = 'require foo/foo v1.4.0 // renovate: branch=release-1.x';
+    const res = parseLine(line);
+    expect(res).toStrictEqual({
+      currentValue: 'v1.4.0',
+      datasource: 'go',
+      depName: 'foo/foo',
+      depType: 'require',
+    });
+  });
+
   it('should parse require definition with placeholder pseudo-version', () => {
     const line = 'require foo/foo v0.0.0-00010101000000-000000000000';
     const res = parseLine(line);
@@ -269,6 +296,23 @@ describe('modules/manager/gomod/line-parser', () => {
     });
   });
 
+  it('should parse replace definition with a branch to follow', () => {
+    const line =
+      'replace foo/foo => bar/bar v0.0.0-20210101000000-000000000000 // indirect; renovate: branch=main';
+    const res = parseLine(line);
+    expect(res).toStrictEqual({
+      currentDigest: '000000000000',
+      currentValue: 'v0.0.0-20210101000000-000000000000',
+      datasource: 'go',
+      depName: 'bar/bar',
+      depType: 'indirect',
+      digestOneAndOnly: true,
+      enabled: false,
+      followBranch: 'main',
+      versioning: 'loose',
+    });
+  });
+
   it('should parse replace definition with placeholder pseudo-version', () => {
     const line =
       'replace foo/foo => bar/bar v0.0.0-00010101000000-000000000000';
@@ -4,33 +4,28 @@ import { GoDatasource } from '../../datasource/go/index.ts';
 import { GolangVersionDatasource } from '../../datasource/golang-version/index.ts';
 import { isVersion } from '../../versioning/semver/index.ts';
 import type { PackageDependency } from '../types.ts';
-
-function trimQuotes(str: string): string {
-  return str.replace(regEx(/^"(.*)"$/), '$1');
-}
-
-const requireRegex = regEx(
-  /^(?<keyword>require)?\s+(?<module>[^\s]+\/?[^\s]+)\s+(?<version>[^\s]+)(?:\s*\/\/\s*(?<comment>[^\s]+)\s*)?$/,
-);
-
-const replaceRegex = regEx(
-  /^(?<keyword>replace)?\s+(?<module>[^\s]+\/?[^\s]+)\s*=>\s*(?<replacement>[^\s]+)(?:\s+(?<version>[^\s]+))?(?:\s*\/\/\s*(?<comment>[^\s]+)\s*)?$/,
-);
-
-export const excludeBlockStartRegex = regEx(/^(?<keyword>exclude)\s+\(\s*$/);
-
-export const endBlockRegex = regEx(/^\s+\)\s*$/);
-
-const toolRegex = regEx(/^(?<keyword>tool)?\s+(?<module>[^\s]+\/?[^\s]+)\s*$/);
-
-const goVersionRegex = regEx(/^\s*go\s+(?<version>[^\s]+)\s*$/);
-
-const toolchainVersionRegex = regEx(/^\s*toolchain\s+go(?<version>[^\s]+)\s*$/);
+import { getDirectives, isIndirect, parseGoMod } from './parser.ts';
+import type { GoModDirective } from './types.ts';
 
 const pseudoVersionRegex = regEx(GoDatasource.pversionRegexp);
 
 const placeholderPseudoVersion = 'v0.0.0-00010101000000-000000000000';
 
+const followBranchRegex = regEx(/\brenovate:\s*branch=(?<branch>[^\s;]+)/);
+
+const knownVerbs = new Set([
+  'exclude',
+  'go',
+  'godebug',
+  'ignore',
+  'module',
+  'replace',
+  'require',
+  'retract',
+  'tool',
+  'toolchain',
+]);
+
 function extractDigest(input: string): string | undefined {
   const match = pseudoVersionRegex.exec(input);
   return match?.groups?.digest;
@@ -40,155 +35,225 @@ function isPlaceholderPseudoVersion(version: string): boolean {
   return version === placeholderPseudoVersion;
 }
 
-export function parseLine(input: string): PackageDependency | null {
-  const goVersionMatches = goVersionRegex.exec(input)?.groups;
-  if (goVersionMatches) {
-    const { version: currentValue } = goVersionMatches;
-
-    const dep: PackageDependency = {
-      datasource: GolangVersionDatasource.id,
-      versioning: 'go-mod-directive',
-      depType: 'golang',
-      depName: 'go',
-      currentValue,
-      commitMessageTopic: 'go module directive',
-    };
-
-    if (!semver.validRange(currentValue)) {
-      dep.skipReason = 'invalid-version';
+/**
+ * Reads the branch to follow from a trailing `// renovate: branch=<name>` comment.
+ */
+function applyFollowBranch(
+  dep: PackageDependency,
+  { comments }: GoModDirective,
+): void {
+  const branch = comments.suffix
+    ? followBranchRegex.exec(comments.suffix)?.groups?.branch
+    : undefined;
+  if (branch && dep.currentDigest) {
+    dep.followBranch = branch;
+  }
+}
+
+function applyVersion(dep: PackageDependency, currentValue: string): void {
+  if (isVersion(currentValue)) {
+    const digest = extractDigest(currentValue);
+    if (digest) {
+      dep.currentDigest = digest;
+      dep.digestOneAndOnly = true;
+      dep.versioning = 'loose';
+      if (isPlaceholderPseudoVersion(currentValue)) {
+        dep.skipReason = 'invalid-version';
+      }
     }
+  } else {
+    dep.skipReason = 'invalid-version';
+  }
+}
 
-    return dep;
+function parseGoDirective({ args }: GoModDirective): PackageDependency | null {
+  const currentValue = args[0]?.value;
+  if (!currentValue) {
+    return null;
   }
 
-  const toolchainMatches = toolchainVersionRegex.exec(input)?.groups;
-  if (toolchainMatches) {
-    const { version: currentValue } = toolchainMatches;
+  const dep: PackageDependency = {
+    datasource: GolangVersionDatasource.id,
+    versioning: 'go-mod-directive',
+    depType: 'golang',
+    depName: 'go',
+    currentValue,
+    commitMessageTopic: 'go module directive',
+  };
+
+  if (!semver.validRange(currentValue)) {
+    dep.skipReason = 'invalid-version';
+  }
 
-    const dep: PackageDependency = {
-      datasource: GolangVersionDatasource.id,
-      depType: 'toolchain',
-      depName: 'go',
-      currentValue,
-      commitMessageTopic: 'go toolchain directive',
-    };
+  return dep;
+}
 
-    if (!semver.valid(currentValue)) {
-      dep.skipReason = 'invalid-version';
-    }
+function parseToolchainDirective({
+  args,
+}: GoModDirective): PackageDependency | null {
+  const toolchain = args[0]?.value;
+  if (!toolchain?.startsWith('go')) {
+    return null;
+  }
+  const currentValue = toolchain.replace(regEx(/^go/), '');
+
+  const dep: PackageDependency = {
+    datasource: GolangVersionDatasource.id,
+    depType: 'toolchain',
+    depName: 'go',
+    currentValue,
+    commitMessageTopic: 'go toolchain directive',
+  };
+
+  if (!semver.valid(currentValue)) {
+    dep.skipReason = 'invalid-version';
+  }
+
+  return dep;
+}
 
-    return dep;
+function parseRequireDirective(
+  directive: GoModDirective,
+): PackageDependency | null {
+  const [module, version] = directive.args;
+  if (!module || !version) {
+    return null;
   }
 
-  const requireMatches = requireRegex.exec(input)?.groups;
-  if (requireMatches) {
-    const { keyword, module, version: currentValue, comment } = requireMatches;
+  const currentValue = version.value;
+  const dep: PackageDependency = {
+    datasource: GoDatasource.id,
+    depType: 'require',
+    depName: module.value,
+    currentValue,
+  };
 
-    const depName = trimQuotes(module);
+  applyVersion(dep, currentValue);
+  applyFollowBranch(dep, directive);
 
-    const dep: PackageDependency = {
-      datasource: GoDatasource.id,
-      depType: 'require',
-      depName,
-      currentValue,
-    };
+  if (isIndirect(directive)) {
+    dep.depType = 'indirect';
+    dep.enabled = false;
+  }
 
-    if (isVersion(currentValue)) {
-      const digest = extractDigest(currentValue);
-      if (digest) {
-        dep.currentDigest = digest;
-        dep.digestOneAndOnly = true;
-        dep.versioning = 'loose';
-        if (isPlaceholderPseudoVersion(currentValue)) {
-          dep.skipReason = 'invalid-version';
-        }
-      }
-    } else {
-      dep.skipReason = 'invalid-version';
-    }
+  return dep;
+}
 
-    if (comment === 'indirect') {
-      dep.depType = 'indirect';
-      dep.enabled = false;
-    }
+function parseReplaceDirective(
+  directive: GoModDirective,
+): PackageDependency | null {
+  const { args } = directive;
+  const arrowIndex = args.findIndex(({ type }) => type === 'arrow');
+  const replacement = args[arrowIndex + 1];
+  if (arrowIndex < 1 || !replacement) {
+    return null;
+  }
 
-    if (!keyword) {
-      dep.managerData = { multiLine: true };
-    }
+  const depName = replacement.value;
+  const currentValue = args[arrowIndex + 2]?.value;
+
+  const dep: PackageDependency = {
+    datasource: GoDatasource.id,
+    depType: 'replace',
+    depName,
+  };
+
+  if (currentValue) {
+    dep.currentValue = currentValue;
+    applyVersion(dep, currentValue);
+    applyFollowBranch(dep, directive);
+  } else {
+    dep.skipReason = 'unspecified-version';
+  }
 
-    return dep;
-  }
-
-  const replaceMatches = replaceRegex.exec(input)?.groups;
-  if (replaceMatches) {
-    const {
-      keyword,
-      replacement,
-      version: currentValue,
-      comment,
-    } = replaceMatches;
-
-    const depName = trimQuotes(replacement);
-
-    const dep: PackageDependency = {
-      datasource: GoDatasource.id,
-      depType: 'replace',
-      depName,
-      currentValue,
-    };
-
-    if (isVersion(currentValue)) {
-      const digest = extractDigest(currentValue);
-      if (digest) {
-        dep.currentDigest = digest;
-        dep.digestOneAndOnly = true;
-        dep.versioning = 'loose';
-        if (isPlaceholderPseudoVersion(currentValue)) {
-          dep.skipReason = 'invalid-version';
-        }
-      }
-    } else if (currentValue) {
-      dep.skipReason = 'invalid-version';
-    } else {
-      dep.skipReason = 'unspecified-version';
-      delete dep.currentValue;
-    }
+  if (isIndirect(directive)) {
+    dep.depType = 'indirect';
+    dep.enabled = false;
+  }
 
-    if (comment === 'indirect') {
-      dep.depType = 'indirect';
-      dep.enabled = false;
-    }
+  if (depName.startsWith('/') || depName.startsWith('.')) {
+    dep.skipReason = 'local-dependency';
+  }
 
-    if (!keyword) {
-      dep.managerData = { multiLine: true };
-    }
+  return dep;
+}
 
-    if (depName.startsWith('/') || depName.startsWith('.')) {
-      dep.skipReason = 'local-dependency';
-    }
+function parseToolDirective({ args }: GoModDirective): PackageDependency | null {
+  const [module] = args;
+  if (!module) {
+    return null;
+  }
+
+  return {
+    datasource: GoDatasource.id,
+    depType: 'tool',
+    depName: module.value,
+    skipReason: 'unversioned-reference',
+  };
+}
+
+/**
+ * Converts a directive of the `go.mod` syntax tree into a dependency.
+ *
+ * Entries of blocks are marked with `managerData.multiLine`.
+ */
+export function parseDirective(
+  directive: GoModDirective,
+): PackageDependency | null {
+  let dep: PackageDependency | null;
+  switch (directive.verb) {
+    case 'go':
+      return parseGoDirective(directive);
+    case 'toolchain':
+      return parseToolchainDirective(directive);
+    case 'require':
+      dep = parseRequireDirective(directive);
+      break;
+    case 'replace':
+      dep = parseReplaceDirective(directive);
+      break;
+    case 'tool':
+      dep = parseToolDirective(directive);
+      break;
+    default:
+      return null;
+  }
 
-    return dep;
+  if (dep && directive.inBlock) {
+    dep.managerData = { multiLine: true };
   }
 
-  const toolMatches = toolRegex.exec(input)?.groups;
-  if (toolMatches) {
-    const { keyword, module } = toolMatches;
+  return dep;
+}
 
-    const depName = trimQuotes(module);
+/**
+ * Parses a single `go.mod` line.
+ *
+ * Indented lines without a known directive keyword are treated as block
+ * entries, e.g. lines taken out of their context in a diff.
+ * The directive is then inferred from the shape of the line.
+ */
+export function parseLine(input: string): PackageDependency | null {
+  const [directive] = getDirectives(parseGoMod(input));
+  if (!directive) {
+    return null;
+  }
 
-    const dep: PackageDependency = {
-      datasource: GoDatasource.id,
-      depType: 'tool',
-      depName,
-      skipReason: 'unversioned-reference',
-    };
+  if (knownVerbs.has(directive.verb)) {
+    return parseDirective(directive);
+  }
 
-    if (!keyword) {
-      dep.managerData = { multiLine: true };
-    }
+  if (!regEx(/^\s/).test(input)) {
+    return null;
+  }
 
-    return dep;
+  let verb = 'tool';
+  if (directive.args.some(({ type }) => type === 'arrow')) {
+    verb = 'replace';
+  } else if (directive.args.length) {
+    verb = 'require';
   }
 
-  return null;
+  const [blockEntry] = getDirectives(parseGoMod(`${verb} (\n${input}\n)`));
+  return blockEntry ? parseDirective(blockEntry) : null;
 }
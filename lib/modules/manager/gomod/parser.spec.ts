@@ -0,0 +1,207 @@
+import { codeBlock } from 'common-tags';
+import { getDirectives, isIndirect, parseGoMod } from './parser.ts';
+
+describe('modules/manager/gomod/parser', () => {
+  describe('parseGoMod()', () => {
+    it('returns no statements for empty content', () => {
+      expect(parseGoMod('')).toEqual({ statements: [] });
+    });
+
+    it('parses single-line directives with positions', () => {
+      const content = codeBlock`
+        module github.com/renovate-tests/gomod
+        require github.com/pkg/errors v0.7.0 // indirect
+      `;
+
+      const { statements } = parseGoMod(content);
+
+      expect(statements).toEqual([
+        {
+          type: 'directive',
+          verb: 'module',
+          args: [
+            {
+              type: 'word',
+              value: 'github.com/renovate-tests/gomod',
+              text: 'github.com/renovate-tests/gomod',
+              offset: 7,
+              lineNumber: 0,
+            },
+          ],
+          lineNumber: 0,
+          inBlock: false,
+          comments: { before: [] },
+        },
+        {
+          type: 'directive',
+          verb: 'require',
+          args: [
+            {
+              type: 'word',
+              value: 'github.com/pkg/errors',
+              text: 'github.com/pkg/errors',
+              offset: 47,
+              lineNumber: 1,
+            },
+            {
+              type: 'word',
+              value: 'v0.7.0',
+              text: 'v0.7.0',
+              offset: 69,
+              lineNumber: 1,
+            },
+          ],
+          lineNumber: 1,
+          inBlock: false,
+          comments: { before: [], suffix: 'indirect' },
+        },
+      ]);
+      expect(content.slice(69, 75)).toBe('v0.7.0');
+    });
+
+    it('parses blocks and keeps comments', () => {
+      const content = codeBlock`
+        // Deprecated: use example.com/other instead.
+        module example.com/mod
+
+        require (
+        	// needed for tests
+        	"example.com/foo" v1.0.0
+        	example.com/bar v1.2.3 // indirect; see #123
+        ) // end of requires
+      `;
+
+      const { statements } = parseGoMod(content);
+
+      expect(statements).toMatchObject([
+        {
+          type: 'directive',
+          verb: 'module',
+          comments: {
+            before: ['Deprecated: use example.com/other instead.'],
+          },
+        },
+        {
+          type: 'block',
+          verb: 'require',
+          lineNumber: 3,
+          directives: [
+            {
+              verb: 'require',
+              lineNumber: 5,
+              inBlock: true,
+              args: [
+                { type: 'string', value: 'example.com/foo' },
+                { type: 'word', value: 'v1.0.0' },
+              ],
+              comments: { before: ['needed for tests'] },
+            },
+            {
+              verb: 'require',
+              lineNumber: 6,
+              inBlock: true,
+              args: [{ value: 'example.com/bar' }, { value: 'v1.2.3' }],
+              comments: { before: [], suffix: 'indirect; see #123' },
+            },
+          ],
+        },
+      ]);
+    });
+
+    it('tokenizes replace and retract directives', () => {
+      const content = codeBlock`
+        replace example.com/foo v1.0.0 => ../foo
+        retract [v1.1.0, v1.2.0] // broken
+      `;
+
+      const [replace, retract] = getDirectives(parseGoMod(content));
+
+      expect(replace.args.map(({ type, value }) => [type, value])).toEqual([
+        ['word', 'example.com/foo'],
+        ['word', 'v1.0.0'],
+        ['arrow', '=>'],
+        ['word', '../foo'],
+      ]);
+      expect(retract.args.map(({ type }) => type)).toEqual([
+        'lbracket',
+        'word',
+        'comma',
+        'word',
+        'rbracket',
+      ]);
+      expect(retract.comments.suffix).toBe('broken');
+    });
+
+    it('parses blocks opened and closed on the same line', () => {
+      const content = 'replace (golang.org/x/foo => example.com/foo v1.0.0)';
+
+      const [directive] = getDirectives(parseGoMod(content));
+
+      expect(directive).toMatchObject({
+        verb: 'replace',
+        inBlock: true,
+        args: [
+          { value: 'golang.org/x/foo' },
+          { value: '=>' },
+          { value: 'example.com/foo' },
+          { value: 'v1.0.0' },
+        ],
+      });
+    });
+
+    it('handles CRLF line endings', () => {
+      const content =
+        'go 1.23\r\nrequire (\r\n\texample.com/foo v1.0.0\r\n)\r\n';
+
+      const directives = getDirectives(parseGoMod(content));
+
+      expect(directives).toMatchObject([
+        { verb: 'go', lineNumber: 0, args: [{ value: '1.23' }] },
+        {
+          verb: 'require',
+          lineNumber: 2,
+          args: [{ value: 'example.com/foo' }, { value: 'v1.0.0' }],
+        },
+      ]);
+    });
+
+    it('does not throw on malformed content', () => {
+      const content = codeBlock`
+        require "example.com/unterminated v1.0.0
+        require (
+        	example.com/foo v1.0.0
+      `;
+
+      const directives = getDirectives(parseGoMod(content));
+
+      expect(directives).toMatchObject([
+        {
+          verb: 'require',
+          args: [{ text: '"example.com/unterminated v1.0.0' }],
+        },
+        { verb: 'require', inBlock: true, lineNumber: 2 },
+      ]);
+    });
+  });
+
+  describe('isIndirect()', () => {
+    it.each`
+      comment                 | expected
+      ${undefined}            | ${false}
+      ${'indirect'}           | ${true}
+      ${'indirect; see #123'} | ${true}
+      ${'not indirect'}       | ${false}
+      ${'indirectly'}         | ${false}
+    `(
+      'returns $expected for "$comment"',
+      ({ comment, expected }: { comment?: string; expected: boolean }) => {
+        const [directive] = getDirectives(
+          parseGoMod(
+            `require example.com/foo v1.0.0${comment ? ` // ${comment}` : ''}`,
+          ),
+        );
+        expect(isIndirect(directive)).toBe(expected);
+      },
+    );
+  });
+});
@@ -0,0 +1,212 @@
+import moo from 'moo';
+import type {
+  GoModBlock,
+  GoModComments,
+  GoModDirective,
+  GoModFile,
+  GoModStatement,
+  GoModToken,
+  GoModTokenType,
+} from './types.ts';
+
+// https://go.dev/ref/mod#go-mod-file-lexical
+/* oxlint-disable renovate/require-regex-util -- moo lexer patterns must be native RegExp: moo recompiles their source with the native engine and rejects RE2 instances (TODO #12870) */
+const lexer = moo.compile({
+  newline: { match: /\r?\n/, lineBreaks: true },
+  whitespace: /[ \t\r\f\v]+/,
+  comment: /\/\/.*/,
+  arrow: '=>',
+  lparen: '(',
+  rparen: ')',
+  lbracket: '[',
+  rbracket: ']',
+  comma: ',',
+  string: [/"(?:\\.|[^"\\\n])*"/, /`[^`\n]*`/],
+  word: /(?:[^\s"`()[\],/=]|\/(?!\/)|=(?!>))+/,
+  unterminated: /["`][^\n]*/,
+});
+/* oxlint-enable renovate/require-regex-util */
+
+interface TokenizedLine {
+  lineNumber: number;
+  tokens: moo.Token[];
+  comment?: string;
+}
+
+function tokenizeLines(content: string): TokenizedLine[] {
+  lexer.reset(content);
+  const lines: TokenizedLine[] = [];
+  let line: TokenizedLine = { lineNumber: 0, tokens: [] };
+  for (const token of lexer) {
+    if (token.type === 'newline') {
+      lines.push(line);
+      // moo line numbers are one-based, so this is the next zero-based line
+      line = { lineNumber: token.line, tokens: [] };
+    } else if (token.type === 'comment') {
+      line.comment = token.value.slice(2).trim();
+    } else if (token.type !== 'whitespace') {
+      line.tokens.push(token);
+    }
+  }
+  lines.push(line);
+  lexer.reset();
+  return lines;
+}
+
+function unquote(text: string): string {
+  if (text.startsWith('`')) {
+    return text.slice(1, -1);
+  }
+  try {
+    return JSON.parse(text) as string;
+  } catch {
+    return text.slice(1, -1);
+  }
+}
+
+function toGoModToken(token: moo.Token): GoModToken {
+  const { text, offset } = token;
+  const lineNumber = token.line - 1;
+  switch (token.type) {
+    case 'string':
+      return {
+        type: 'string',
+        value: unquote(text),
+        text,
+        offset,
+        lineNumber,
+      };
+    case 'arrow':
+    case 'lbracket':
+    case 'rbracket':
+    case 'comma':
+      return {
+        type: token.type as GoModTokenType,
+        value: text,
+        text,
+        offset,
+        lineNumber,
+      };
+    default:
+      return { type: 'word', value: text, text, offset, lineNumber };
+  }
+}
+
+function toDirective(
+  verb: string,
+  tokens: moo.Token[],
+  line: TokenizedLine,
+  before: string[],
+  inBlock: boolean,
+): GoModDirective {
+  const comments: GoModComments = { before };
+  if (line.comment !== undefined) {
+    comments.suffix = line.comment;
+  }
+  return {
+    type: 'directive',
+    verb,
+    args: tokens.map(toGoModToken),
+    lineNumber: line.lineNumber,
+    inBlock,
+    comments,
+  };
+}
+
+/**
+ * Parses the content of a `go.mod` (or `go.work`) file into a syntax tree.
+ *
+ * The parser is lenient: it never throws, and unknown directives are kept
+ * in the tree so that callers can decide how to handle them.
+ *
+ * @see https://go.dev/ref/mod#go-mod-file-grammar
+ */
+export function parseGoMod(content: string): GoModFile {
+  const statements: GoModStatement[] = [];
+  let block: GoModBlock | null = null;
+  let pendingComments: string[] = [];
+
+  for (const line of tokenizeLines(content)) {
+    const [first, ...rest] = line.tokens;
+
+    if (!first) {
+      if (line.comment === undefined) {
+        pendingComments = [];
+      } else {
+        pendingComments.push(line.comment);
+      }
+      continue;
+    }
+
+    if (block) {
+      if (first.type === 'rparen') {
+        block = null;
+      } else {
+        block.directives.push(
+          toDirective(block.verb, line.tokens, line, pendingComments, true),
+        );
+      }
+      pendingComments = [];
+      continue;
+    }
+
+    const comments: GoModComments = { before: pendingComments };
+    pendingComments = [];
+
+    if (rest[0]?.type !== 'lparen') {
+      statements.push(
+        toDirective(first.value, rest, line, comments.before, false),
+      );
+      continue;
+    }
+
+    if (line.comment !== undefined) {
+      comments.suffix = line.comment;
+    }
+    const newBlock: GoModBlock = {
+      type: 'block',
+      verb: first.value,
+      lineNumber: line.lineNumber,
+      directives: [],
+      comments,
+    };
+    statements.push(newBlock);
+
+    // Blocks opened and closed on the same line, e.g. `replace ( a => b v1.0.0 )`
+    const inner = rest.slice(1);
+    const isClosed = inner.at(-1)?.type === 'rparen';
+    if (isClosed) {
+      inner.pop();
+    }
+    if (inner.length) {
+      const innerLine = { ...line, comment: undefined };
+      newBlock.directives.push(
+        toDirective(first.value, inner, innerLine, [], true),
+      );
+    }
+    if (!isClosed) {
+      block = newBlock;
+    }
+  }
+
+  return { statements };
+}
+
+/**
+ * Returns all directives of the file in order, with block entries flattened.
+ */
+export function getDirectives(file: GoModFile): GoModDirective[] {
+  return file.statements.flatMap((statement) =>
+    statement.type === 'block' ? statement.directives : [statement],
+  );
+}
+
+/**
+ * Whether the directive is marked with an `// indirect` comment.
+ *
+ * @see https://go.dev/ref/mod#go-mod-file-require
+ */
+export function isIndirect(directive: GoModDirective): boolean {
+  const comment = directive.comments.suffix;
+  return comment === 'indirect' || !!comment?.startsWith('indirect;');
+}
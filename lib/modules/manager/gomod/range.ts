@@ -0,0 +1,12 @@
+import type { RangeStrategy } from '../../../types/index.ts';
+import type { RangeConfig } from '../types.ts';
+
+// `updateLockedDependency` is only for remediating modules which aren't required directly
+export function getRangeStrategy({
+  rangeStrategy,
+}: RangeConfig): RangeStrategy {
+  if (rangeStrategy !== 'auto') {
+    return rangeStrategy!;
+  }
+  return 'replace';
+}
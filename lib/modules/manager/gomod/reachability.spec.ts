@@ -0,0 +1,154 @@
+import type { Osv } from '@renovatebot/osv-offline';
+import { codeBlock } from 'common-tags';
+import { fs, scm } from '~test/util.ts';
+import * as memCache from '../../../util/cache/memory/index.ts';
+import { getGoFileUsage, isGoVulnerabilityReachable } from './reachability.ts';
+
+vi.mock('../../../util/fs/index.ts');
+
+const mainGo = codeBlock`
+  package main
+
+  import (
+  	"fmt"
+  	"net/http"
+
+  	yaml "gopkg.in/yaml.v2"
+  	"github.com/google/go-github/v28/github"
+  	_ "github.com/lib/pq"
+  )
+
+  func main() {
+  	client := github.NewClient(http.DefaultClient)
+  	repo, _, _ := client.Repositories.Get(ctx, "renovatebot", "renovate")
+  	out, _ := yaml.Marshal(repo)
+  	fmt.Println(string(out))
+  }
+`;
+
+function affected(
+  imports: { path: string; symbols?: string[] }[] | undefined,
+): Osv.Affected {
+  return {
+    package: { ecosystem: 'Go', name: 'example.com/mod' },
+    ...(imports ? { ecosystem_specific: { imports } } : {}),
+  };
+}
+
+describe('modules/manager/gomod/reachability', () => {
+  describe('getGoFileUsage()', () => {
+    it('collects imports and selectors', () => {
+      const usage = getGoFileUsage(mainGo);
+
+      expect(Object.fromEntries(usage.imports)).toEqual({
+        fmt: ['fmt'],
+        'net/http': ['http'],
+        'gopkg.in/yaml.v2': ['yaml'],
+        'github.com/google/go-github/v28/github': ['github'],
+        'github.com/lib/pq': ['_'],
+      });
+      expect(usage.qualified).toContain('github.NewClient');
+      expect(usage.qualified).toContain('yaml.Marshal');
+      expect(usage.selectors).toContain('Get');
+    });
+
+    it('guesses the names of unnamed imports', () => {
+      const usage = getGoFileUsage(codeBlock`
+        import (
+        	"github.com/go-chi/chi/v5"
+        	"gopkg.in/yaml.v3"
+        	"github.com/rethinkdb/rethinkdb-go"
+        )
+      `);
+
+      expect(Object.fromEntries(usage.imports)).toEqual({
+        'github.com/go-chi/chi/v5': ['chi'],
+        'gopkg.in/yaml.v3': ['yaml'],
+        'github.com/rethinkdb/rethinkdb-go': [
+          'rethinkdb-go',
+          'rethinkdb',
+          'rethinkdbgo',
+        ],
+      });
+    });
+  });
+
+  describe('isGoVulnerabilityReachable()', () => {
+    beforeEach(() => {
+      scm.getFileList.mockResolvedValue([
+        'go.mod',
+        'main.go',
+        'main_test.go',
+        'vendor/gopkg.in/yaml.v2/yaml.go',
+      ]);
+      fs.readLocalFile.mockResolvedValue(mainGo);
+    });
+
+    it('returns null without affected imports', async () => {
+      await expect(
+        isGoVulnerabilityReachable('go.mod', affected(undefined)),
+      ).resolves.toBeNull();
+      await expect(
+        isGoVulnerabilityReachable('go.mod', affected([])),
+      ).resolves.toBeNull();
+      expect(scm.getFileList).not.toHaveBeenCalled();
+    });
+
+    it.each`
+      path                                        | symbols                          | expected
+      ${'gopkg.in/yaml.v2'}                       | ${['Marshal']}                   | ${true}
+      ${'gopkg.in/yaml.v2'}                       | ${['Unmarshal', 'NewDecoder']}   | ${false}
+      ${'gopkg.in/yaml.v2'}                       | ${undefined}                     | ${true}
+      ${'gopkg.in/yaml.v2/internal'}              | ${undefined}                     | ${false}
+      ${'github.com/google/go-github/v28/github'} | ${['RepositoriesService.Get']}   | ${true}
+      ${'github.com/google/go-github/v28/github'} | ${['RepositoriesService.Merge']} | ${false}
+      ${'github.com/lib/pq'}                      | ${['Open']}                      | ${false}
+      ${'github.com/lib/pq'}                      | ${[]}                            | ${true}
+    `(
+      '$path $symbols is reachable: $expected',
+      async ({
+        path,
+        symbols,
+        expected,
+      }: {
+        path: string;
+        symbols: string[] | undefined;
+        expected: boolean;
+      }) => {
+        await expect(
+          isGoVulnerabilityReachable('go.mod', affected([{ path, symbols }])),
+        ).resolves.toBe(expected);
+        expect(fs.readLocalFile).toHaveBeenCalledExactlyOnceWith(
+          'main.go',
+          'utf8',
+        );
+      },
+    );
+
+    it('treats dot imports as reachable', async () => {
+      fs.readLocalFile.mockResolvedValue(
+        'package main\n\nimport . "gopkg.in/yaml.v2"\n',
+      );
+
+      await expect(
+        isGoVulnerabilityReachable(
+          'go.mod',
+          affected([{ path: 'gopkg.in/yaml.v2', symbols: ['Unmarshal'] }]),
+        ),
+      ).resolves.toBeTrue();
+    });
+
+    it('scans each module once', async () => {
+      memCache.init();
+      const yamlAffected = affected([
+        { path: 'gopkg.in/yaml.v2', symbols: ['Marshal'] },
+      ]);
+
+      await isGoVulnerabilityReachable('go.mod', yamlAffected);
+      await isGoVulnerabilityReachable('go.mod', yamlAffected);
+
+      expect(scm.getFileList).toHaveBeenCalledOnce();
+      memCache.reset();
+    });
+  });
+});
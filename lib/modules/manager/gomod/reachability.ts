@@ -0,0 +1,153 @@
+import type { Osv } from '@renovatebot/osv-offline';
+import { z } from 'zod/v4';
+import { logger } from '../../../logger/index.ts';
+import * as memCache from '../../../util/cache/memory/index.ts';
+import { readLocalFile } from '../../../util/fs/index.ts';
+import { regEx } from '../../../util/regex.ts';
+import { LooseArray } from '../../../util/schema-utils/index.ts';
+import { getImportSpecs, getModuleGoFiles } from './import-paths.ts';
+
+const selectorRegex = regEx(
+  /(?<qualifier>[A-Za-z_]\w*)?\.(?<sel>[A-Za-z_]\w*)/g,
+);
+
+// https://go.dev/doc/security/vuln/database#schema
+const GoEcosystemSpecific = z.object({
+  imports: LooseArray(
+    z.object({
+      path: z.string(),
+      symbols: z.array(z.string()).optional(),
+    }),
+  ),
+});
+
+interface GoFileUsage {
+  /** import path to the names it is referenced by */
+  imports: Map<string, string[]>;
+  /** qualified identifiers, e.g. `http.Get` */
+  qualified: Set<string>;
+  /** selected field and method names, e.g. `Do` */
+  selectors: Set<string>;
+}
+
+/**
+ * Returns the names a Go package may be referenced by when imported without a name.
+ *
+ * The package name isn't known without reading the package itself,
+ * so this includes the common variants of the last path element.
+ */
+function getDefaultPackageNames(importPath: string): string[] {
+  const elements = importPath.split('/');
+  let name = elements.pop()!;
+  if (regEx(/^v\d+$/).test(name) && elements.length > 0) {
+    name = elements.pop()!;
+  }
+  name = name.replace(regEx(/\.v\d+$/), '');
+  return [
+    ...new Set([
+      name,
+      name.replace(regEx(/^go-|-go$/g), ''),
+      name.replace(regEx(/[-.]/g), ''),
+      name.replace(regEx(/^go-|-go$/g), '').replace(regEx(/[-.]/g), ''),
+    ]),
+  ];
+}
+
+export function getGoFileUsage(content: string): GoFileUsage {
+  const imports = new Map<string, string[]>();
+  for (const { name, path } of getImportSpecs(content)) {
+    const names = name ? [name] : getDefaultPackageNames(path);
+    imports.set(path, [...(imports.get(path) ?? []), ...names]);
+  }
+
+  const qualified = new Set<string>();
+  const selectors = new Set<string>();
+  for (const match of content.matchAll(selectorRegex)) {
+    const { qualifier, sel } = match.groups!;
+    if (qualifier) {
+      qualified.add(`${qualifier}.${sel}`);
+    }
+    selectors.add(sel);
+  }
+
+  return { imports, qualified, selectors };
+}
+
+async function getModuleUsage(goModFileName: string): Promise<GoFileUsage[]> {
+  const cacheKey = `gomod-reachability:${goModFileName}`;
+  const cached = memCache.get<Promise<GoFileUsage[]> | undefined>(cacheKey);
+  if (cached) {
+    return cached;
+  }
+
+  const promise = (async () => {
+    const result: GoFileUsage[] = [];
+    for (const file of await getModuleGoFiles(goModFileName)) {
+      // test code isn't part of the built binaries
+      if (file.endsWith('_test.go')) {
+        continue;
+      }
+      const content = await readLocalFile(file, 'utf8');
+      if (content) {
+        result.push(getGoFileUsage(content));
+      }
+    }
+    logger.debug(
+      { goModFileName, files: result.length },
+      'gomod: scanned Go files for vulnerability reachability',
+    );
+    return result;
+  })();
+  memCache.set(cacheKey, promise);
+  return promise;
+}
+
+function isSymbolUsed(
+  usage: GoFileUsage,
+  names: string[],
+  symbol: string,
+): boolean {
+  const [typeName, method] = symbol.split('.');
+  if (method) {
+    // Methods can be called on values of the type without naming it
+    return usage.selectors.has(method);
+  }
+  return names.some((name) => usage.qualified.has(`${name}.${typeName}`));
+}
+
+/**
+ * Checks whether the module of `goModFileName` uses any of the packages or symbols affected by a Go advisory.
+ *
+ * This is a static check of the import declarations and selector expressions of the module's `.go` files.
+ * It errs on the side of reporting advisories as reachable, but it can't see uses through other modules,
+ * so it is only meaningful for modules which are required directly.
+ *
+ * @returns `true` if the advisory may be reachable, `false` if not, and `null` if the advisory doesn't list its affected packages
+ */
+export async function isGoVulnerabilityReachable(
+  goModFileName: string,
+  affected: Osv.Affected,
+): Promise<boolean | null> {
+  const parsed = GoEcosystemSpecific.safeParse(affected.ecosystem_specific);
+  if (!parsed.success || parsed.data.imports.length === 0) {
+    return null;
+  }
+
+  const moduleUsage = await getModuleUsage(goModFileName);
+  for (const { path, symbols } of parsed.data.imports) {
+    for (const usage of moduleUsage) {
+      const names = usage.imports.get(path);
+      if (!names) {
+        continue;
+      }
+      if (!symbols?.length || names.includes('.')) {
+        return true;
+      }
+      if (symbols.some((symbol) => isSymbolUsed(usage, names, symbol))) {
+        return true;
+      }
+    }
+  }
+
+  return false;
+}
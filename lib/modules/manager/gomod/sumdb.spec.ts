@@ -0,0 +1,370 @@
+import crypto from 'node:crypto';
+import * as httpMock from '~test/http-mock.ts';
+import {
+  getNewGoSumLines,
+  getSumDb,
+  getTilePath,
+  lookupGoSumLines,
+  parseVerifierKey,
+  verifyGoSum,
+} from './sumdb.ts';
+
+const sumDbUrl = 'https://sumdb.example.com';
+const sumDbName = 'sumdb.example.com';
+
+function sha256(...data: (Buffer | string)[]): Buffer {
+  const hash = crypto.createHash('sha256');
+  for (const d of data) {
+    hash.update(d);
+  }
+  return hash.digest();
+}
+
+function leafHash(data: string): Buffer {
+  return sha256(Buffer.from([0]), data);
+}
+
+function treeHash(leaves: Buffer[]): Buffer {
+  if (leaves.length === 1) {
+    return leaves[0];
+  }
+  let split = 1;
+  while (split * 2 < leaves.length) {
+    split *= 2;
+  }
+  return sha256(
+    Buffer.from([1]),
+    treeHash(leaves.slice(0, split)),
+    treeHash(leaves.slice(split)),
+  );
+}
+
+/**
+ * A local stand-in for a checksum database.
+ */
+class TestSumDb {
+  readonly vkey: string;
+
+  private readonly keyHash: Buffer;
+
+  private readonly privateKey: crypto.KeyObject;
+
+  readonly records: string[] = [];
+
+  constructor() {
+    const { publicKey, privateKey } = crypto.generateKeyPairSync('ed25519');
+    const key = Buffer.concat([
+      Buffer.from([1]),
+      Buffer.from(publicKey.export({ format: 'jwk' }).x!, 'base64url'),
+    ]);
+    this.keyHash = sha256(`${sumDbName}\n`, key).subarray(0, 4);
+    this.vkey = `${sumDbName}+${this.keyHash.toString('hex')}+${key.toString('base64')}`;
+    this.privateKey = privateKey;
+  }
+
+  add(count: number, data?: string): number {
+    for (let i = 0; i < count; i += 1) {
+      this.records.push(
+        `example.com/filler${this.records.length} v1.0.0 h1:x\n`,
+      );
+    }
+    if (data) {
+      this.records.push(data);
+    }
+    return this.records.length - 1;
+  }
+
+  leaves(): Buffer[] {
+    return this.records.map(leafHash);
+  }
+
+  signedTree(): string {
+    const root = treeHash(this.leaves()).toString('base64');
+    const text = `go.sum database tree\n${this.records.length}\n${root}\n`;
+    const sig = crypto.sign(null, Buffer.from(text), this.privateKey);
+    const signature = Buffer.concat([this.keyHash, sig]).toString('base64');
+    return `${text}\n— ${sumDbName} ${signature}\n`;
+  }
+
+  lookup(id: number, note = this.signedTree()): string {
+    return `${id}\n${this.records[id]}\n${note}`;
+  }
+
+  tile(level: number, index: number, width: number): Buffer {
+    const hashes: Buffer[] = [];
+    const leaves = this.leaves();
+    const size = 2 ** (level * 8);
+    for (let i = index * 256; i < index * 256 + width; i += 1) {
+      hashes.push(treeHash(leaves.slice(i * size, (i + 1) * size)));
+    }
+    return Buffer.concat(hashes);
+  }
+}
+
+describe('modules/manager/gomod/sumdb', () => {
+  describe('getTilePath()', () => {
+    it.each`
+      level | index      | width  | expected
+      ${0}  | ${0}       | ${256} | ${'tile/8/0/000'}
+      ${0}  | ${5}       | ${12}  | ${'tile/8/0/005.p/12'}
+      ${1}  | ${1234067} | ${256} | ${'tile/8/1/x001/x234/067'}
+    `(
+      '$level/$index/$width is $expected',
+      ({
+        level,
+        index,
+        width,
+        expected,
+      }: {
+        level: number;
+        index: number;
+        width: number;
+        expected: string;
+      }) => {
+        expect(getTilePath(level, index, width)).toBe(expected);
+      },
+    );
+  });
+
+  describe('parseVerifierKey()', () => {
+    it('parses the sum.golang.org key', () => {
+      expect(
+        parseVerifierKey(
+          'sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8',
+        ),
+      ).toMatchObject({ name: 'sum.golang.org' });
+    });
+
+    it.each`
+      vkey
+      ${'sum.golang.org'}
+      ${'sum.golang.org+033de0ae+AAAA'}
+      ${'sum.golang.org+00000000+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8'}
+    `('throws for $vkey', ({ vkey }: { vkey: string }) => {
+      expect(() => parseVerifierKey(vkey)).toThrow();
+    });
+  });
+
+  describe('getSumDb()', () => {
+    it('defaults to sum.golang.org', () => {
+      expect(getSumDb()).toMatchObject({
+        verifier: { name: 'sum.golang.org' },
+        url: 'https://sum.golang.org',
+      });
+    });
+
+    it('supports the sum.golang.org mirror', () => {
+      expect(getSumDb('sum.golang.google.cn')).toMatchObject({
+        verifier: { name: 'sum.golang.org' },
+        url: 'https://sum.golang.google.cn',
+      });
+    });
+
+    it('supports custom keys and URLs', () => {
+      const { vkey } = new TestSumDb();
+
+      expect(getSumDb(vkey)).toMatchObject({
+        verifier: { name: sumDbName },
+        url: 'https://sumdb.example.com',
+      });
+      expect(getSumDb(`${vkey} localhost:8080`)).toMatchObject({
+        url: 'https://localhost:8080',
+      });
+      expect(getSumDb(`${vkey} http://localhost:8080`)).toMatchObject({
+        url: 'http://localhost:8080',
+      });
+    });
+
+    it('returns null when turned off', () => {
+      expect(getSumDb('off')).toBeNull();
+    });
+  });
+
+  describe('getNewGoSumLines()', () => {
+    it('groups new lines by module version', () => {
+      const existing = [
+        'github.com/pkg/errors v0.8.0 h1:a',
+        'github.com/pkg/errors v0.8.0/go.mod h1:b',
+      ].join('\n');
+      const updated = [
+        'github.com/pkg/errors v0.8.0 h1:a',
+        'github.com/pkg/errors v0.8.0/go.mod h1:b',
+        'github.com/pkg/errors v0.9.1 h1:c',
+        'github.com/pkg/errors v0.9.1/go.mod h1:d',
+        'golang.org/x/text v0.3.0/go.mod h1:e',
+        '',
+      ].join('\r\n');
+
+      expect(Object.fromEntries(getNewGoSumLines(existing, updated))).toEqual({
+        'github.com/pkg/errors@v0.9.1': [
+          'github.com/pkg/errors v0.9.1 h1:c',
+          'github.com/pkg/errors v0.9.1/go.mod h1:d',
+        ],
+        'golang.org/x/text@v0.3.0': ['golang.org/x/text v0.3.0/go.mod h1:e'],
+      });
+    });
+  });
+
+  describe('lookupGoSumLines()', () => {
+    const data =
+      'github.com/!burnt!sushi/toml v1.0.0 h1:abc\n' +
+      'github.com/!burnt!sushi/toml v1.0.0/go.mod h1:def\n';
+
+    it('proves the inclusion of a record', async () => {
+      const db = new TestSumDb();
+      const id = db.add(3, data);
+      db.add(1);
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/github.com/!burnt!sushi/toml@v1.0.0')
+        .reply(200, db.lookup(id))
+        .get('/tile/8/0/000.p/5')
+        .reply(200, db.tile(0, 0, 5));
+
+      await expect(
+        lookupGoSumLines(
+          getSumDb(db.vkey)!,
+          'github.com/BurntSushi/toml',
+          'v1.0.0',
+        ),
+      ).resolves.toEqual([
+        'github.com/!burnt!sushi/toml v1.0.0 h1:abc',
+        'github.com/!burnt!sushi/toml v1.0.0/go.mod h1:def',
+      ]);
+    });
+
+    it('reads hashes from higher tile levels', async () => {
+      const db = new TestSumDb();
+      const id = db.add(260, data);
+      db.add(39);
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/github.com/!burnt!sushi/toml@v1.0.0')
+        .reply(200, db.lookup(id))
+        .get('/tile/8/1/000.p/1')
+        .reply(200, db.tile(1, 0, 1))
+        .get('/tile/8/0/001.p/44')
+        .reply(200, db.tile(0, 1, 44));
+
+      await expect(
+        lookupGoSumLines(
+          getSumDb(db.vkey)!,
+          'github.com/BurntSushi/toml',
+          'v1.0.0',
+        ),
+      ).resolves.toHaveLength(2);
+    });
+
+    it('throws if the record is not included in the tree', async () => {
+      const db = new TestSumDb();
+      const id = db.add(3, data);
+      const note = db.signedTree();
+      db.records[id] = data.replace('h1:abc', 'h1:tampered');
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/github.com/!burnt!sushi/toml@v1.0.0')
+        .reply(200, db.lookup(id, note))
+        .get('/tile/8/0/000.p/4')
+        .reply(200, db.tile(0, 0, 4));
+
+      await expect(
+        lookupGoSumLines(
+          getSumDb(db.vkey)!,
+          'github.com/BurntSushi/toml',
+          'v1.0.0',
+        ),
+      ).rejects.toThrow('is not included in the signed tree');
+    });
+
+    it('throws if the tree is not signed by the checksum database', async () => {
+      const db = new TestSumDb();
+      const other = new TestSumDb();
+      const id = db.add(0, data);
+      other.add(0, data);
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/github.com/!burnt!sushi/toml@v1.0.0')
+        .reply(200, db.lookup(id, other.signedTree()));
+
+      await expect(
+        lookupGoSumLines(
+          getSumDb(db.vkey)!,
+          'github.com/BurntSushi/toml',
+          'v1.0.0',
+        ),
+      ).rejects.toThrow(`Signed note is not signed by ${sumDbName}`);
+    });
+
+    it('throws for malformed lookups', async () => {
+      const db = new TestSumDb();
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/github.com/!burnt!sushi/toml@v1.0.0')
+        .reply(200, 'not found');
+
+      await expect(
+        lookupGoSumLines(
+          getSumDb(db.vkey)!,
+          'github.com/BurntSushi/toml',
+          'v1.0.0',
+        ),
+      ).rejects.toThrow('Malformed checksum database lookup');
+    });
+  });
+
+  describe('verifyGoSum()', () => {
+    const existing = 'golang.org/x/text v0.3.0/go.mod h1:e\n';
+
+    it('verifies new lines', async () => {
+      const db = new TestSumDb();
+      const id = db.add(
+        1,
+        'golang.org/x/text v0.3.7 h1:a\ngolang.org/x/text v0.3.7/go.mod h1:b\n',
+      );
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/golang.org/x/text@v0.3.7')
+        .reply(200, db.lookup(id))
+        .get('/tile/8/0/000.p/2')
+        .reply(200, db.tile(0, 0, 2));
+
+      await expect(
+        verifyGoSum(
+          existing,
+          `${existing}golang.org/x/text v0.3.7 h1:a\ngolang.org/x/text v0.3.7/go.mod h1:b\ngit.example.com/private v1.0.0 h1:c\n`,
+          { GOSUMDB: db.vkey, GOPRIVATE: 'git.example.com' },
+        ),
+      ).resolves.toBeUndefined();
+    });
+
+    it('throws for lines which do not match', async () => {
+      const db = new TestSumDb();
+      const id = db.add(
+        1,
+        'golang.org/x/text v0.3.7 h1:a\ngolang.org/x/text v0.3.7/go.mod h1:b\n',
+      );
+      httpMock
+        .scope(sumDbUrl)
+        .get('/lookup/golang.org/x/text@v0.3.7')
+        .reply(200, db.lookup(id))
+        .get('/tile/8/0/000.p/2')
+        .reply(200, db.tile(0, 0, 2));
+
+      await expect(
+        verifyGoSum(existing, `${existing}golang.org/x/text v0.3.7 h1:evil\n`, {
+          GOSUMDB: db.vkey,
+        }),
+      ).rejects.toThrow(
+        `go.sum lines do not match the checksum database ${sumDbName}:\ngolang.org/x/text v0.3.7 h1:evil`,
+      );
+    });
+
+    it('skips verification when GOSUMDB is off', async () => {
+      await expect(
+        verifyGoSum(existing, `${existing}golang.org/x/text v0.3.7 h1:a\n`, {
+          GOSUMDB: 'off',
+        }),
+      ).resolves.toBeUndefined();
+    });
+  });
+});
@@ -0,0 +1,399 @@
+import crypto from 'node:crypto';
+import { logger } from '../../../logger/index.ts';
+import { Http } from '../../../util/http/index.ts';
+import { newlineRegex, regEx } from '../../../util/regex.ts';
+import { isHttpUrl, joinUrlParts } from '../../../util/url.ts';
+import { parseNoproxy } from '../../datasource/go/goproxy-parser.ts';
+
+const http = new Http('go');
+
+// https://go.dev/ref/mod#checksum-database
+const knownSumDbs: Record<string, { key: string; url: string }> = {
+  'sum.golang.org': {
+    key: 'sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8',
+    url: 'https://sum.golang.org',
+  },
+  'sum.golang.google.cn': {
+    key: 'sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8',
+    url: 'https://sum.golang.google.cn',
+  },
+};
+
+const tileHeight = 8;
+const tileWidth = 1 << tileHeight;
+const hashSize = 32;
+
+export interface SumDbVerifier {
+  name: string;
+  keyHash: Buffer;
+  publicKey: crypto.KeyObject;
+}
+
+export interface SumDb {
+  verifier: SumDbVerifier;
+  url: string;
+}
+
+export interface GoSumEnv {
+  GOSUMDB?: string;
+  GONOSUMDB?: string;
+  GOPRIVATE?: string;
+}
+
+interface SignedTree {
+  size: number;
+  hash: Buffer;
+}
+
+function sha256(...data: Buffer[]): Buffer {
+  const hash = crypto.createHash('sha256');
+  for (const d of data) {
+    hash.update(d);
+  }
+  return hash.digest();
+}
+
+function getKeyHash(name: string, key: Buffer): Buffer {
+  return sha256(Buffer.from(`${name}\n`), key).subarray(0, 4);
+}
+
+/**
+ * Parses a verifier key like `sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8`.
+ *
+ * @see https://pkg.go.dev/golang.org/x/mod/sumdb/note
+ */
+export function parseVerifierKey(vkey: string): SumDbVerifier {
+  // the base64 encoded key may itself contain `+`
+  const [name, hashHex, ...keyParts] = vkey.split('+');
+  const key = Buffer.from(keyParts.join('+'), 'base64');
+  // the first byte is the algorithm, 1 is Ed25519
+  if (
+    !name ||
+    !regEx(/^[0-9a-f]{8}$/).test(hashHex ?? '') ||
+    key.length !== 33 ||
+    key[0] !== 1
+  ) {
+    throw new Error(`Invalid checksum database key: ${vkey}`);
+  }
+
+  const keyHash = getKeyHash(name, key);
+  if (keyHash.toString('hex') !== hashHex) {
+    throw new Error(`Invalid checksum database key hash: ${vkey}`);
+  }
+
+  const publicKey = crypto.createPublicKey({
+    key: {
+      kty: 'OKP',
+      crv: 'Ed25519',
+      x: key.subarray(1).toString('base64url'),
+    },
+    format: 'jwk',
+  });
+  return { name, keyHash, publicKey };
+}
+
+/**
+ * Resolves the checksum database from a `GOSUMDB` value.
+ *
+ * @returns `null` if checksum verification is turned off
+ */
+export function getSumDb(gosumdb = 'sum.golang.org'): SumDb | null {
+  const [keyOrName, url] = gosumdb.trim().split(regEx(/\s+/));
+  if (keyOrName === 'off') {
+    return null;
+  }
+
+  const known = knownSumDbs[keyOrName] as
+    | (typeof knownSumDbs)[string]
+    | undefined;
+  const verifier = parseVerifierKey(known?.key ?? keyOrName);
+  let sumDbUrl = url ?? known?.url ?? `https://${verifier.name}`;
+  if (!isHttpUrl(sumDbUrl)) {
+    sumDbUrl = `https://${sumDbUrl}`;
+  }
+  return { verifier, url: sumDbUrl };
+}
+
+/**
+ * Verifies a signed note and returns its text.
+ *
+ * @see https://pkg.go.dev/golang.org/x/mod/sumdb/note
+ */
+export function verifyNote(note: string, verifier: SumDbVerifier): string {
+  const split = note.lastIndexOf('\n\n');
+  if (split === -1) {
+    throw new Error('Malformed signed note');
+  }
+  const text = note.slice(0, split + 1);
+  for (const line of note.slice(split + 2).split('\n')) {
+    const match = regEx(/^— (?<name>\S+) (?<sig>[A-Za-z0-9+/=]+)$/).exec(
+      line,
+    );
+    if (match?.groups?.name !== verifier.name) {
+      continue;
+    }
+    const sig = Buffer.from(match.groups.sig, 'base64');
+    if (
+      sig.length === 4 + 64 &&
+      sig.subarray(0, 4).equals(verifier.keyHash) &&
+      crypto.verify(
+        null,
+        Buffer.from(text),
+        verifier.publicKey,
+        sig.subarray(4),
+      )
+    ) {
+      return text;
+    }
+  }
+  throw new Error(`Signed note is not signed by ${verifier.name}`);
+}
+
+function parseTree(text: string): SignedTree {
+  const [header, size, hash] = text.split('\n');
+  if (header !== 'go.sum database tree' || !regEx(/^\d+$/).test(size)) {
+    throw new Error('Malformed checksum database tree');
+  }
+  return { size: parseInt(size, 10), hash: Buffer.from(hash, 'base64') };
+}
+
+function hashLeaf(data: Buffer): Buffer {
+  return sha256(Buffer.from([0]), data);
+}
+
+function hashNode(left: Buffer, right: Buffer): Buffer {
+  return sha256(Buffer.from([1]), left, right);
+}
+
+/**
+ * @see https://research.swtch.com/tlog#serving_tiles
+ */
+export function getTilePath(
+  level: number,
+  index: number,
+  width: number,
+): string {
+  let n = index;
+  let path = String(n % 1000).padStart(3, '0');
+  while (n >= 1000) {
+    n = Math.floor(n / 1000);
+    path = `x${String(n % 1000).padStart(3, '0')}/${path}`;
+  }
+  const partial = width < tileWidth ? `.p/${width}` : '';
+  return `tile/${tileHeight}/${level}/${path}${partial}`;
+}
+
+/**
+ * Reads hashes of the transparency log using its tiles.
+ */
+class TileReader {
+  private readonly tiles = new Map<string, Promise<Buffer>>();
+
+  private readonly url: string;
+
+  private readonly treeSize: number;
+
+  constructor(url: string, treeSize: number) {
+    this.url = url;
+    this.treeSize = treeSize;
+  }
+
+  private getTile(path: string): Promise<Buffer> {
+    let tile = this.tiles.get(path);
+    if (!tile) {
+      tile = http
+        .getBuffer(joinUrlParts(this.url, path))
+        .then(({ body }) => body);
+      this.tiles.set(path, tile);
+    }
+    return tile;
+  }
+
+  /**
+   * Returns the hash of the complete subtree with `2^level` leaves at `index`.
+   */
+  async getHash(level: number, index: number): Promise<Buffer> {
+    const tileLevel = Math.floor(level / tileHeight);
+    const subLevel = level % tileHeight;
+    const start = index * 2 ** subLevel;
+    const count = 2 ** subLevel;
+    const tileIndex = Math.floor(start / tileWidth);
+    const offset = start % tileWidth;
+    const width = Math.min(
+      tileWidth,
+      Math.floor(this.treeSize / 2 ** (tileLevel * tileHeight)) -
+        tileIndex * tileWidth,
+    );
+
+    const tile = await this.getTile(getTilePath(tileLevel, tileIndex, width));
+    if (tile.length < (offset + count) * hashSize) {
+      throw new Error('Malformed checksum database tile');
+    }
+
+    let hashes: Buffer[] = [];
+    for (let i = offset; i < offset + count; i += 1) {
+      hashes.push(tile.subarray(i * hashSize, (i + 1) * hashSize));
+    }
+    while (hashes.length > 1) {
+      const parents: Buffer[] = [];
+      for (let i = 0; i < hashes.length; i += 2) {
+        parents.push(hashNode(hashes[i], hashes[i + 1]));
+      }
+      hashes = parents;
+    }
+    return hashes[0];
+  }
+}
+
+/**
+ * Computes the root hash of the leaves `[lo, hi)`, with the leaf at `recordId` replaced by `recordHash`.
+ */
+async function getTreeHash(
+  tiles: TileReader,
+  lo: number,
+  hi: number,
+  recordId: number,
+  recordHash: Buffer,
+): Promise<Buffer> {
+  const size = hi - lo;
+  if (size === 1 && lo === recordId) {
+    return recordHash;
+  }
+
+  const level = Math.log2(size);
+  if (
+    Number.isInteger(level) &&
+    lo % size === 0 &&
+    (recordId < lo || recordId >= hi)
+  ) {
+    return await tiles.getHash(level, lo / size);
+  }
+
+  let split = 1;
+  while (split * 2 < size) {
+    split *= 2;
+  }
+  return hashNode(
+    await getTreeHash(tiles, lo, lo + split, recordId, recordHash),
+    await getTreeHash(tiles, lo + split, hi, recordId, recordHash),
+  );
+}
+
+function escapeModulePath(input: string): string {
+  return input.replace(regEx(/([A-Z])/g), (x) => `!${x.toLowerCase()}`);
+}
+
+/**
+ * Looks up the `go.sum` lines of a module version,
+ * and proves that they are included in the signed transparency log.
+ */
+export async function lookupGoSumLines(
+  sumDb: SumDb,
+  module: string,
+  version: string,
+): Promise<string[]> {
+  const { body } = await http.getText(
+    joinUrlParts(
+      sumDb.url,
+      'lookup',
+      `${escapeModulePath(module)}@${escapeModulePath(version)}`,
+    ),
+  );
+
+  const idEnd = body.indexOf('\n');
+  const dataEnd = body.indexOf('\n\n', idEnd);
+  if (idEnd === -1 || dataEnd === -1) {
+    throw new Error(`Malformed checksum database lookup for ${module}`);
+  }
+  const recordId = parseInt(body.slice(0, idEnd), 10);
+  const data = body.slice(idEnd + 1, dataEnd + 1);
+  const tree = parseTree(verifyNote(body.slice(dataEnd + 2), sumDb.verifier));
+  if (!Number.isInteger(recordId) || recordId < 0 || recordId >= tree.size) {
+    throw new Error(`Invalid checksum database record for ${module}`);
+  }
+
+  const tiles = new TileReader(sumDb.url, tree.size);
+  const rootHash = await getTreeHash(
+    tiles,
+    0,
+    tree.size,
+    recordId,
+    hashLeaf(Buffer.from(data)),
+  );
+  if (!rootHash.equals(tree.hash)) {
+    throw new Error(
+      `Checksum database record for ${module}@${version} is not included in the signed tree`,
+    );
+  }
+
+  return data.split(newlineRegex).filter(Boolean);
+}
+
+/**
+ * Returns the lines of `newContent` which aren't in `existingContent`, grouped by module version.
+ */
+export function getNewGoSumLines(
+  existingContent: string,
+  newContent: string,
+): Map<string, string[]> {
+  const existingLines = new Set(
+    existingContent.split(newlineRegex).map((line) => line.trim()),
+  );
+  const result = new Map<string, string[]>();
+  for (const rawLine of newContent.split(newlineRegex)) {
+    const line = rawLine.trim();
+    const [module, version] = line.split(' ');
+    if (!module || !version || existingLines.has(line)) {
+      continue;
+    }
+    const key = `${module}@${version.replace(regEx(/\/go\.mod$/), '')}`;
+    result.set(key, [...(result.get(key) ?? []), line]);
+  }
+  return result;
+}
+
+/**
+ * Verifies the new lines of a `go.sum` file against the checksum database.
+ *
+ * Modules matching `GONOSUMDB` (or `GOPRIVATE`) are skipped, as Go does.
+ *
+ * @throws if a new line doesn't match the checksum database
+ */
+export async function verifyGoSum(
+  existingContent: string,
+  newContent: string,
+  env: GoSumEnv,
+): Promise<void> {
+  const sumDb = getSumDb(env.GOSUMDB);
+  if (!sumDb) {
+    logger.debug('GOSUMDB=off, skipping go.sum verification');
+    return;
+  }
+
+  const noSumDb = parseNoproxy(env.GONOSUMDB ?? env.GOPRIVATE ?? '');
+  const mismatches: string[] = [];
+  for (const [moduleVersion, lines] of getNewGoSumLines(
+    existingContent,
+    newContent,
+  )) {
+    const [module, version] = moduleVersion.split('@');
+    if (noSumDb?.test(module)) {
+      logger.debug(`Skipping go.sum verification for ${moduleVersion}`);
+      continue;
+    }
+
+    const expectedLines = await lookupGoSumLines(sumDb, module, version);
+    for (const line of lines) {
+      if (!expectedLines.includes(line)) {
+        mismatches.push(line);
+      }
+    }
+  }
+
+  if (mismatches.length > 0) {
+    throw new Error(
+      `go.sum lines do not match the checksum database ${sumDb.verifier.name}:\n${mismatches.join('\n')}`,
+    );
+  }
+  logger.debug(`Verified go.sum against ${sumDb.verifier.name}`);
+}
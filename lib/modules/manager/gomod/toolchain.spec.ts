@@ -0,0 +1,302 @@
+import { codeBlock } from 'common-tags';
+import { fs } from '~test/util.ts';
+import { setCustomEnv } from '../../../util/env.ts';
+import {
+  compareGoVersions,
+  getGoToolchainVersions,
+  isGoToolchainUpdate,
+  parseGoToolchainSetting,
+  syncGoToolchain,
+  syncGoToolchainFiles,
+} from './toolchain.ts';
+
+vi.mock('../../../util/fs/index.ts');
+
+describe('modules/manager/gomod/toolchain', () => {
+  describe('compareGoVersions()', () => {
+    it.each`
+      a            | b              | expected
+      ${'1.21'}    | ${'1.21rc1'}   | ${-1}
+      ${'1.21rc1'} | ${'1.21rc2'}   | ${-1}
+      ${'1.21rc2'} | ${'1.21.0'}    | ${-1}
+      ${'1.21.0'}  | ${'1.21.0'}    | ${0}
+      ${'1.21.3'}  | ${'1.21.10'}   | ${-1}
+      ${'1.22'}    | ${'1.21.10'}   | ${1}
+      ${'1.22rc1'} | ${'1.22beta1'} | ${1}
+      ${'invalid'} | ${'1.0'}       | ${-1}
+      ${'invalid'} | ${'other'}     | ${0}
+    `('compares $a with $b', ({ a, b, expected }) => {
+      expect(Math.sign(compareGoVersions(a, b))).toBe(expected);
+      expect(Math.sign(compareGoVersions(b, a))).toBe(-expected || 0);
+    });
+  });
+
+  describe('parseGoToolchainSetting()', () => {
+    it.each`
+      value              | expected
+      ${undefined}       | ${{ switching: true }}
+      ${''}              | ${{ switching: true }}
+      ${'auto'}          | ${{ switching: true }}
+      ${'path'}          | ${{ switching: true }}
+      ${'local'}         | ${{ switching: false }}
+      ${'go1.22.3'}      | ${{ version: '1.22.3', switching: false }}
+      ${'go1.22.3+auto'} | ${{ version: '1.22.3', switching: true }}
+      ${'go1.22.3+path'} | ${{ version: '1.22.3', switching: true }}
+      ${'gofoo'}         | ${{ switching: false }}
+    `('parses "$value"', ({ value, expected }) => {
+      expect(parseGoToolchainSetting(value)).toEqual(expected);
+    });
+  });
+
+  describe('getGoToolchainVersions()', () => {
+    it('returns the go and toolchain versions', () => {
+      const content = codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.22
+
+        toolchain go1.22.3
+      `;
+      expect(getGoToolchainVersions(content)).toEqual({
+        go: '1.22',
+        toolchain: '1.22.3',
+      });
+    });
+
+    it('ignores toolchains other than go', () => {
+      expect(getGoToolchainVersions('go 1.22\ntoolchain default\n')).toEqual({
+        go: '1.22',
+      });
+    });
+  });
+
+  describe('syncGoToolchain()', () => {
+    const auto = parseGoToolchainSetting('auto');
+
+    it('returns content without go directive', () => {
+      const content = 'module github.com/renovate-tests/gomod\n';
+      expect(syncGoToolchain(content, auto, { go: '1.22' })).toBe(content);
+    });
+
+    it('keeps a consistent file unchanged', () => {
+      const content = codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.21.0
+
+        toolchain go1.22.3
+      `;
+      expect(syncGoToolchain(content, auto)).toBe(content);
+    });
+
+    it('removes a toolchain which is not newer than go', () => {
+      const content = codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.22.3
+
+        toolchain go1.22.1
+
+        require github.com/pkg/errors v0.9.1
+      `;
+      expect(syncGoToolchain(content, auto)).toBe(codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.22.3
+
+        require github.com/pkg/errors v0.9.1
+      `);
+    });
+
+    it('removes a trailing toolchain', () => {
+      const content = 'go 1.22.3\n\ntoolchain go1.22.3\n';
+      expect(syncGoToolchain(content, auto)).toBe('go 1.22.3\n');
+    });
+
+    it('adds the toolchain named by GOTOOLCHAIN', () => {
+      const content = codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.21.0
+
+        require github.com/pkg/errors v0.9.1
+      `;
+      expect(
+        syncGoToolchain(content, parseGoToolchainSetting('go1.22.3+auto')),
+      ).toBe(codeBlock`
+        module github.com/renovate-tests/gomod
+
+        go 1.21.0
+
+        toolchain go1.22.3
+
+        require github.com/pkg/errors v0.9.1
+      `);
+    });
+
+    it('raises an older toolchain to the one named by GOTOOLCHAIN', () => {
+      const content = 'go 1.21.0\n\ntoolchain go1.21.5\n';
+      expect(
+        syncGoToolchain(content, parseGoToolchainSetting('go1.22.3+path')),
+      ).toBe('go 1.21.0\n\ntoolchain go1.22.3\n');
+    });
+
+    it('keeps a newer toolchain than the one named by GOTOOLCHAIN', () => {
+      const content = 'go 1.21.0\n\ntoolchain go1.23.0\n';
+      expect(
+        syncGoToolchain(content, parseGoToolchainSetting('go1.22.3+auto')),
+      ).toBe(content);
+    });
+
+    it('does not add the toolchain named by GOTOOLCHAIN without switching', () => {
+      const content = 'go 1.21.0\n\ntoolchain go1.21.5\n';
+      expect(
+        syncGoToolchain(content, parseGoToolchainSetting('go1.22.3')),
+      ).toBe(content);
+    });
+
+    it('does not add a toolchain for GOTOOLCHAIN=local', () => {
+      const content = 'go 1.21.0\n';
+      expect(syncGoToolchain(content, parseGoToolchainSetting('local'))).toBe(
+        content,
+      );
+    });
+
+    it('raises go and toolchain to the minimum', () => {
+      const content = codeBlock`
+        go 1.21.0
+
+        toolchain go1.21.5
+
+        use ./foo
+      `;
+      expect(
+        syncGoToolchain(content, auto, { go: '1.22.0', toolchain: '1.22.3' }),
+      ).toBe(codeBlock`
+        go 1.22.0
+
+        toolchain go1.22.3
+
+        use ./foo
+      `);
+    });
+
+    it('removes the toolchain when go is raised past it', () => {
+      const content = 'go 1.21.0\n\ntoolchain go1.21.5\n\nuse ./foo\n';
+      expect(syncGoToolchain(content, auto, { go: '1.22.0' })).toBe(
+        'go 1.22.0\n\nuse ./foo\n',
+      );
+    });
+
+    it('never lowers go', () => {
+      const content = 'go 1.22.0\n';
+      expect(syncGoToolchain(content, auto, { go: '1.21.0' })).toBe(content);
+    });
+  });
+
+  describe('isGoToolchainUpdate()', () => {
+    it.each`
+      depName               | depType        | expected
+      ${'go'}               | ${'golang'}    | ${true}
+      ${'go'}               | ${'toolchain'} | ${true}
+      ${'go'}               | ${'require'}   | ${false}
+      ${'golang.org/x/net'} | ${'require'}   | ${false}
+    `(
+      'returns $expected for $depName ($depType)',
+      ({ depName, depType, expected }) => {
+        expect(isGoToolchainUpdate([{ depName, depType }])).toBe(expected);
+      },
+    );
+  });
+
+  describe('syncGoToolchainFiles()', () => {
+    beforeEach(() => {
+      setCustomEnv({});
+    });
+
+    it('syncs the file only', async () => {
+      const res = await syncGoToolchainFiles(
+        'go.mod',
+        'go 1.22.3\n\ntoolchain go1.22.1\n',
+      );
+      expect(res).toEqual({ content: 'go 1.22.3\n', results: [] });
+      expect(fs.findLocalSiblingOrParent).toHaveBeenCalledTimes(2);
+      expect(fs.writeLocalFile).not.toHaveBeenCalled();
+    });
+
+    it('syncs go.work and .go-version', async () => {
+      setCustomEnv({ GOTOOLCHAIN: 'go1.22.3+auto' });
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce('go.work');
+      fs.readLocalFile.mockResolvedValueOnce('go 1.21.0\n\nuse ./foo\n');
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce('.go-version');
+      fs.readLocalFile.mockResolvedValueOnce('1.21\n');
+
+      const res = await syncGoToolchainFiles('foo/go.mod', 'go 1.22.0\n');
+
+      expect(res).toEqual({
+        content: 'go 1.22.0\n\ntoolchain go1.22.3\n',
+        results: [
+          {
+            file: {
+              type: 'addition',
+              path: 'go.work',
+              contents: 'go 1.22.0\n\ntoolchain go1.22.3\n\nuse ./foo\n',
+            },
+          },
+          {
+            file: {
+              type: 'addition',
+              path: '.go-version',
+              contents: '1.22\n',
+            },
+          },
+        ],
+      });
+      expect(fs.writeLocalFile).toHaveBeenCalledTimes(2);
+      expect(fs.writeLocalFile).toHaveBeenNthCalledWith(
+        1,
+        'go.work',
+        'go 1.22.0\n\ntoolchain go1.22.3\n\nuse ./foo\n',
+      );
+      expect(fs.writeLocalFile).toHaveBeenNthCalledWith(
+        2,
+        '.go-version',
+        '1.22\n',
+      );
+    });
+
+    it('does not look for go.work from go.work', async () => {
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce('.go-version');
+      fs.readLocalFile.mockResolvedValueOnce('1.22.3\n');
+
+      const res = await syncGoToolchainFiles('go.work', 'go 1.22.0\n');
+
+      expect(res).toEqual({ content: 'go 1.22.0\n', results: [] });
+      expect(fs.findLocalSiblingOrParent).toHaveBeenCalledExactlyOnceWith(
+        'go.work',
+        '.go-version',
+      );
+    });
+
+    it('updates .go-version with full precision', async () => {
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce(null);
+      fs.findLocalSiblingOrParent.mockResolvedValueOnce('.go-version');
+      fs.readLocalFile.mockResolvedValueOnce('1.21.5\n');
+
+      const res = await syncGoToolchainFiles(
+        'go.mod',
+        'go 1.22.0\n\ntoolchain go1.22.3\n',
+      );
+
+      expect(res.results).toEqual([
+        {
+          file: {
+            type: 'addition',
+            path: '.go-version',
+            contents: '1.22.3\n',
+          },
+        },
+      ]);
+    });
+  });
+});
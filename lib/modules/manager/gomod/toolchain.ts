@@ -0,0 +1,310 @@
+import upath from 'upath';
+import { logger } from '../../../logger/index.ts';
+import { getEnv } from '../../../util/env.ts';
+import {
+  findLocalSiblingOrParent,
+  readLocalFile,
+  writeLocalFile,
+} from '../../../util/fs/index.ts';
+import { newlineRegex, regEx } from '../../../util/regex.ts';
+import type { UpdateArtifactsResult, Upgrade } from '../types.ts';
+import { getDirectives, parseGoMod } from './parser.ts';
+import type { GoModToken } from './types.ts';
+
+const goVersionRegex = regEx(
+  /^(?<major>\d+)(?:\.(?<minor>\d+))?(?:\.(?<patch>\d+)|(?<pre>rc|beta)(?<preNum>\d+))?$/,
+);
+
+// language versions like `1.21` sort before their prereleases and releases
+const versionKinds: Record<string, number> = { beta: 1, rc: 2 };
+
+function parseGoVersion(version: string): number[] | null {
+  const groups = goVersionRegex.exec(version)?.groups;
+  if (!groups) {
+    return null;
+  }
+  const { major, minor, patch, pre, preNum } = groups;
+  let kind = 0;
+  let num = 0;
+  if (patch) {
+    kind = 3;
+    num = parseInt(patch, 10);
+  } else if (pre) {
+    kind = versionKinds[pre];
+    num = parseInt(preNum, 10);
+  }
+  return [parseInt(major, 10), parseInt(minor ?? '0', 10), kind, num];
+}
+
+/**
+ * Compares Go versions as the `go` command does, so that `1.21` < `1.21rc1` < `1.21.0`.
+ *
+ * Invalid versions sort before all valid versions.
+ *
+ * @see https://go.dev/doc/toolchain#version
+ */
+export function compareGoVersions(a: string, b: string): number {
+  const left = parseGoVersion(a);
+  const right = parseGoVersion(b);
+  if (!left || !right) {
+    return Number(!!left) - Number(!!right);
+  }
+  for (let i = 0; i < left.length; i += 1) {
+    if (left[i] !== right[i]) {
+      return left[i] - right[i];
+    }
+  }
+  return 0;
+}
+
+export interface GoToolchainSetting {
+  /** Version of the toolchain named by `GOTOOLCHAIN`, if any */
+  version?: string;
+  /** Whether the `go` command may switch to the toolchain a module asks for */
+  switching: boolean;
+}
+
+/**
+ * Parses a `GOTOOLCHAIN` value like `local`, `auto`, `go1.22.3` or `go1.22.3+auto`.
+ *
+ * @see https://go.dev/doc/toolchain#select
+ */
+export function parseGoToolchainSetting(value?: string): GoToolchainSetting {
+  const [name, suffix] = (value?.trim() || 'auto').split('+');
+  const switching = name === 'auto' || name === 'path' || !!suffix;
+  const version = name.replace(regEx(/^go/), '');
+  if (name.startsWith('go') && parseGoVersion(version)) {
+    return { version, switching };
+  }
+  return { switching };
+}
+
+function getLineRange(content: string, token: GoModToken): [number, number] {
+  const start = content.lastIndexOf('\n', token.offset) + 1;
+  const end = content.indexOf('\n', token.offset);
+  return [start, end === -1 ? content.length : end];
+}
+
+interface GoToolchainEdit {
+  start: number;
+  end: number;
+  text: string;
+}
+
+function replaceToken(token: GoModToken, text: string): GoToolchainEdit {
+  return { start: token.offset, end: token.offset + token.text.length, text };
+}
+
+function removeLine(content: string, token: GoModToken): GoToolchainEdit {
+  let [start, end] = getLineRange(content, token);
+  end = Math.min(end + 1, content.length);
+  // keep a single blank line between the surrounding directives
+  if (content.slice(0, start).endsWith('\n\n')) {
+    const blankLine = regEx(/^\r?\n/).exec(content.slice(end));
+    if (blankLine) {
+      end += blankLine[0].length;
+    } else if (end === content.length) {
+      start -= 1;
+    }
+  }
+  return { start, end, text: '' };
+}
+
+export interface GoToolchainVersions {
+  go?: string;
+  toolchain?: string;
+}
+
+/**
+ * Returns the versions of the `go` and `toolchain` directives of a `go.mod` or `go.work` file.
+ */
+export function getGoToolchainVersions(content: string): GoToolchainVersions {
+  const result: GoToolchainVersions = {};
+  for (const { verb, args, inBlock } of getDirectives(parseGoMod(content))) {
+    const value = args[0]?.value;
+    if (inBlock || !value) {
+      continue;
+    }
+    if (verb === 'go') {
+      result.go = value;
+    } else if (verb === 'toolchain' && value.startsWith('go')) {
+      result.toolchain = value.replace(regEx(/^go/), '');
+    }
+  }
+  return result;
+}
+
+/**
+ * Makes the `go` and `toolchain` directives of a `go.mod` or `go.work` file consistent, as the `go` command would:
+ *
+ * - both are raised to `minimum`, if given
+ * - a `toolchain` named by `GOTOOLCHAIN` which is newer than the `go` version is inserted, if toolchain switching is enabled like in `GOTOOLCHAIN=go1.22.3+auto`
+ * - a `toolchain` which isn't newer than the `go` version is removed
+ *
+ * @see https://go.dev/doc/toolchain#config
+ */
+export function syncGoToolchain(
+  content: string,
+  setting: GoToolchainSetting,
+  minimum: GoToolchainVersions = {},
+): string {
+  const directives = getDirectives(parseGoMod(content)).filter(
+    ({ inBlock, args }) => !inBlock && args.length,
+  );
+  const goToken = directives.find(({ verb }) => verb === 'go')?.args[0];
+  if (!goToken || !parseGoVersion(goToken.value)) {
+    return content;
+  }
+  const toolchainToken = directives.find(
+    ({ verb, args }) => verb === 'toolchain' && args[0].value.startsWith('go'),
+  )?.args[0];
+
+  let go = goToken.value;
+  if (minimum.go && compareGoVersions(minimum.go, go) > 0) {
+    go = minimum.go;
+  }
+  let toolchain = toolchainToken?.value.replace(regEx(/^go/), '');
+  // without switching, the go command only runs the named toolchain and never records it
+  const settingVersion = setting.switching ? setting.version : undefined;
+  for (const candidate of [minimum.toolchain, settingVersion]) {
+    if (
+      candidate &&
+      compareGoVersions(candidate, go) > 0 &&
+      (!toolchain || compareGoVersions(candidate, toolchain) > 0)
+    ) {
+      toolchain = candidate;
+    }
+  }
+  if (toolchain && compareGoVersions(toolchain, go) <= 0) {
+    toolchain = undefined;
+  }
+
+  const edits: GoToolchainEdit[] = [];
+  if (go !== goToken.value) {
+    edits.push(replaceToken(goToken, go));
+  }
+  if (toolchainToken && !toolchain) {
+    logger.debug(`Removing toolchain ${toolchainToken.value}`);
+    edits.push(removeLine(content, toolchainToken));
+  } else if (toolchainToken && toolchain) {
+    edits.push(replaceToken(toolchainToken, `go${toolchain}`));
+  } else if (toolchain) {
+    logger.debug(`Adding toolchain go${toolchain}`);
+    const [, end] = getLineRange(content, goToken);
+    edits.push({ start: end, end, text: `\n\ntoolchain go${toolchain}` });
+  }
+
+  // apply from the end of the file so that earlier offsets remain valid
+  let result = content;
+  for (const { start, end, text } of edits.sort((a, b) => b.start - a.start)) {
+    result = result.slice(0, start) + text + result.slice(end);
+  }
+  return result;
+}
+
+/**
+ * Whether an update changes the `go` or `toolchain` directive of a `go.mod` or `go.work` file.
+ */
+export function isGoToolchainUpdate(upgrades: Upgrade[]): boolean {
+  return upgrades.some(
+    ({ depName, depType }) =>
+      depName === 'go' && (depType === 'golang' || depType === 'toolchain'),
+  );
+}
+
+function updateGoVersionFile(content: string, version: string): string {
+  const current = content.trim();
+  if (!parseGoVersion(current) || compareGoVersions(version, current) <= 0) {
+    return content;
+  }
+  // keep the precision of the existing file, e.g. `1.22` rather than `1.22.3`
+  const precision = current.split('.').length;
+  const newVersion = version.split('.').slice(0, precision).join('.');
+  return content.replace(current, newVersion);
+}
+
+/**
+ * Applies the coordinated `go` and `toolchain` update of `goToolchainSync` to a `go.mod` or `go.work` file.
+ *
+ * Keeps the directives consistent, and brings the enclosing `go.work` and the nearest `.go-version` file along.
+ * Updated files are written to disk, so that the following `go` commands see them.
+ *
+ * @returns the synced content of the file, and the other files which were updated
+ */
+export async function syncGoToolchainFiles(
+  fileName: string,
+  content: string,
+): Promise<{ content: string; results: UpdateArtifactsResult[] }> {
+  const setting = parseGoToolchainSetting(getEnv().GOTOOLCHAIN);
+  const syncedContent = syncGoToolchain(content, setting);
+  if (syncedContent !== content) {
+    logger.debug(`Synced go and toolchain directives of ${fileName}`);
+  }
+  const versions = getGoToolchainVersions(syncedContent);
+  if (
+    setting.version &&
+    !setting.switching &&
+    versions.go &&
+    compareGoVersions(setting.version, versions.go) < 0
+  ) {
+    logger.debug(
+      `GOTOOLCHAIN=go${setting.version} is older than go ${versions.go} of ${fileName}`,
+    );
+  }
+
+  const results: UpdateArtifactsResult[] = [];
+  if (upath.basename(fileName) !== 'go.work') {
+    const goWorkFileName = await findLocalSiblingOrParent(fileName, 'go.work');
+    const goWorkContent = goWorkFileName
+      ? await readLocalFile(goWorkFileName, 'utf8')
+      : null;
+    if (goWorkFileName && goWorkContent) {
+      // a workspace must declare at least the versions of its modules
+      const newGoWorkContent = syncGoToolchain(
+        goWorkContent,
+        setting,
+        versions,
+      );
+      if (newGoWorkContent !== goWorkContent) {
+        logger.debug(`Synced go and toolchain directives of ${goWorkFileName}`);
+        await writeLocalFile(goWorkFileName, newGoWorkContent);
+        results.push({
+          file: {
+            type: 'addition',
+            path: goWorkFileName,
+            contents: newGoWorkContent,
+          },
+        });
+      }
+    }
+  }
+
+  const goVersionFileName = await findLocalSiblingOrParent(
+    fileName,
+    '.go-version',
+  );
+  const goVersionContent = goVersionFileName
+    ? await readLocalFile(goVersionFileName, 'utf8')
+    : null;
+  const version = versions.toolchain ?? versions.go;
+  if (goVersionFileName && goVersionContent && version) {
+    const [firstLine] = goVersionContent.split(newlineRegex);
+    const newGoVersionContent = goVersionContent.replace(
+      firstLine,
+      updateGoVersionFile(firstLine, version),
+    );
+    if (newGoVersionContent !== goVersionContent) {
+      logger.debug(`Updated ${goVersionFileName} to ${version}`);
+      await writeLocalFile(goVersionFileName, newGoVersionContent);
+      results.push({
+        file: {
+          type: 'addition',
+          path: goVersionFileName,
+          contents: newGoVersionContent,
+        },
+      });
+    }
+  }
+
+  return { content: syncedContent, results };
+}
@@ -10,3 +10,72 @@ export interface ExtraDep {
   currentValue: string;
   newValue: string;
 }
+
+export type GoModTokenType =
+  | 'word'
+  | 'string'
+  | 'arrow'
+  | 'lbracket'
+  | 'rbracket'
+  | 'comma';
+
+export interface GoModToken {
+  type: GoModTokenType;
+  /** Token value, with quotes removed from quoted strings */
+  value: string;
+  /** Raw token text as found in the file */
+  text: string;
+  /** Offset of the token from the start of the file */
+  offset: number;
+  /** Zero-based line number of the token */
+  lineNumber: number;
+}
+
+export interface GoModComments {
+  /** Comment lines directly preceding the statement */
+  before: string[];
+  /** Comment on the same line, after the statement */
+  suffix?: string;
+}
+
+export interface GoModDirective {
+  type: 'directive';
+  /** Directive keyword, inherited from the enclosing block if any */
+  verb: string;
+  args: GoModToken[];
+  lineNumber: number;
+  inBlock: boolean;
+  comments: GoModComments;
+}
+
+export interface GoModBlock {
+  type: 'block';
+  verb: string;
+  lineNumber: number;
+  directives: GoModDirective[];
+  comments: GoModComments;
+}
+
+export type GoModStatement = GoModDirective | GoModBlock;
+
+export interface GoModFile {
+  statements: GoModStatement[];
+}
+
+export interface GoImportSpec {
+  /** Package name of the import, e.g. `_` or `.` */
+  name?: string;
+  /** Import path, without quotes */
+  path: string;
+  /** Offset of the quoted import path in the source */
+  offset: number;
+  /** Quoted import path as written in the source */
+  text: string;
+}
+
+export interface ImportPathRewrite {
+  /** Module path to rewrite, including any major version suffix */
+  from: string;
+  /** Module path to rewrite to */
+  to: string;
+}
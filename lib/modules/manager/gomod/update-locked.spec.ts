@@ -0,0 +1,352 @@
+import { codeBlock } from 'common-tags';
+import upath from 'upath';
+import { envMock, mockExecSequence } from '~test/exec-util.ts';
+import { env, fs, logger } from '~test/util.ts';
+import { GlobalConfig } from '../../../config/global.ts';
+import type { RepoGlobalConfig } from '../../../config/types.ts';
+import type { UpdateLockedConfig } from '../types.ts';
+import {
+  getRequiringModules,
+  parseBuildList,
+  parseGoSum,
+  updateLockedDependency,
+  updateRequire,
+} from './update-locked.ts';
+
+vi.mock('../../../util/exec/env.ts');
+vi.mock('../../../util/fs/index.ts');
+
+const adminConfig: RepoGlobalConfig = {
+  localDir: upath.join('/tmp/github/some/repo'),
+  cacheDir: upath.join('/tmp/renovate/cache'),
+  binarySource: 'global',
+};
+
+const goMod = codeBlock`
+  module github.com/renovate-tests/gomod
+
+  go 1.22.0
+
+  require github.com/pkg/errors v0.9.1
+
+  require (
+    golang.org/x/sys v0.20.0 // indirect
+    golang.org/x/text v0.15.0 // indirect
+  )
+`;
+
+const goSum = 'Current go.sum';
+
+const buildList = codeBlock`
+  github.com/renovate-tests/gomod
+  github.com/pkg/errors v0.9.1
+  golang.org/x/net v0.25.0
+  golang.org/x/sys v0.20.0
+  golang.org/x/text v0.15.0
+`;
+
+const graph = codeBlock`
+  github.com/renovate-tests/gomod github.com/pkg/errors@v0.9.1
+  github.com/renovate-tests/gomod golang.org/x/sys@v0.20.0
+  github.com/renovate-tests/gomod golang.org/x/text@v0.15.0
+  github.com/pkg/errors@v0.9.1 golang.org/x/net@v0.25.0
+  golang.org/x/net@v0.25.0 golang.org/x/text@v0.15.0
+`;
+
+const config: UpdateLockedConfig = {
+  packageFile: 'go.mod',
+  packageFileContent: goMod,
+  lockFile: 'go.sum',
+  lockFileContent: goSum,
+  depName: 'golang.org/x/net',
+  currentVersion: 'v0.25.0',
+  newVersion: 'v0.33.0',
+};
+
+describe('modules/manager/gomod/update-locked', () => {
+  beforeEach(() => {
+    env.getChildProcessEnv.mockReturnValue(envMock.basic);
+    GlobalConfig.set(adminConfig);
+  });
+
+  afterEach(() => {
+    GlobalConfig.reset();
+  });
+
+  describe('parseBuildList()', () => {
+    it('parses go list output', () => {
+      expect(
+        parseBuildList(`${buildList}\ngithub.com/foo/bar v1.0.0 => ../bar\n`),
+      ).toEqual(
+        new Map([
+          ['github.com/pkg/errors', 'v0.9.1'],
+          ['golang.org/x/net', 'v0.25.0'],
+          ['golang.org/x/sys', 'v0.20.0'],
+          ['golang.org/x/text', 'v0.15.0'],
+          ['github.com/foo/bar', 'v1.0.0'],
+        ]),
+      );
+    });
+  });
+
+  describe('parseGoSum()', () => {
+    it('returns the highest version of each module in the build', () => {
+      expect(
+        parseGoSum(codeBlock`
+          github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+          github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+          golang.org/x/net v0.20.0/go.mod h1:z8BVo6PvndSri0LbOE3hAn0apkU+1YvI6E70E9jsnvY=
+          golang.org/x/net v0.25.0 h1:d/OCCoBEUq33pjydKrGQhw7IlUPI2Oylr+8qLx49kac=
+          golang.org/x/net v0.9.0 h1:aWJ/m6xSmxWBx+V0XRHTlrYrPG56jKsLdTFmsSsCzOM=
+          golang.org/x/tools v0.21.0/go.mod h1:aiJjzUbINMkxbQROHiO6hDPo2LHcIPhhQsa9DLh0yGk=
+        `),
+      ).toEqual(
+        new Map([
+          ['github.com/pkg/errors', 'v0.9.1'],
+          ['golang.org/x/net', 'v0.25.0'],
+        ]),
+      );
+    });
+  });
+
+  describe('getRequiringModules()', () => {
+    it('returns the modules requiring a version', () => {
+      expect(
+        getRequiringModules(graph, 'golang.org/x/text', 'v0.15.0'),
+      ).toEqual([
+        'github.com/renovate-tests/gomod',
+        'golang.org/x/net@v0.25.0',
+      ]);
+    });
+  });
+
+  describe('updateRequire()', () => {
+    it('raises an existing requirement', () => {
+      expect(updateRequire(goMod, 'golang.org/x/text', 'v0.21.0')).toBe(
+        goMod.replace('v0.15.0', 'v0.21.0'),
+      );
+    });
+
+    it('adds an indirect requirement to the indirect block', () => {
+      expect(updateRequire(goMod, 'golang.org/x/net', 'v0.33.0')).toBe(
+        codeBlock`
+          module github.com/renovate-tests/gomod
+
+          go 1.22.0
+
+          require github.com/pkg/errors v0.9.1
+
+          require (
+            golang.org/x/sys v0.20.0 // indirect
+            golang.org/x/text v0.15.0 // indirect
+            golang.org/x/net v0.33.0 // indirect
+          )
+        `,
+      );
+    });
+
+    it('adds an indirect requirement', () => {
+      const content = 'module github.com/renovate-tests/gomod\n\ngo 1.16\n';
+      expect(updateRequire(content, 'golang.org/x/net', 'v0.33.0')).toBe(
+        `${content}\nrequire golang.org/x/net v0.33.0 // indirect\n`,
+      );
+    });
+  });
+
+  describe('updateLockedDependency()', () => {
+    it('rejects invalid versions', async () => {
+      expect(
+        await updateLockedDependency({ ...config, newVersion: 'master' }),
+      ).toEqual({ status: 'update-failed' });
+    });
+
+    it('rejects replaced modules', async () => {
+      const res = await updateLockedDependency({
+        ...config,
+        packageFileContent: `${goMod}\nreplace golang.org/x/net => ../net\n`,
+      });
+      expect(res).toEqual({ status: 'update-failed' });
+    });
+
+    it('detects already required versions', async () => {
+      const execSnapshots = mockExecSequence([]);
+      const res = await updateLockedDependency({
+        ...config,
+        depName: 'golang.org/x/text',
+        currentVersion: 'v0.14.0',
+        newVersion: 'v0.15.0',
+      });
+      expect(res).toEqual({ status: 'already-updated' });
+      expect(execSnapshots).toBeEmptyArray();
+    });
+
+    it('detects already selected versions', async () => {
+      const execSnapshots = mockExecSequence([
+        { stdout: buildList, stderr: '' },
+      ]);
+      const res = await updateLockedDependency({
+        ...config,
+        newVersion: 'v0.25.0',
+      });
+      expect(res).toEqual({ status: 'already-updated' });
+      expect(execSnapshots).toMatchObject([{ cmd: 'go list -m all' }]);
+      expect(fs.writeLocalFile).toHaveBeenCalledWith('go.sum', goSum);
+    });
+
+    it('rejects modules outside of the build list', async () => {
+      mockExecSequence([{ stdout: buildList, stderr: '' }]);
+      const res = await updateLockedDependency({
+        ...config,
+        depName: 'golang.org/x/crypto',
+      });
+      expect(res).toEqual({ status: 'update-failed' });
+    });
+
+    it('requires the fixed version', async () => {
+      const execSnapshots = mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        { stdout: graph, stderr: '' },
+        {
+          stdout: buildList.replace('x/net v0.25.0', 'x/net v0.33.0'),
+          stderr: '',
+        },
+      ]);
+
+      const res = await updateLockedDependency(config);
+
+      const newGoMod = updateRequire(goMod, 'golang.org/x/net', 'v0.33.0');
+      expect(res).toEqual({
+        status: 'updated',
+        files: { 'go.mod': newGoMod },
+      });
+      expect(execSnapshots).toMatchObject([
+        {
+          cmd: 'go list -m all',
+          options: {
+            cwd: '/tmp/github/some/repo',
+            env: { GOFLAGS: '-mod=mod -modcacherw' },
+          },
+        },
+        { cmd: 'go mod graph' },
+        { cmd: 'go list -m all' },
+      ]);
+      expect(fs.writeLocalFile.mock.calls).toEqual([
+        ['go.mod', goMod],
+        ['go.mod', newGoMod],
+        ['go.mod', goMod],
+        ['go.sum', goSum],
+      ]);
+    });
+
+    it('uses -modfile for non-default go.mod filenames', async () => {
+      const execSnapshots = mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        { stdout: graph, stderr: '' },
+        {
+          stdout: buildList.replace('x/net v0.25.0', 'x/net v0.33.0'),
+          stderr: '',
+        },
+      ]);
+
+      const res = await updateLockedDependency({
+        ...config,
+        packageFile: 'tools/tools.mod',
+        lockFile: 'tools/tools.sum',
+      });
+
+      expect(res.status).toBe('updated');
+      expect(execSnapshots).toMatchObject([
+        { cmd: 'go list -modfile=tools.mod -m all' },
+        { cmd: 'go mod graph -modfile=tools.mod' },
+        { cmd: 'go list -modfile=tools.mod -m all' },
+      ]);
+    });
+
+    it('reports parents when other modules are upgraded', async () => {
+      mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        { stdout: graph, stderr: '' },
+        {
+          stdout: buildList
+            .replace('x/net v0.25.0', 'x/net v0.33.0')
+            .replace('x/sys v0.20.0', 'x/sys v0.28.0'),
+          stderr: '',
+        },
+      ]);
+
+      const res = await updateLockedDependency({
+        ...config,
+        allowParentUpdates: false,
+      });
+
+      expect(res).toEqual({ status: 'update-failed' });
+      expect(logger.logger.info).toHaveBeenCalledWith(
+        {
+          depName: 'golang.org/x/net',
+          newVersion: 'v0.33.0',
+          upgradedModules: [['golang.org/x/sys', 'v0.28.0']],
+          parents: ['github.com/pkg/errors@v0.9.1'],
+        },
+        'Requiring the fixed version upgrades other modules, a newer version of a parent module is needed instead',
+      );
+    });
+
+    it('allows other upgrades with allowParentUpdates', async () => {
+      mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        { stdout: graph, stderr: '' },
+        {
+          stdout: buildList
+            .replace('x/net v0.25.0', 'x/net v0.33.0')
+            .replace('x/sys v0.20.0', 'x/sys v0.28.0'),
+          stderr: '',
+        },
+      ]);
+
+      const res = await updateLockedDependency(config);
+
+      expect(res.status).toBe('updated');
+    });
+
+    it('reports parents when the fixed version is not selected', async () => {
+      mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        { stdout: graph, stderr: '' },
+        { stdout: buildList, stderr: '' },
+      ]);
+
+      const res = await updateLockedDependency(config);
+
+      expect(res).toEqual({ status: 'update-failed' });
+      expect(logger.logger.info).toHaveBeenCalledWith(
+        {
+          depName: 'golang.org/x/net',
+          newVersion: 'v0.33.0',
+          newSelectedVersion: 'v0.25.0',
+          parents: ['github.com/pkg/errors@v0.9.1'],
+        },
+        'Requiring the fixed version did not select it, a newer version of a parent module is needed instead',
+      );
+    });
+
+    it('handles go command errors', async () => {
+      mockExecSequence([
+        { stdout: buildList, stderr: '' },
+        { stdout: graph, stderr: '' },
+        new Error('go: golang.org/x/net@v0.33.0: invalid version'),
+      ]);
+
+      const res = await updateLockedDependency(config);
+
+      expect(res).toEqual({ status: 'update-failed' });
+      expect(logger.logger.debug).toHaveBeenCalledWith(
+        expect.objectContaining({
+          depName: 'golang.org/x/net',
+          parents: ['github.com/pkg/errors@v0.9.1'],
+        }),
+        'gomod.updateLockedDependency() error',
+      );
+      expect(fs.writeLocalFile).toHaveBeenLastCalledWith('go.sum', goSum);
+    });
+  });
+});
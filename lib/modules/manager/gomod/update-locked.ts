@@ -0,0 +1,279 @@
+import semver from 'semver';
+import { quote } from 'shlex';
+import upath from 'upath';
+import { TEMPORARY_ERROR } from '../../../constants/error-messages.ts';
+import { logger } from '../../../logger/index.ts';
+import { getEnv } from '../../../util/env.ts';
+import type { ExecOptions } from '../../../util/exec/types.ts';
+import { ensureCacheDir, writeLocalFile } from '../../../util/fs/index.ts';
+import { withGitEnvironment } from '../../../util/git/exec.ts';
+import { newlineRegex, regEx } from '../../../util/regex.ts';
+import type { UpdateLockedConfig, UpdateLockedResult } from '../types.ts';
+import { deriveGoToolchainConstraints } from './artifacts.ts';
+import { getDirectives, isIndirect, parseGoMod } from './parser.ts';
+import type { GoModBlock } from './types.ts';
+
+const gitExec = withGitEnvironment(['go']);
+
+/**
+ * Parses the output of `go list -m all` into the selected version of each module.
+ */
+export function parseBuildList(stdout: string): Map<string, string> {
+  const buildList = new Map<string, string>();
+  for (const line of stdout.split(newlineRegex)) {
+    // replaced modules are listed as `module version => replacement version`
+    const [module, version] = line.trim().split(regEx(/\s+/));
+    if (module && version) {
+      buildList.set(module, version);
+    }
+  }
+  return buildList;
+}
+
+/**
+ * Returns the modules which require `module@version`, according to the output of `go mod graph`.
+ */
+export function getRequiringModules(
+  stdout: string,
+  module: string,
+  version: string,
+): string[] {
+  const requirement = `${module}@${version}`;
+  const result: string[] = [];
+  for (const line of stdout.split(newlineRegex)) {
+    const [from, to] = line.trim().split(' ');
+    if (from && to === requirement) {
+      result.push(from);
+    }
+  }
+  return result;
+}
+
+/**
+ * Returns the highest version of each module whose content is checksummed in go.sum.
+ * Modules with only a `/go.mod` checksum are part of the module graph, but not of the build.
+ */
+export function parseGoSum(content: string): Map<string, string> {
+  const versions = new Map<string, string>();
+  for (const line of content.split(newlineRegex)) {
+    const [module, version] = line.trim().split(' ');
+    if (!module || !semver.valid(version)) {
+      continue;
+    }
+    const current = versions.get(module);
+    if (!current || semver.gt(version, current)) {
+      versions.set(module, version);
+    }
+  }
+  return versions;
+}
+
+/**
+ * Raises the `require` directive of `depName` to `newVersion`, or adds it as an `// indirect` requirement.
+ */
+export function updateRequire(
+  content: string,
+  depName: string,
+  newVersion: string,
+): string {
+  const file = parseGoMod(content);
+  const requirement = getDirectives(file).find(
+    ({ verb, args }) => verb === 'require' && args[0]?.value === depName,
+  );
+  const versionToken = requirement?.args[1];
+  if (versionToken) {
+    return (
+      content.slice(0, versionToken.offset) +
+      newVersion +
+      content.slice(versionToken.offset + versionToken.text.length)
+    );
+  }
+
+  // go.mod files of Go 1.17 and later keep indirect requirements in their own block
+  const indirectBlock = file.statements.findLast(
+    (statement): statement is GoModBlock =>
+      statement.type === 'block' &&
+      statement.verb === 'require' &&
+      statement.directives.length > 0 &&
+      statement.directives.every(isIndirect),
+  );
+  const lastArg = indirectBlock?.directives.at(-1)?.args[0];
+  if (lastArg) {
+    const lineStart = content.lastIndexOf('\n', lastArg.offset) + 1;
+    const indent = content.slice(lineStart, lastArg.offset);
+    let lineEnd = content.indexOf('\n', lastArg.offset);
+    if (lineEnd === -1) {
+      lineEnd = content.length;
+    }
+    return `${content.slice(0, lineEnd)}\n${indent}${depName} ${newVersion} // indirect${content.slice(lineEnd)}`;
+  }
+
+  const separator = content.endsWith('\n') ? '\n' : '\n\n';
+  return `${content}${separator}require ${depName} ${newVersion} // indirect\n`;
+}
+
+async function getBuildList(
+  packageFile: string,
+  content: string,
+  modFileFlag: string,
+  execOptions: ExecOptions,
+): Promise<Map<string, string>> {
+  await writeLocalFile(packageFile, content);
+  const { stdout } = await gitExec(`go list${modFileFlag} -m all`, execOptions);
+  return parseBuildList(stdout);
+}
+
+/**
+ * Fixes a transitive dependency by requiring its fixed version, so that minimal version selection picks it.
+ *
+ * The `go` command is used to check that the build list reaches the fixed version.
+ * If it doesn't, or other modules would be upgraded without `allowParentUpdates`, the modules requiring the dependency are logged, as a newer version of one of them is needed instead.
+ *
+ * @see https://go.dev/ref/mod#minimal-version-selection
+ */
+export async function updateLockedDependency(
+  config: UpdateLockedConfig,
+): Promise<UpdateLockedResult> {
+  const {
+    depName,
+    currentVersion,
+    newVersion,
+    packageFile,
+    packageFileContent,
+    lockFile,
+    lockFileContent,
+    allowParentUpdates = true,
+  } = config;
+  logger.debug(
+    `gomod.updateLockedDependency: ${depName}@${currentVersion} -> ${newVersion} [${lockFile}]`,
+  );
+  if (!packageFileContent || !semver.valid(newVersion)) {
+    return { status: 'update-failed' };
+  }
+
+  const directives = getDirectives(parseGoMod(packageFileContent));
+  if (
+    directives.some(
+      ({ verb, args }) => verb === 'replace' && args[0]?.value === depName,
+    )
+  ) {
+    logger.debug(`${depName} is replaced, so its requirement is not used`);
+    return { status: 'update-failed' };
+  }
+  const requiredVersion = directives.find(
+    ({ verb, args }) => verb === 'require' && args[0]?.value === depName,
+  )?.args[1]?.value;
+  if (
+    requiredVersion &&
+    semver.valid(requiredVersion) &&
+    semver.gte(requiredVersion, newVersion)
+  ) {
+    return { status: 'already-updated' };
+  }
+
+  const goModFileBaseName = upath.basename(packageFile);
+  const modFileFlag =
+    goModFileBaseName === 'go.mod'
+      ? ''
+      : ` -modfile=${quote(goModFileBaseName)}`;
+  let parents: string[] = [];
+  try {
+    const env = getEnv();
+    const execOptions: ExecOptions = {
+      cwdFile: packageFile,
+      extraEnv: {
+        GOPATH: await ensureCacheDir('go'),
+        GOPROXY: env.GOPROXY,
+        GOPRIVATE: env.GOPRIVATE,
+        GONOPROXY: env.GONOPROXY,
+        GONOSUMDB: env.GONOSUMDB,
+        GOSUMDB: env.GOSUMDB,
+        GOINSECURE: env.GOINSECURE,
+        // lets the go command add the go.sum lines it needs to load the graph
+        GOFLAGS: '-mod=mod -modcacherw',
+      },
+      docker: {},
+      toolConstraints: [
+        {
+          toolName: 'golang',
+          constraint: deriveGoToolchainConstraints({}, packageFileContent),
+        },
+      ],
+    };
+
+    const buildList = await getBuildList(
+      packageFile,
+      packageFileContent,
+      modFileFlag,
+      execOptions,
+    );
+    const selectedVersion = buildList.get(depName);
+    if (!selectedVersion) {
+      logger.debug(`${depName} is not in the build list of ${packageFile}`);
+      return { status: 'update-failed' };
+    }
+    if (
+      semver.valid(selectedVersion) &&
+      semver.gte(selectedVersion, newVersion)
+    ) {
+      logger.debug(`${depName}@${selectedVersion} is already selected`);
+      return { status: 'already-updated' };
+    }
+    const { stdout: graph } = await gitExec(
+      `go mod graph${modFileFlag}`,
+      execOptions,
+    );
+    parents = getRequiringModules(graph, depName, selectedVersion);
+
+    const newPackageFileContent = updateRequire(
+      packageFileContent,
+      depName,
+      newVersion,
+    );
+    const newBuildList = await getBuildList(
+      packageFile,
+      newPackageFileContent,
+      modFileFlag,
+      execOptions,
+    );
+    const newSelectedVersion = newBuildList.get(depName);
+    if (!newSelectedVersion || semver.lt(newSelectedVersion, newVersion)) {
+      logger.info(
+        { depName, newVersion, newSelectedVersion, parents },
+        'Requiring the fixed version did not select it, a newer version of a parent module is needed instead',
+      );
+      return { status: 'update-failed' };
+    }
+    const upgradedModules = [...newBuildList].filter(
+      ([module, version]) =>
+        module !== depName && buildList.get(module) !== version,
+    );
+    if (upgradedModules.length && !allowParentUpdates) {
+      logger.info(
+        { depName, newVersion, upgradedModules, parents },
+        'Requiring the fixed version upgrades other modules, a newer version of a parent module is needed instead',
+      );
+      return { status: 'update-failed' };
+    }
+
+    return {
+      status: 'updated',
+      files: { [packageFile]: newPackageFileContent },
+    };
+  } catch (err) {
+    if (err.message === TEMPORARY_ERROR) {
+      throw err;
+    }
+    logger.debug(
+      { err, depName, newVersion, parents },
+      'gomod.updateLockedDependency() error',
+    );
+    return { status: 'update-failed' };
+  } finally {
+    // go.sum is completed by updateArtifacts later, from the original content
+    await writeLocalFile(packageFile, packageFileContent);
+    if (lockFileContent) {
+      await writeLocalFile(lockFile, lockFileContent);
+    }
+  }
+}
@@ -612,11 +612,100 @@ describe('modules/manager/gomod/update', () => {
       expect(res).toContain('k8s.io/client-go => k8s.io/client-go v0.22.0');
     });
 
-    it('should return null for replacement', () => {
+    it('preserves comments and line endings', () => {
+      const fileContent =
+        'go 1.23 // minimum\r\nrequire (\r\n\t"example.com/foo" v1.0.0 // pinned\r\n)\r\n';
+      const upgrade = {
+        depName: 'example.com/foo',
+        managerData: { lineNumber: 2, multiLine: true },
+        newValue: 'v1.1.0',
+        depType: 'require',
+      };
+      const res = updateDependency({
+        fileContent,
+        packageFile: 'go.mod',
+        upgrade,
+      });
+      expect(res).toBe(
+        'go 1.23 // minimum\r\nrequire (\r\n\t"example.com/foo" v1.1.0 // pinned\r\n)\r\n',
+      );
+    });
+
+    it('replaces module path and version of a require line', () => {
+      const upgrade = {
+        depName: 'github.com/pkg/errors',
+        managerData: { lineNumber: 2 },
+        currentValue: 'v0.7.0',
+        newName: 'github.com/go-errors/errors',
+        newValue: 'v1.5.1',
+        updateType: 'replacement' as UpdateType,
+        depType: 'require',
+      };
       const res = updateDependency({
-        fileContent: '',
+        fileContent: gomod1,
         packageFile: 'go.mod',
-        upgrade: { updateType: 'replacement' },
+        upgrade,
+      });
+      expect(res).toBe(
+        gomod1.replace(
+          'require github.com/pkg/errors v0.7.0',
+          'require github.com/go-errors/errors v1.5.1',
+        ),
+      );
+    });
+
+    it('replaces quoted module path in a require block', () => {
+      const upgrade = {
+        depName: 'gopkg.in/src-d/go-billy.v4',
+        managerData: { lineNumber: 57, multiLine: true },
+        currentValue: 'v4.2.0',
+        newName: 'github.com/go-git/go-billy/v5',
+        newValue: 'v5.6.2',
+        updateType: 'replacement' as UpdateType,
+        depType: 'require',
+      };
+      const res = updateDependency({
+        fileContent: gomod2,
+        packageFile: 'go.mod',
+        upgrade,
+      });
+      expect(res).toContain('"github.com/go-git/go-billy/v5" v5.6.2');
+      expect(res).not.toContain('gopkg.in/src-d/go-billy.v4');
+    });
+
+    it('replaces the target of a replace directive', () => {
+      const upgrade = {
+        depName: 'github.com/pravesht/gocql',
+        managerData: { lineNumber: 11 },
+        currentValue: 'v0.0.0',
+        newName: 'github.com/gocql/gocql',
+        newValue: 'v1.7.0',
+        updateType: 'replacement' as UpdateType,
+        depType: 'replace',
+      };
+      const res = updateDependency({
+        fileContent: gomod1,
+        packageFile: 'go.mod',
+        upgrade,
+      });
+      expect(res).toContain(
+        'replace golang.org/x/foo => github.com/gocql/gocql v1.7.0',
+      );
+      expect(res).toContain('require golang.org/x/foo v1.0.0');
+    });
+
+    it('returns null for replacement without new value', () => {
+      const upgrade = {
+        depName: 'github.com/pkg/errors',
+        managerData: { lineNumber: 2 },
+        newName: 'github.com/go-errors/errors',
+        updateType: 'replacement' as UpdateType,
+        depType: 'require',
+      };
+      const res = updateDependency({
+        fileContent: gomod1,
+        packageFile: 'go.mod',
+        upgrade,
       });
       expect(res).toBeNull();
     });
@@ -1,7 +1,10 @@
 // TODO: types (#22198)
 import { logger } from '../../../logger/index.ts';
-import { newlineRegex, regEx } from '../../../util/regex.ts';
-import type { UpdateDependencyConfig } from '../types.ts';
+import { regEx } from '../../../util/regex.ts';
+import type { UpdateDependencyConfig, Upgrade } from '../types.ts';
+import { getNewMajorModulePath } from './import-paths.ts';
+import { getDirectives, isIndirect, parseGoMod } from './parser.ts';
+import type { GoModDirective, GoModToken } from './types.ts';
 
 function getNameWithNoVersion(name: string): string {
   // remove version suffixes like /v1 or /v2
@@ -13,6 +16,118 @@ function getNameWithNoVersion(name: string): string {
   return nameNoVersion;
 }
 
+const directiveDepTypes: Record<string, string[]> = {
+  go: ['golang'],
+  toolchain: ['toolchain'],
+  require: ['require', 'indirect'],
+  replace: ['replace', 'indirect'],
+};
+
+function getArrowIndex(directive: GoModDirective): number {
+  return directive.args.findIndex(({ type }) => type === 'arrow');
+}
+
+/**
+ * Returns the module path tokens of a directive which may need to be renamed
+ */
+function getModulePaths(directive: GoModDirective): GoModToken[] {
+  const { verb, args } = directive;
+  if (verb === 'require') {
+    return args.slice(0, 1);
+  }
+  if (verb === 'replace') {
+    return [args[0], args[getArrowIndex(directive) + 1]].filter(Boolean);
+  }
+  return [];
+}
+
+function getVersionToken(
+  directive: GoModDirective,
+  depType: string | undefined,
+): GoModToken | undefined {
+  const { verb, args } = directive;
+  if (depType && !directiveDepTypes[verb]?.includes(depType)) {
+    return undefined;
+  }
+  switch (verb) {
+    case 'go':
+    case 'toolchain':
+      return args[0];
+    case 'require':
+      return args[1];
+    case 'replace':
+      return args[getArrowIndex(directive) + 2];
+    default:
+      return undefined;
+  }
+}
+
+function getMajorUpdatePath(
+  path: string,
+  currentName: string,
+  upgrade: Upgrade,
+): string {
+  if (path !== currentName) {
+    return path;
+  }
+  if (
+    currentName.startsWith('gopkg.in/') ||
+    (upgrade.newMajor! > 1 && !upgrade.newValue!.endsWith('+incompatible'))
+  ) {
+    return getNewMajorModulePath(path, upgrade.newMajor!);
+  }
+  return path;
+}
+
+interface GoModEdit {
+  token: GoModToken;
+  value: string;
+}
+
+function applyEdits(content: string, edits: GoModEdit[]): string {
+  let result = content;
+  // Apply from the end of the file so that earlier offsets remain valid
+  const sortedEdits = [...edits].sort(
+    (a, b) => b.token.offset - a.token.offset,
+  );
+  for (const { token, value } of sortedEdits) {
+    result =
+      result.slice(0, token.offset) +
+      value +
+      result.slice(token.offset + token.text.length);
+  }
+  return result;
+}
+
+function quoteLike(token: GoModToken, value: string): string {
+  return token.type === 'string' ? JSON.stringify(value) : value;
+}
+
+function replaceDependency(
+  fileContent: string,
+  directive: GoModDirective,
+  versionToken: GoModToken,
+  upgrade: Upgrade,
+): string | null {
+  const { depName, newName = depName, newValue } = upgrade;
+  const pathToken =
+    directive.verb === 'replace'
+      ? directive.args[getArrowIndex(directive) + 1]
+      : directive.args[0];
+  if (!newName || !newValue || pathToken.value !== depName) {
+    logger.debug(
+      { depName, newName, newValue },
+      'gomod: cannot apply replacement update',
+    );
+    return null;
+  }
+  logger.debug(`gomod: replacing ${depName} with ${newName}@${newValue}`);
+  return applyEdits(fileContent, [
+    { token: pathToken, value: quoteLike(pathToken, newName) },
+    { token: versionToken, value: quoteLike(versionToken, newValue) },
+  ]);
+}
+
 export function updateDependency({
   fileContent,
   upgrade,
@@ -21,64 +136,49 @@ export function updateDependency({
     logger.debug(`gomod.updateDependency: ${upgrade.newValue}`);
     const { depType, updateType } = upgrade;
     const currentName = upgrade.depName;
-    if (updateType === 'replacement') {
-      logger.warn('gomod manager does not support replacement updates yet');
-      return null;
-    }
     /* v8 ignore next -- should never happen */
     if (!currentName || !upgrade.managerData) {
       return null;
     }
     const currentNameNoVersion = getNameWithNoVersion(currentName);
-    const lines = fileContent.split(newlineRegex);
+    const { lineNumber, multiLine } = upgrade.managerData;
+    const directive = getDirectives(parseGoMod(fileContent)).find(
+      (entry) => entry.lineNumber === lineNumber,
+    );
     /* v8 ignore next -- hard to test */
-    if (lines.length <= upgrade.managerData.lineNumber) {
+    if (!directive) {
       logger.warn('go.mod current line no longer exists after update');
       return null;
     }
-    const lineToChange = lines[upgrade.managerData.lineNumber];
-    logger.trace({ upgrade, lineToChange }, 'go.mod current line');
+    logger.trace({ upgrade, directive }, 'go.mod current directive');
+    const isGoDirective =
+      directive.verb === 'go' || directive.verb === 'toolchain';
+    const modulePaths = getModulePaths(directive);
     if (
-      !lineToChange.includes(currentNameNoVersion) &&
-      !lineToChange.includes('rethinkdb/rethinkdb-go.v5')
+      !isGoDirective &&
+      !modulePaths.some(
+        ({ value }) =>
+          value.includes(currentNameNoVersion) ||
+          value.includes('rethinkdb/rethinkdb-go.v5'),
+      )
     ) {
       logger.debug(
-        { lineToChange, depName: currentName },
+        { lineNumber, depName: currentName },
         "go.mod current line doesn't contain dependency",
       );
       return null;
     }
-    let updateLineExp: RegExp | undefined;
-
-    if (depType === 'golang' || depType === 'toolchain') {
-      updateLineExp = regEx(
-        /(?<depPart>(?:toolchain )?go)(?<divider>\s*)([^\s]+|[\w]+)/,
-      );
-    }
-    if (depType === 'replace') {
-      if (upgrade.managerData.multiLine) {
-        updateLineExp = regEx(
-          /^(?<depPart>\s+[^\s]+[\s]+[=][>]+\s+)(?<divider>[^\s]+\s+)[^\s]+/,
-        );
-      } else {
-        updateLineExp = regEx(
-          /^(?<depPart>replace\s+[^\s]+[\s]+[=][>]+\s+)(?<divider>[^\s]+\s+)[^\s]+/,
-        );
-      }
-    } else if (depType === 'require' || depType === 'indirect') {
-      if (upgrade.managerData.multiLine) {
-        updateLineExp = regEx(/^(?<depPart>\s+[^\s]+)(?<divider>\s+)[^\s]+/);
-      } else {
-        updateLineExp = regEx(
-          /^(?<depPart>require\s+[^\s]+)(?<divider>\s+)[^\s]+/,
-        );
-      }
-    }
-    if (updateLineExp && !updateLineExp.test(lineToChange)) {
+    const versionToken = getVersionToken(directive, depType);
+    if (!versionToken || !!multiLine !== directive.inBlock) {
       logger.debug('No line found to update');
       return null;
     }
-    let newLine: string;
+
+    if (updateType === 'replacement') {
+      return replaceDependency(fileContent, directive, versionToken, upgrade);
+    }
+
+    let newVersion: string;
     if (upgrade.updateType === 'digest') {
       // Since the 2024 goproxy datasource changes, newValue and newDigest are
       // both extracted from the same proxy version string and always reference
@@ -91,14 +191,10 @@ export function updateDependency({
         upgrade.newValue !== upgrade.currentValue
       ) {
         logger.debug(
-          { depName: currentName, lineToChange, newValue: upgrade.newValue },
+          { depName: currentName, lineNumber, newValue: upgrade.newValue },
           'gomod: updating pseudo-version digest',
         );
-        newLine = lineToChange.replace(
-          // TODO: can be undefined? (#22198)
-          updateLineExp!,
-          `$<depPart>$<divider>${upgrade.newValue}`,
-        );
+        newVersion = upgrade.newValue;
       } else {
         // Fallback for private modules where the proxy could not resolve a new
         // pseudo-version, or non-pseudo-version digest updates.
@@ -108,82 +204,52 @@ export function updateDependency({
           0,
           upgrade.currentDigest!.length,
         );
-        if (lineToChange.includes(newDigestRightSized)) {
+        if (versionToken.value.includes(newDigestRightSized)) {
           return fileContent;
         }
         logger.debug(
-          { depName: currentName, lineToChange, newDigestRightSized },
+          { depName: currentName, lineNumber, newDigestRightSized },
           'gomod: need to update digest',
         );
-        newLine = lineToChange.replace(
-          // TODO: can be undefined? (#22198)
-          updateLineExp!,
-          `$<depPart>$<divider>${newDigestRightSized}`,
-        );
+        newVersion = newDigestRightSized;
       }
+    } else if (directive.verb === 'toolchain') {
+      newVersion = `go${upgrade.newValue}`;
     } else {
-      newLine = lineToChange.replace(
-        // TODO: can be undefined? (#22198)
-        updateLineExp!,
-        `$<depPart>$<divider>${upgrade.newValue}`,
-      );
-    }
-    if (upgrade.updateType === 'major') {
-      logger.debug(`gomod: major update for ${currentName}`);
-      if (currentName.startsWith('gopkg.in/')) {
-        const oldV = currentName.split('.').pop();
-        newLine = newLine.replace(`.${oldV}`, `.v${upgrade.newMajor}`);
-        // Package renames - I couldn't think of a better place to do this
-        newLine = newLine.replace(
-          'gorethink/gorethink.v5',
-          'rethinkdb/rethinkdb-go.v5',
-        );
-      } else if (
-        upgrade.newMajor! > 1 &&
-        !newLine.includes(`/v${upgrade.newMajor}`) &&
-        !upgrade.newValue!.endsWith('+incompatible')
-      ) {
-        if (currentName === currentNameNoVersion) {
-          // If package currently has no version, pin to latest one.
-          newLine = newLine.replaceAll(
-            currentName,
-            `${currentName}/v${upgrade.newMajor}`,
-          );
-        } else {
-          // Replace version
-          const [oldV] = upgrade.currentValue!.split('.');
-          newLine = newLine.replace(
-            regEx(`/${oldV}(\\s+)`, undefined, false),
-            `/v${upgrade.newMajor}$1`,
-          );
-        }
-      }
+      newVersion = upgrade.newValue!;
     }
     if (
-      lineToChange.endsWith('+incompatible') &&
-      !upgrade.newValue?.endsWith('+incompatible')
+      versionToken.value.endsWith('+incompatible') &&
+      !upgrade.newValue?.endsWith('+incompatible') &&
+      !(upgrade.updateType === 'major' && upgrade.newMajor! >= 2)
     ) {
-      let toAdd = '+incompatible';
+      newVersion += '+incompatible';
+    }
 
-      if (upgrade.updateType === 'major' && upgrade.newMajor! >= 2) {
-        toAdd = '';
+    const edits: GoModEdit[] = [
+      { token: versionToken, value: quoteLike(versionToken, newVersion) },
+    ];
+    if (upgrade.updateType === 'major') {
+      logger.debug(`gomod: major update for ${currentName}`);
+      for (const token of modulePaths) {
+        const newPath = getMajorUpdatePath(token.value, currentName, upgrade);
+        edits.push({ token, value: quoteLike(token, newPath) });
       }
-      newLine += toAdd;
     }
-    if (newLine === lineToChange) {
+    if (edits.every(({ token, value }) => token.text === value)) {
       logger.debug('No changes necessary');
       return fileContent;
     }
 
-    if (depType === 'indirect') {
-      newLine = newLine.replace(
-        regEx(/\s*(?:\/\/\s*indirect(?:\s*;)?\s*)*$/),
-        ' // indirect',
-      );
+    if (
+      depType === 'indirect' &&
+      !isIndirect(directive) &&
+      directive.comments.suffix === undefined
+    ) {
+      edits[0].value += ' // indirect';
     }
 
-    lines[upgrade.managerData.lineNumber] = newLine;
-    return lines.join('\n');
+    return applyEdits(fileContent, edits);
   } catch (err) {
     logger.debug({ err }, 'Error setting new go.mod version');
     return null;
@@ -0,0 +1,203 @@
+import { codeBlock } from 'common-tags';
+import upath from 'upath';
+import { envMock, mockExecAll } from '~test/exec-util.ts';
+import { env, fs, git, partial } from '~test/util.ts';
+import { GlobalConfig } from '../../../config/global.ts';
+import type {
+  InternalGlobalConfigOptions,
+  RepoGlobalConfig,
+} from '../../../config/types.ts';
+import { TEMPORARY_ERROR } from '../../../constants/error-messages.ts';
+import * as memCache from '../../../util/cache/memory/index.ts';
+import { setUserEnv } from '../../../util/env.ts';
+import type { StatusResult } from '../../../util/git/types.ts';
+import * as gowork from './index.ts';
+
+vi.mock('../../../util/exec/env.ts');
+vi.mock('../../../util/fs/index.ts');
+
+process.env.CONTAINERBASE = 'true';
+
+const goWork = codeBlock`
+  go 1.22
+
+  toolchain go1.22.4
+
+  use (
+  	./api
+  	./cmd
+  )
+`;
+
+const adminConfig: RepoGlobalConfig & InternalGlobalConfigOptions = {
+  // `join` fixes Windows CI
+  localDir: upath.join('/tmp/github/some/repo'),
+  cacheDir: upath.join('/tmp/renovate/cache'),
+  containerbaseDir: upath.join('/tmp/renovate/cache/containerbase'),
+  binarySource: 'global',
+};
+
+describe('modules/manager/gowork/artifacts', () => {
+  beforeEach(() => {
+    env.getChildProcessEnv.mockReturnValue(envMock.basic);
+    GlobalConfig.set(adminConfig);
+  });
+
+  afterEach(() => {
+    GlobalConfig.reset();
+  });
+
+  it('returns null if nothing changed', async () => {
+    const execSnapshots = mockExecAll();
+    fs.readLocalFile.mockResolvedValueOnce(goWork);
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({ modified: [], not_added: [] }),
+    );
+
+    expect(
+      await gowork.updateArtifacts({
+        packageFileName: 'go.work',
+        updatedDeps: [],
+        newPackageFileContent: goWork,
+        config: {},
+      }),
+    ).toBeNull();
+    expect(execSnapshots).toMatchObject([
+      {
+        cmd: 'go work sync',
+        options: {
+          cwd: '/tmp/github/some/repo',
+          env: { GOFLAGS: '-modcacherw' },
+        },
+      },
+    ]);
+  });
+
+  it('ignores GOAUTH and NETRC from the repository env', async () => {
+    memCache.init();
+    setUserEnv({ GOAUTH: 'auth-helper', NETRC: '/repo/.netrc' });
+    const execSnapshots = mockExecAll();
+    fs.readLocalFile.mockResolvedValueOnce(goWork);
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({ modified: [], not_added: [] }),
+    );
+
+    expect(
+      await gowork.updateArtifacts({
+        packageFileName: 'go.work',
+        updatedDeps: [],
+        newPackageFileContent: goWork,
+        config: {},
+      }),
+    ).toBeNull();
+    expect(execSnapshots).toHaveLength(1);
+    expect(execSnapshots[0].options?.env).not.toHaveProperty('GOAUTH');
+    expect(execSnapshots[0].options?.env).not.toHaveProperty('NETRC');
+
+    memCache.reset();
+  });
+
+  it('returns go.work.sum and synced module files', async () => {
+    const execSnapshots = mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({
+        modified: ['go.work.sum', 'api/go.mod', 'api/go.sum'],
+        not_added: [],
+      }),
+    );
+    fs.readLocalFile.mockResolvedValueOnce('New go.work.sum');
+    fs.readLocalFile.mockResolvedValueOnce('New api/go.mod');
+    fs.readLocalFile.mockResolvedValueOnce('New api/go.sum');
+    fs.readLocalFile.mockResolvedValueOnce(goWork);
+
+    expect(
+      await gowork.updateArtifacts({
+        packageFileName: 'go.work',
+        updatedDeps: [{ depName: 'go', depType: 'toolchain' }],
+        newPackageFileContent: goWork,
+        config: {},
+      }),
+    ).toEqual([
+      {
+        file: {
+          type: 'addition',
+          path: 'go.work.sum',
+          contents: 'New go.work.sum',
+        },
+      },
+      {
+        file: {
+          type: 'addition',
+          path: 'api/go.mod',
+          contents: 'New api/go.mod',
+        },
+      },
+      {
+        file: {
+          type: 'addition',
+          path: 'api/go.sum',
+          contents: 'New api/go.sum',
+        },
+      },
+    ]);
+    expect(execSnapshots).toMatchObject([{ cmd: 'go work sync' }]);
+  });
+
+  it('returns rewritten go.work', async () => {
+    mockExecAll();
+    git.getRepoStatus.mockResolvedValueOnce(
+      partial<StatusResult>({ modified: ['go.work'], not_added: [] }),
+    );
+    fs.readLocalFile.mockResolvedValueOnce('go 1.22.4\n');
+
+    expect(
+      await gowork.updateArtifacts({
+        packageFileName: 'go.work',
+        updatedDeps: [],
+        newPackageFileContent: goWork,
+        config: {},
+      }),
+    ).toEqual([
+      {
+        file: {
+          type: 'addition',
+          path: 'go.work',
+          contents: 'go 1.22.4\n',
+        },
+      },
+    ]);
+  });
+
+  it('returns artifact error', async () => {
+    mockExecAll(new Error('go: some error'));
+
+    expect(
+      await gowork.updateArtifacts({
+        packageFileName: 'go.work',
+        updatedDeps: [],
+        newPackageFileContent: goWork,
+        config: {},
+      }),
+    ).toEqual([
+      {
+        artifactError: {
+          fileName: 'go.work.sum',
+          stderr: 'go: some error',
+        },
+      },
+    ]);
+  });
+
+  it('rethrows temporary error', async () => {
+    mockExecAll(new Error(TEMPORARY_ERROR));
+
+    await expect(
+      gowork.updateArtifacts({
+        packageFileName: 'go.work',
+        updatedDeps: [],
+        newPackageFileContent: goWork,
+        config: {},
+      }),
+    ).rejects.toThrow(TEMPORARY_ERROR);
+  });
+});
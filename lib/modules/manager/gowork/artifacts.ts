@@ -0,0 +1,149 @@
+import upath from 'upath';
+import { GlobalConfig } from '../../../config/global.ts';
+import { TEMPORARY_ERROR } from '../../../constants/error-messages.ts';
+import { logger } from '../../../logger/index.ts';
+import { getEnv } from '../../../util/env.ts';
+import type { ExecOptions } from '../../../util/exec/types.ts';
+import {
+  ensureCacheDir,
+  readLocalFile,
+  writeLocalFile,
+} from '../../../util/fs/index.ts';
+import { withGitEnvironment } from '../../../util/git/exec.ts';
+import { getRepoStatus } from '../../../util/git/index.ts';
+import { getGoAuthEnv } from '../../datasource/go/goauth.ts';
+import { deriveGoToolchainConstraints } from '../gomod/artifacts.ts';
+import {
+  isGoToolchainUpdate,
+  syncGoToolchainFiles,
+} from '../gomod/toolchain.ts';
+import type { UpdateArtifact, UpdateArtifactsResult } from '../types.ts';
+import { extractPackageFile } from './extract.ts';
+
+const gitExec = withGitEnvironment(['go']);
+
+function getWorkspaceModuleFiles(
+  goWorkFileName: string,
+  goWorkContent: string,
+): string[] {
+  const goWorkDir = upath.dirname(goWorkFileName);
+  const deps = extractPackageFile(goWorkContent)?.deps ?? [];
+  return deps
+    .filter((dep) => dep.depType === 'use' && dep.depName)
+    .flatMap((dep) => {
+      const moduleDir = upath.join(goWorkDir, dep.depName!);
+      return [
+        upath.join(moduleDir, 'go.mod'),
+        upath.join(moduleDir, 'go.sum'),
+      ];
+    });
+}
+
+export async function updateArtifacts({
+  packageFileName: goWorkFileName,
+  updatedDeps,
+  newPackageFileContent: newGoWorkContent,
+  config,
+}: UpdateArtifact): Promise<UpdateArtifactsResult[] | null> {
+  logger.debug(`gowork.updateArtifacts(${goWorkFileName})`);
+
+  const goWorkSumFileName = upath.join(
+    upath.dirname(goWorkFileName),
+    'go.work.sum',
+  );
+
+  let syncedGoWorkContent = newGoWorkContent;
+  let toolchainSyncResults: UpdateArtifactsResult[] = [];
+  if (
+    config.postUpdateOptions?.includes('goToolchainSync') &&
+    isGoToolchainUpdate(updatedDeps)
+  ) {
+    ({ content: syncedGoWorkContent, results: toolchainSyncResults } =
+      await syncGoToolchainFiles(goWorkFileName, newGoWorkContent));
+  }
+  const goConstraints = deriveGoToolchainConstraints(
+    config,
+    syncedGoWorkContent,
+  );
+
+  try {
+    await writeLocalFile(goWorkFileName, syncedGoWorkContent);
+
+    const env = getEnv();
+    const goAuthEnv = getGoAuthEnv();
+    const execOptions: ExecOptions = {
+      cwdFile: goWorkFileName,
+      extraEnv: {
+        GOPATH: await ensureCacheDir('go'),
+        GOPROXY: env.GOPROXY,
+        GOPRIVATE: env.GOPRIVATE,
+        GONOPROXY: env.GONOPROXY,
+        GONOSUMDB: env.GONOSUMDB,
+        GOSUMDB: env.GOSUMDB,
+        GOINSECURE: env.GOINSECURE,
+        GOAUTH: goAuthEnv.GOAUTH,
+        NETRC: goAuthEnv.NETRC,
+        GOFLAGS: '-modcacherw',
+        CGO_ENABLED: GlobalConfig.get('binarySource') === 'docker' ? '0' : null,
+      },
+      // overrides the `env` config of the repository, as GOAUTH runs commands
+      env: goAuthEnv,
+      docker: {},
+      toolConstraints: [
+        {
+          toolName: 'golang',
+          constraint: goConstraints,
+        },
+      ],
+    };
+
+    await gitExec('go work sync', execOptions);
+
+    const status = await getRepoStatus();
+    const candidates = [
+      goWorkSumFileName,
+      ...getWorkspaceModuleFiles(goWorkFileName, newGoWorkContent),
+    ];
+
+    const res: UpdateArtifactsResult[] = [...toolchainSyncResults];
+    for (const f of candidates) {
+      if (status.modified.includes(f) || status.not_added.includes(f)) {
+        logger.debug(`Returning updated ${f}`);
+        res.push({
+          file: {
+            type: 'addition',
+            path: f,
+            contents: await readLocalFile(f),
+          },
+        });
+      }
+    }
+
+    const finalGoWorkContent = await readLocalFile(goWorkFileName, 'utf8');
+    if (finalGoWorkContent && finalGoWorkContent !== newGoWorkContent) {
+      logger.debug('Found updated go.work after go work sync');
+      res.push({
+        file: {
+          type: 'addition',
+          path: goWorkFileName,
+          contents: finalGoWorkContent,
+        },
+      });
+    }
+
+    return res.length ? res : null;
+  } catch (err) {
+    if (err.message === TEMPORARY_ERROR) {
+      throw err;
+    }
+    logger.debug({ err }, 'Failed to run go work sync');
+    return [
+      {
+        artifactError: {
+          fileName: goWorkSumFileName,
+          stderr: err.message,
+        },
+      },
+    ];
+  }
+}
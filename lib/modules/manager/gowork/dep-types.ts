@@ -0,0 +1,22 @@
+import type { DepTypeMetadata } from '../types.ts';
+
+export const knownDepTypes = [
+  {
+    depType: 'golang',
+    description:
+      'The `go` directive specifying the minimum version of Go required by the workspace',
+  },
+  {
+    depType: 'toolchain',
+    description:
+      'The `toolchain` directive specifying the Go toolchain version',
+  },
+  {
+    depType: 'replace',
+    description: 'A module replacement directive',
+  },
+  {
+    depType: 'use',
+    description: 'A local module directory included in the workspace',
+  },
+] as const satisfies readonly DepTypeMetadata[];
@@ -0,0 +1,117 @@
+import { codeBlock } from 'common-tags';
+import { extractPackageFile } from './index.ts';
+
+describe('modules/manager/gowork/extract', () => {
+  describe('extractPackageFile()', () => {
+    it('returns null for empty', () => {
+      expect(extractPackageFile('nothing here')).toBeNull();
+    });
+
+    it('extracts go, toolchain, use and replace directives', () => {
+      const goWork = codeBlock`
+        go 1.22.1
+
+        toolchain go1.22.4
+
+        use ./cmd/tool // the cli
+
+        use (
+        	.
+        	// ./disabled
+        	"./lib/with space"
+        	../shared
+        )
+
+        godebug (
+        	default=go1.21
+        )
+
+        replace github.com/pkg/errors => github.com/pkg/errors v0.9.1
+
+        replace (
+        	golang.org/x/net => golang.org/x/net v0.21.0
+        	example.com/local => ../local
+        )
+      `;
+      const res = extractPackageFile(goWork);
+      expect(res).toEqual({
+        deps: [
+          {
+            datasource: 'golang-version',
+            versioning: 'go-mod-directive',
+            depType: 'golang',
+            depName: 'go',
+            currentValue: '1.22.1',
+            commitMessageTopic: 'go module directive',
+            managerData: { lineNumber: 0 },
+          },
+          {
+            datasource: 'golang-version',
+            depType: 'toolchain',
+            depName: 'go',
+            currentValue: '1.22.4',
+            commitMessageTopic: 'go toolchain directive',
+            managerData: { lineNumber: 2 },
+          },
+          {
+            depName: './cmd/tool',
+            depType: 'use',
+            skipReason: 'local-dependency',
+          },
+          { depName: '.', depType: 'use', skipReason: 'local-dependency' },
+          {
+            depName: './lib/with space',
+            depType: 'use',
+            skipReason: 'local-dependency',
+          },
+          {
+            depName: '../shared',
+            depType: 'use',
+            skipReason: 'local-dependency',
+          },
+          {
+            datasource: 'go',
+            depType: 'replace',
+            depName: 'github.com/pkg/errors',
+            currentValue: 'v0.9.1',
+            managerData: { lineNumber: 17 },
+          },
+          {
+            datasource: 'go',
+            depType: 'replace',
+            depName: 'golang.org/x/net',
+            currentValue: 'v0.21.0',
+            managerData: { lineNumber: 20, multiLine: true },
+          },
+          {
+            datasource: 'go',
+            depType: 'replace',
+            depName: '../local',
+            skipReason: 'local-dependency',
+            managerData: { lineNumber: 21, multiLine: true },
+          },
+        ],
+        extractedConstraints: {
+          '%goMod': '~1.22.x',
+          golang: '1.22.4',
+        },
+        constraintsVersioning: {
+          '%goMod': 'semver-coerced',
+        },
+      });
+    });
+
+    it('extracts use directives only', () => {
+      const goWork = codeBlock`
+        use (
+        	./a
+        	./b
+        )
+      `;
+      expect(extractPackageFile(goWork)?.deps).toMatchObject([
+        { depName: './a', depType: 'use' },
+        { depName: './b', depType: 'use' },
+      ]);
+    });
+  });
+});
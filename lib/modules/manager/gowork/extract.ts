@@ -0,0 +1,66 @@
+import { convertGoDirectiveToSemVerRange } from '../gomod/extract.ts';
+import { parseDirective } from '../gomod/line-parser.ts';
+import { getDirectives, parseGoMod } from '../gomod/parser.ts';
+import type { PackageDependency, PackageFileContent } from '../types.ts';
+
+const workDepTypes = new Set(['golang', 'toolchain', 'replace']);
+
+export function extractPackageFile(content: string): PackageFileContent | null {
+  const deps: PackageDependency[] = [];
+
+  for (const directive of getDirectives(parseGoMod(content))) {
+    if (directive.verb === 'use') {
+      const [path] = directive.args;
+      if (path) {
+        deps.push({
+          depName: path.value,
+          depType: 'use',
+          skipReason: 'local-dependency',
+        });
+      }
+      continue;
+    }
+
+    const dep = parseDirective(directive);
+    if (!dep?.depType || !workDepTypes.has(dep.depType)) {
+      continue;
+    }
+
+    dep.managerData ??= {};
+    dep.managerData.lineNumber = directive.lineNumber;
+
+    deps.push(dep);
+  }
+
+  if (!deps.length) {
+    return null;
+  }
+
+  const packageFile: PackageFileContent = {
+    deps,
+  };
+
+  const goDirective = deps.find(
+    (dep) => dep.depName === 'go' && dep.depType === 'golang',
+  );
+  if (goDirective?.currentValue) {
+    const range = convertGoDirectiveToSemVerRange(goDirective.currentValue);
+    if (range.version) {
+      packageFile.extractedConstraints ??= {};
+      packageFile.extractedConstraints['%goMod'] = range.version;
+
+      packageFile.constraintsVersioning ??= {};
+      packageFile.constraintsVersioning['%goMod'] = range.versioning;
+    }
+  }
+
+  const toolchainDirective = deps.find(
+    (dep) => dep.depName === 'go' && dep.depType === 'toolchain',
+  );
+  if (toolchainDirective?.currentValue) {
+    packageFile.extractedConstraints ??= {};
+    packageFile.extractedConstraints.golang = toolchainDirective.currentValue;
+  }
+
+  return packageFile;
+}
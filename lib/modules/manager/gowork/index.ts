@@ -0,0 +1,23 @@
+import type { Category } from '../../../constants/index.ts';
+import { GoDatasource } from '../../datasource/go/index.ts';
+import { GolangVersionDatasource } from '../../datasource/golang-version/index.ts';
+import { updateArtifacts } from './artifacts.ts';
+import { extractPackageFile } from './extract.ts';
+import { updateDependency } from './update.ts';
+
+export { knownDepTypes } from './dep-types.ts';
+export { extractPackageFile, updateArtifacts, updateDependency };
+
+export const displayName = 'Go Workspaces';
+export const url = 'https://go.dev/ref/mod#workspaces';
+export const categories: Category[] = ['golang'];
+
+export const defaultConfig = {
+  managerFilePatterns: ['/(^|/)go\\.work$/'],
+  pinDigests: false,
+};
+
+export const supportedDatasources = [
+  GoDatasource.id,
+  GolangVersionDatasource.id,
+];
@@ -0,0 +1,70 @@
+import { codeBlock } from 'common-tags';
+import { updateDependency } from './index.ts';
+
+const goWork = codeBlock`
+  go 1.22.1
+
+  toolchain go1.22.4
+
+  use ./a
+
+  replace (
+  	golang.org/x/net => golang.org/x/net v0.21.0
+  )
+`;
+
+describe('modules/manager/gowork/update', () => {
+  describe('updateDependency', () => {
+    it('updates the go directive', () => {
+      const res = updateDependency({
+        fileContent: goWork,
+        packageFile: 'go.work',
+        upgrade: {
+          depName: 'go',
+          depType: 'golang',
+          managerData: { lineNumber: 0 },
+          newValue: '1.23.0',
+        },
+      });
+      expect(res).toContain('go 1.23.0\n');
+      expect(res).toContain('toolchain go1.22.4');
+    });
+
+    it('updates the toolchain directive', () => {
+      const res = updateDependency({
+        fileContent: goWork,
+        packageFile: 'go.work',
+        upgrade: {
+          depName: 'go',
+          depType: 'toolchain',
+          managerData: { lineNumber: 2 },
+          newValue: '1.22.5',
+        },
+      });
+      expect(res).toContain('toolchain go1.22.5');
+    });
+
+    it('updates a replace target', () => {
+      const res = updateDependency({
+        fileContent: goWork,
+        packageFile: 'go.work',
+        upgrade: {
+          depName: 'golang.org/x/net',
+          depType: 'replace',
+          managerData: { lineNumber: 7, multiLine: true },
+          newValue: 'v0.22.0',
+        },
+      });
+      expect(res).toContain('golang.org/x/net => golang.org/x/net v0.22.0');
+    });
+
+    it('returns null for use directives', () => {
+      const res = updateDependency({
+        fileContent: goWork,
+        packageFile: 'go.work',
+        upgrade: { depName: './a', depType: 'use', newValue: './b' },
+      });
+      expect(res).toBeNull();
+    });
+  });
+});
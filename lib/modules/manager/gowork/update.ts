@@ -0,0 +1,18 @@
+import { logger } from '../../../logger/index.ts';
+import { updateDependency as updateGoModDependency } from '../gomod/update.ts';
+import type { UpdateDependencyConfig } from '../types.ts';
+
+const updatableDepTypes = new Set(['golang', 'toolchain', 'replace']);
+
+export function updateDependency(
+  config: UpdateDependencyConfig,
+): string | null {
+  const { depName, depType } = config.upgrade;
+  logger.debug(`gowork.updateDependency: ${config.upgrade.newValue}`);
+  if (!depType || !updatableDepTypes.has(depType)) {
+    logger.debug({ depName, depType }, 'go.work dependency is not updatable');
+    return null;
+  }
+  // go, toolchain and replace directives share the go.mod line grammar
+  return updateGoModDependency(config);
+}
@@ -254,6 +254,15 @@ describe('modules/manager/index', () => {
       ).toBe('update-lockfile');
     });
 
+    it('returns replace for gomod despite updateLockedDependency', () => {
+      expect(
+        manager.getRangeStrategy({ manager: 'gomod', rangeStrategy: 'auto' }),
+      ).toBe('replace');
+      expect(
+        manager.getRangeStrategy({ manager: 'gomod', rangeStrategy: 'bump' }),
+      ).toBe('bump');
+    });
+
     afterEach(() => {
       manager.getManagers().delete('dummy');
     });
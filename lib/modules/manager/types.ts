@@ -96,6 +96,8 @@ export interface PackageFile<
 
 export interface LookupUpdate {
   bucket?: string;
+  /** The group for updates released together from the same source repository, used when no `groupName` is configured */
+  sourceGroupName?: string;
   branchName?: string;
   commitMessageAction?: string;
   isBump?: boolean;
@@ -128,6 +130,7 @@ export interface LookupUpdate {
   mergeConfidenceLevel?: MergeConfidence | undefined;
   userStrings?: Record<string, string>;
   checksumUrl?: string;
+  checksums?: Record<string, string>;
   downloadUrl?: string;
   releaseTimestamp?: Timestamp;
   newVersionAgeInDays?: number;
@@ -171,6 +174,12 @@ export interface PackageDependency<
   gitRef?: boolean;
   sourceUrl?: string | null;
   pinDigests?: boolean;
+  /** The branch whose head a dependency pinned to a commit should follow */
+  followBranch?: string;
+  /** Whether `packageName` is a Go package path, whose module is resolved with the module proxy */
+  goPackagePath?: boolean;
+  /** The name the datasource found the package under, for instance the module providing a Go package */
+  lookupName?: string;
   currentRawValue?: string;
   major?: { enabled?: boolean };
   prettyDepType?: string;
@@ -180,6 +189,8 @@ export interface PackageDependency<
   currentDigestShort?: string;
   datasource?: string;
   deprecationMessage?: string;
+  /** Why the release matching `currentVersion` is deprecated, for instance a Go module retraction */
+  currentVersionDeprecationMessage?: string;
   digestOneAndOnly?: boolean;
   /**
    * The digest for this dependency is managed externally (for instance in a lockfile) instead of alongside the package file's version,
@@ -216,6 +227,9 @@ export interface PackageDependency<
 
   mostRecentTimestamp?: Timestamp;
   isAbandoned?: boolean;
+  abandonmentReasons?: string[];
+  /** Whether the current version is out of its maintainers' support window */
+  isUnsupported?: boolean;
   extractedConstraints?: Partial<Record<ConstraintName, string>>;
   /**
    * Whether the package registry has attestation information for the given update.
@@ -1,5 +1,13 @@
+import { codeBlock } from 'common-tags';
+import { fs, git, partial } from '~test/util.ts';
+import { rawExec as _rawExec } from '../../../util/exec/common.ts';
+import type { ExecResult } from '../../../util/exec/types.ts';
 import * as platform from './index.ts';
 
+vi.mock('../../../util/fs/index.ts');
+
+const rawExec = vi.mocked(_rawExec);
+
 describe('modules/platform/local/index', () => {
   describe('initPlatform', () => {
     it('returns input', async () => {
@@ -60,7 +68,7 @@ describe('modules/platform/local/index', () => {
 
   describe('dummy functions', () => {
     it('findIssue', async () => {
-      expect(await platform.findIssue()).toBeNull();
+      expect(await platform.findIssue('title')).toBeNull();
     });
 
     it('getIssueList', async () => {
@@ -80,11 +88,13 @@ describe('modules/platform/local/index', () => {
     });
 
     it('ensureIssueClosing', async () => {
-      expect(await platform.ensureIssueClosing()).toBeUndefined();
+      expect(await platform.ensureIssueClosing('title')).toBeUndefined();
     });
 
     it('ensureIssue', async () => {
-      expect(await platform.ensureIssue()).toBeNull();
+      expect(
+        await platform.ensureIssue({ title: 'title', body: 'body' }),
+      ).toBeNull();
     });
 
     it('massageMarkdown', () => {
@@ -96,7 +106,9 @@ describe('modules/platform/local/index', () => {
     });
 
     it('updatePr', async () => {
-      expect(await platform.updatePr()).toBeUndefined();
+      expect(
+        await platform.updatePr({ number: 1, prTitle: 'title' }),
+      ).toBeUndefined();
     });
 
     it('mergePr', async () => {
@@ -104,19 +116,26 @@ describe('modules/platform/local/index', () => {
     });
 
     it('addReviewers', async () => {
-      expect(await platform.addReviewers()).toBeUndefined();
+      expect(await platform.addReviewers(1, ['user'])).toBeUndefined();
     });
 
     it('addAssignees', async () => {
-      expect(await platform.addAssignees()).toBeUndefined();
+      expect(await platform.addAssignees(1, ['user'])).toBeUndefined();
     });
 
     it('createPr', async () => {
-      expect(await platform.createPr()).toBeNull();
+      expect(
+        await platform.createPr({
+          sourceBranch: 'branch',
+          targetBranch: 'main',
+          prTitle: 'title',
+          prBody: 'body',
+        }),
+      ).toBeNull();
     });
 
     it('deleteLabel', async () => {
-      expect(await platform.deleteLabel()).toBeUndefined();
+      expect(await platform.deleteLabel(1, 'label')).toBeUndefined();
     });
 
     it('setBranchStatus', async () => {
@@ -140,15 +159,252 @@ describe('modules/platform/local/index', () => {
     });
 
     it('getPr', async () => {
-      expect(await platform.getPr()).toBeNull();
+      expect(await platform.getPr(1)).toBeNull();
     });
 
     it('findPr', async () => {
-      expect(await platform.findPr()).toBeNull();
+      expect(await platform.findPr({ branchName: 'branch' })).toBeNull();
     });
 
     it('getBranchPr', async () => {
-      expect(await platform.getBranchPr()).toBeNull();
+      expect(await platform.getBranchPr('branch')).toBeNull();
+    });
+  });
+
+  describe('with localPlatformDir', () => {
+    const state = codeBlock`
+      {
+        "prs": [
+          {
+            "number": 1,
+            "sourceBranch": "renovate/foo-1.x",
+            "targetBranch": "main",
+            "title": "Update foo to v1.1.0",
+            "body": "old body",
+            "state": "closed",
+            "createdAt": "2024-01-01T00:00:00.000Z",
+            "updatedAt": "2024-01-01T00:00:00.000Z"
+          },
+          {
+            "number": 2,
+            "sourceBranch": "renovate/foo-1.x",
+            "targetBranch": "main",
+            "title": "Update foo to v1.2.0",
+            "body": "body",
+            "state": "open",
+            "labels": ["deps"],
+            "createdAt": "2024-01-02T00:00:00.000Z",
+            "updatedAt": "2024-01-02T00:00:00.000Z"
+          }
+        ],
+        "issues": [
+          {
+            "number": 3,
+            "title": "Dependency Dashboard",
+            "body": "dashboard",
+            "state": "open",
+            "createdAt": "2024-01-01T00:00:00.000Z",
+            "lastModified": "2024-01-01T00:00:00.000Z"
+          }
+        ]
+      }
+    `;
+
+    function getWrittenState(): unknown {
+      const [, content] = fs.writeSystemFile.mock.calls.findLast(([path]) =>
+        path.endsWith('/state.json'),
+      )!;
+      return JSON.parse(content as string);
+    }
+
+    beforeEach(async () => {
+      vi.spyOn(process, 'cwd').mockReturnValue('/repo');
+      rawExec.mockResolvedValue(partial<ExecResult>({ stdout: 'main\n' }));
+      await expect(
+        platform.initPlatform({ localPlatformDir: '/out' }),
+      ).resolves.toEqual({
+        endpoint: 'local',
+        persistRepoData: true,
+        requireConfig: 'optional',
+      });
+    });
+
+    it('clones the working copy and reads the state', async () => {
+      fs.readSystemFile.mockResolvedValueOnce(state as never);
+
+      expect(await platform.initRepo()).toEqual({
+        defaultBranch: 'main',
+        isFork: false,
+        repoFingerprint: expect.any(String),
+      });
+      expect(git.initRepo).toHaveBeenCalledExactlyOnceWith({
+        url: '/repo',
+        defaultBranch: 'main',
+      });
+      expect(fs.readSystemFile).toHaveBeenCalledExactlyOnceWith(
+        '/out/state.json',
+        'utf8',
+      );
+      expect(await platform.getBranchPr('renovate/foo-1.x')).toMatchObject({
+        number: 2,
+        title: 'Update foo to v1.2.0',
+        labels: ['deps'],
+        bodyStruct: { hash: expect.any(String) },
+      });
+      expect(
+        await platform.findPr({
+          branchName: 'renovate/foo-1.x',
+          state: '!open',
+        }),
+      ).toMatchObject({ number: 1, state: 'closed' });
+      expect(await platform.findIssue('Dependency Dashboard')).toMatchObject({
+        number: 3,
+        body: 'dashboard',
+      });
+    });
+
+    it('uses the default branch of origin if HEAD is detached', async () => {
+      rawExec
+        .mockRejectedValueOnce(
+          new Error('fatal: ref HEAD is not a symbolic ref'),
+        )
+        .mockResolvedValueOnce(
+          partial<ExecResult>({ stdout: 'origin/develop\n' }),
+        );
+      fs.readSystemFile.mockResolvedValueOnce(state as never);
+
+      expect(await platform.initRepo()).toMatchObject({
+        defaultBranch: 'develop',
+      });
+      expect(rawExec).toHaveBeenLastCalledWith(
+        'git rev-parse --abbrev-ref origin/HEAD',
+        { cwd: '/repo' },
+      );
+      expect(git.initRepo).toHaveBeenCalledExactlyOnceWith({
+        url: '/repo',
+        defaultBranch: 'develop',
+      });
+    });
+
+    it('starts without state', async () => {
+      fs.readSystemFile.mockRejectedValueOnce(
+        Object.assign(new Error('not found'), { code: 'ENOENT' }),
+      );
+
+      await platform.initRepo();
+
+      expect(await platform.getPrList()).toEqual([]);
+      expect(await platform.getIssueList()).toEqual([]);
+    });
+
+    it('ignores invalid state', async () => {
+      fs.readSystemFile.mockResolvedValueOnce('{"prs": "invalid"}' as never);
+
+      await platform.initRepo();
+
+      expect(await platform.getPrList()).toEqual([]);
+    });
+
+    it('writes and updates PRs', async () => {
+      fs.readSystemFile.mockResolvedValueOnce(state as never);
+      await platform.initRepo();
+
+      const pr = await platform.createPr({
+        sourceBranch: 'renovate/bar-2.x',
+        targetBranch: 'main',
+        prTitle: 'Update bar to v2',
+        prBody: 'bar body',
+        labels: ['deps'],
+      });
+
+      expect(pr).toMatchObject({ number: 4, state: 'open' });
+      expect(fs.writeSystemFile).toHaveBeenCalledWith(
+        '/out/prs/4.md',
+        '# Update bar to v2\n\nbar body\n',
+      );
+      expect(fs.writeSystemFile).toHaveBeenCalledWith(
+        '/out/prs/4.json',
+        expect.stringContaining('"sourceBranch": "renovate/bar-2.x"'),
+      );
+
+      await platform.updatePr({
+        number: 4,
+        prTitle: 'Update bar to v2.1',
+        prBody: 'new body',
+        addLabels: ['major'],
+        removeLabels: ['deps'],
+      });
+      await platform.addReviewers(4, ['alice']);
+      await platform.addAssignees(4, ['bob']);
+      await platform.deleteLabel(4, 'major');
+      await platform.updatePr({ number: 4, prTitle: 'x', state: 'closed' });
+
+      expect(fs.writeSystemFile).toHaveBeenCalledWith(
+        '/out/prs/4.md',
+        '# Update bar to v2.1\n\nnew body\n',
+      );
+      expect(getWrittenState()).toMatchObject({
+        prs: [
+          { number: 1 },
+          { number: 2 },
+          {
+            number: 4,
+            title: 'x',
+            body: 'new body',
+            state: 'closed',
+            labels: [],
+            reviewers: ['alice'],
+            assignees: ['bob'],
+          },
+        ],
+      });
+      expect(await platform.getPr(4)).toMatchObject({
+        hasAssignees: true,
+        reviewers: ['alice'],
+      });
+      expect(await platform.mergePr()).toBeFalse();
+    });
+
+    it('ensures issues', async () => {
+      fs.readSystemFile.mockResolvedValueOnce(state as never);
+      await platform.initRepo();
+
+      expect(
+        await platform.ensureIssue({
+          title: 'Dependency Dashboard',
+          body: 'dashboard',
+        }),
+      ).toBeNull();
+      expect(
+        await platform.ensureIssue({
+          title: 'Dependency Dashboard',
+          body: 'new dashboard',
+        }),
+      ).toBe('updated');
+      expect(
+        await platform.ensureIssue({ title: 'Config error', body: 'error' }),
+      ).toBe('created');
+
+      await platform.ensureIssueClosing('Config error');
+
+      expect(
+        await platform.ensureIssue({
+          title: 'Config error',
+          body: 'error',
+          once: true,
+        }),
+      ).toBeNull();
+      expect(await platform.findIssue('Config error')).toBeNull();
+      expect(await platform.getIssue(4)).toMatchObject({ state: 'closed' });
+      expect(
+        await platform.ensureIssue({ title: 'Config error', body: 'error' }),
+      ).toBe('updated');
+      expect(getWrittenState()).toMatchObject({
+        issues: [
+          { number: 3, body: 'new dashboard', state: 'open' },
+          { number: 4, title: 'Config error', state: 'open' },
+        ],
+      });
     });
   });
 });
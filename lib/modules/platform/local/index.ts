@@ -1,16 +1,50 @@
+import upath from 'upath';
+import { logger } from '../../../logger/index.ts';
 import type { BranchStatus } from '../../../types/index.ts';
+import { rawExec } from '../../../util/exec/common.ts';
+import { readSystemFile, writeSystemFile } from '../../../util/fs/index.ts';
+import * as git from '../../../util/git/index.ts';
+import { regEx } from '../../../util/regex.ts';
+import { getPrBodyStruct } from '../pr-body.ts';
 import type {
+  CreatePRConfig,
+  EnsureIssueConfig,
+  EnsureIssueResult,
+  FindPRConfig,
   Issue,
   PlatformParams,
   PlatformResult,
   Pr,
   RepoResult,
+  UpdatePrConfig,
 } from '../types.ts';
+import { repoFingerprint } from '../util.ts';
+import type { LocalIssue, LocalPr } from './schema.ts';
+import { LocalState } from './schema.ts';
 
 export const id = 'local';
 export const experimental = true;
 
+interface LocalConfig {
+  /** Directory for PR files and state, only set when branches are written */
+  dir?: string;
+  state: LocalState;
+}
+
+let config: LocalConfig = { state: { prs: [], issues: [] } };
+
 export function initPlatform(params: PlatformParams): Promise<PlatformResult> {
+  config = { state: { prs: [], issues: [] } };
+  if (params.localPlatformDir) {
+    config.dir = upath.resolve(params.localPlatformDir);
+    logger.debug(`Writing local branches and PR files to ${config.dir}`);
+    return Promise.resolve({
+      endpoint: 'local',
+      persistRepoData: true,
+      requireConfig: 'optional',
+    });
+  }
+
   const dryRun = params.dryRun === 'extract' ? 'extract' : 'lookup';
   return Promise.resolve({
     dryRun,
@@ -24,20 +58,134 @@ export function getRepos(): Promise<string[]> {
   return Promise.resolve([]);
 }
 
-export function initRepo(): Promise<RepoResult> {
-  return Promise.resolve({
-    defaultBranch: '',
-    isFork: false,
-    repoFingerprint: '',
+function getStatePath(): string {
+  return upath.join(config.dir!, 'state.json');
+}
+
+async function readState(): Promise<LocalState> {
+  try {
+    return LocalState.parse(await readSystemFile(getStatePath(), 'utf8'));
+  } catch (err) {
+    if (err.code === 'ENOENT') {
+      logger.debug('No local platform state found');
+    } else {
+      logger.warn({ err }, 'Could not read local platform state');
+    }
+    return { prs: [], issues: [] };
+  }
+}
+
+async function writeState(): Promise<void> {
+  await writeSystemFile(
+    getStatePath(),
+    `${JSON.stringify(config.state, null, 2)}\n`,
+  );
+}
+
+/**
+ * Returns the checked out branch, or the default branch of `origin` if `HEAD` is detached, as in many CI checkouts.
+ */
+async function getDefaultBranch(cwd: string): Promise<string> {
+  try {
+    const { stdout } = await rawExec('git symbolic-ref --short HEAD', { cwd });
+    return stdout.trim();
+  } catch (err) {
+    logger.debug(
+      { err },
+      'HEAD is detached, using the default branch of origin',
+    );
+  }
+  const { stdout } = await rawExec('git rev-parse --abbrev-ref origin/HEAD', {
+    cwd,
   });
+  return stdout.trim().replace(regEx(/^origin\//), '');
 }
 
-export function findIssue(): Promise<null> {
-  return Promise.resolve(null);
+export async function initRepo(): Promise<RepoResult> {
+  if (!config.dir) {
+    return {
+      defaultBranch: '',
+      isFork: false,
+      repoFingerprint: '',
+    };
+  }
+
+  // Renovate works in its own clone of the working copy and pushes its
+  // branches back to it, so the working tree itself is never modified
+  const cwd = process.cwd();
+  const defaultBranch = await getDefaultBranch(cwd);
+  await git.initRepo({ url: cwd, defaultBranch });
+  config.state = await readState();
+  logger.debug(
+    `Found ${config.state.prs.length} PRs and ${config.state.issues.length} issues in local platform state`,
+  );
+
+  return {
+    defaultBranch,
+    isFork: false,
+    repoFingerprint: repoFingerprint(cwd, 'local'),
+  };
+}
+
+function toPr(pr: LocalPr): Pr {
+  return {
+    number: pr.number,
+    sourceBranch: pr.sourceBranch,
+    targetBranch: pr.targetBranch,
+    title: pr.title,
+    state: pr.state,
+    labels: pr.labels,
+    reviewers: pr.reviewers,
+    hasAssignees: pr.assignees.length > 0,
+    isDraft: pr.isDraft,
+    createdAt: pr.createdAt,
+    bodyStruct: getPrBodyStruct(pr.body),
+  };
+}
+
+function toIssue(issue: LocalIssue): Issue {
+  return { ...issue };
+}
+
+function getNextNumber(): number {
+  const numbers = [...config.state.prs, ...config.state.issues].map(
+    ({ number }) => number,
+  );
+  return Math.max(0, ...numbers) + 1;
+}
+
+/**
+ * Writes the PR's title and body as Markdown, and its metadata as JSON.
+ */
+async function writePr(pr: LocalPr): Promise<void> {
+  const prefix = upath.join(config.dir!, 'prs', `${pr.number}`);
+  await writeSystemFile(`${prefix}.md`, `# ${pr.title}\n\n${pr.body}\n`);
+  const { body: _body, ...metadata } = pr;
+  await writeSystemFile(
+    `${prefix}.json`,
+    `${JSON.stringify(metadata, null, 2)}\n`,
+  );
+  await writeState();
+}
+
+function findLocalPr(number: number): LocalPr | undefined {
+  return config.state.prs.find((pr) => pr.number === number);
+}
+
+export function findIssue(title: string): Promise<Issue | null> {
+  const issue = config.state.issues.find(
+    (issue) => issue.state === 'open' && issue.title === title,
+  );
+  return Promise.resolve(issue ? toIssue(issue) : null);
+}
+
+export function getIssue(number: number): Promise<Issue | null> {
+  const issue = config.state.issues.find((issue) => issue.number === number);
+  return Promise.resolve(issue ? toIssue(issue) : null);
 }
 
 export function getIssueList(): Promise<Issue[]> {
-  return Promise.resolve([]);
+  return Promise.resolve(config.state.issues.map(toIssue));
 }
 
 export function getRawFile(): Promise<string | null> {
@@ -49,15 +197,75 @@ export function getJsonFile(): Promise<Record<string, unknown> | null> {
 }
 
 export function getPrList(): Promise<Pr[]> {
-  return Promise.resolve([]);
+  return Promise.resolve(config.state.prs.map(toPr));
 }
 
-export function ensureIssueClosing(): Promise<void> {
-  return Promise.resolve();
+export async function ensureIssueClosing(title: string): Promise<void> {
+  let closed = false;
+  for (const issue of config.state.issues) {
+    if (issue.state === 'open' && issue.title === title) {
+      logger.debug(`Closing issue #${issue.number}`);
+      issue.state = 'closed';
+      issue.lastModified = new Date().toISOString();
+      closed = true;
+    }
+  }
+  if (closed) {
+    await writeState();
+  }
 }
 
-export function ensureIssue(): Promise<null> {
-  return Promise.resolve(null);
+export async function ensureIssue({
+  title,
+  reuseTitle,
+  body,
+  once = false,
+  shouldReOpen = true,
+}: EnsureIssueConfig): Promise<EnsureIssueResult | null> {
+  if (!config.dir) {
+    return null;
+  }
+
+  const now = new Date().toISOString();
+  const issues = config.state.issues.filter(
+    (issue) => issue.title === title || issue.title === reuseTitle,
+  );
+  const issue =
+    issues.find((issue) => issue.state === 'open') ??
+    issues.find((issue) => issue.title === title);
+
+  if (!issue) {
+    const created: LocalIssue = {
+      number: getNextNumber(),
+      title,
+      body,
+      state: 'open',
+      createdAt: now,
+      lastModified: now,
+    };
+    config.state.issues.push(created);
+    await writeState();
+    logger.info(`Issue #${created.number} created`);
+    return 'created';
+  }
+
+  if (issue.state === 'closed' && (once || !shouldReOpen)) {
+    logger.debug(`Issue #${issue.number} is closed - skipping`);
+    return null;
+  }
+
+  if (issue.state === 'open' && issue.title === title && issue.body === body) {
+    logger.debug(`Issue #${issue.number} is open and up to date`);
+    return null;
+  }
+
+  issue.title = title;
+  issue.body = body;
+  issue.state = 'open';
+  issue.lastModified = now;
+  await writeState();
+  logger.debug(`Issue #${issue.number} updated`);
+  return 'updated';
 }
 
 export function massageMarkdown(input: string): string {
@@ -65,34 +273,111 @@ export function massageMarkdown(input: string): string {
 }
 
 /**
- * Unsed, no Dashboard
+ * PR and issue bodies are written to files, so there's no limit
  */
 export function maxBodyLength(): number {
   return Infinity;
 }
 
-export function updatePr(): Promise<void> {
-  return Promise.resolve();
+export async function updatePr({
+  number,
+  prTitle,
+  prBody,
+  state,
+  targetBranch,
+  labels,
+  addLabels,
+  removeLabels,
+}: UpdatePrConfig): Promise<void> {
+  const pr = findLocalPr(number);
+  if (!pr) {
+    logger.debug(`PR #${number} not found`);
+    return;
+  }
+
+  pr.title = prTitle;
+  pr.body = prBody ?? pr.body;
+  pr.state = state ?? pr.state;
+  pr.targetBranch = targetBranch ?? pr.targetBranch;
+  if (labels) {
+    pr.labels = labels;
+  }
+  pr.labels = [...new Set([...pr.labels, ...(addLabels ?? [])])].filter(
+    (label) => !removeLabels?.includes(label),
+  );
+  pr.updatedAt = new Date().toISOString();
+  await writePr(pr);
 }
 
 export function mergePr(): Promise<boolean> {
+  logger.debug('Merging PRs is not supported when platform=local');
   return Promise.resolve(false);
 }
 
-export function addReviewers(): Promise<void> {
-  return Promise.resolve();
+export async function addReviewers(
+  number: number,
+  reviewers: string[],
+): Promise<void> {
+  const pr = findLocalPr(number);
+  if (pr) {
+    pr.reviewers = [...new Set([...pr.reviewers, ...reviewers])];
+    await writePr(pr);
+  }
 }
 
-export function addAssignees(): Promise<void> {
-  return Promise.resolve();
+export async function addAssignees(
+  number: number,
+  assignees: string[],
+): Promise<void> {
+  const pr = findLocalPr(number);
+  if (pr) {
+    pr.assignees = [...new Set([...pr.assignees, ...assignees])];
+    await writePr(pr);
+  }
 }
 
-export function createPr(): Promise<null> {
-  return Promise.resolve(null);
+export async function createPr({
+  sourceBranch,
+  targetBranch,
+  prTitle,
+  prBody,
+  labels,
+  draftPR,
+}: CreatePRConfig): Promise<Pr | null> {
+  if (!config.dir) {
+    return null;
+  }
+
+  const now = new Date().toISOString();
+  const pr: LocalPr = {
+    number: getNextNumber(),
+    sourceBranch,
+    targetBranch,
+    title: prTitle,
+    body: prBody,
+    state: 'open',
+    labels: labels ?? [],
+    reviewers: [],
+    assignees: [],
+    isDraft: draftPR,
+    createdAt: now,
+    updatedAt: now,
+  };
+  config.state.prs.push(pr);
+  await writePr(pr);
+  logger.info({ pr: pr.number, prTitle }, 'PR created');
+  return toPr(pr);
 }
 
-export function deleteLabel(): Promise<void> {
-  return Promise.resolve();
+export async function deleteLabel(
+  number: number,
+  label: string,
+): Promise<void> {
+  const pr = findLocalPr(number);
+  if (pr?.labels.includes(label)) {
+    pr.labels = pr.labels.filter((l) => l !== label);
+    await writePr(pr);
+  }
 }
 
 export function setBranchStatus(): Promise<void> {
@@ -115,14 +400,29 @@ export function ensureComment(): Promise<boolean> {
   return Promise.resolve(false);
 }
 
-export function getPr(): Promise<null> {
-  return Promise.resolve(null);
+export function getPr(number: number): Promise<Pr | null> {
+  const pr = findLocalPr(number);
+  return Promise.resolve(pr ? toPr(pr) : null);
 }
 
-export function findPr(): Promise<null> {
-  return Promise.resolve(null);
+export function findPr({
+  branchName,
+  prTitle,
+  state = 'all',
+  targetBranch,
+}: FindPRConfig): Promise<Pr | null> {
+  // newest first, in case a branch had several PRs over time
+  const pr = config.state.prs.findLast(
+    (pr) =>
+      pr.sourceBranch === branchName &&
+      (!prTitle || pr.title === prTitle) &&
+      (!targetBranch || pr.targetBranch === targetBranch) &&
+      (state === 'all' ||
+        (state === '!open' ? pr.state !== 'open' : pr.state === state)),
+  );
+  return Promise.resolve(pr ? toPr(pr) : null);
 }
 
-export function getBranchPr(): Promise<null> {
-  return Promise.resolve(null);
+export function getBranchPr(branchName: string): Promise<Pr | null> {
+  return findPr({ branchName, state: 'open' });
 }
@@ -0,0 +1,36 @@
+import { z } from 'zod/v4';
+import { Json } from '../../../util/schema-utils/index.ts';
+
+export const LocalPr = z.object({
+  number: z.number(),
+  sourceBranch: z.string(),
+  targetBranch: z.string(),
+  title: z.string(),
+  body: z.string(),
+  state: z.enum(['open', 'closed', 'merged']),
+  labels: z.array(z.string()).default([]),
+  reviewers: z.array(z.string()).default([]),
+  assignees: z.array(z.string()).default([]),
+  isDraft: z.boolean().optional(),
+  createdAt: z.string(),
+  updatedAt: z.string(),
+});
+export type LocalPr = z.infer<typeof LocalPr>;
+
+export const LocalIssue = z.object({
+  number: z.number(),
+  title: z.string(),
+  body: z.string(),
+  state: z.enum(['open', 'closed']),
+  createdAt: z.string(),
+  lastModified: z.string(),
+});
+export type LocalIssue = z.infer<typeof LocalIssue>;
+
+export const LocalState = Json.pipe(
+  z.object({
+    prs: z.array(LocalPr).default([]),
+    issues: z.array(LocalIssue).default([]),
+  }),
+);
+export type LocalState = z.infer<typeof LocalState>;
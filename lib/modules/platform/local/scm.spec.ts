@@ -1,4 +1,5 @@
-import { partial } from '~test/util.ts';
+import { git, partial } from '~test/util.ts';
+import { GlobalConfig } from '../../../config/global.ts';
 import { rawExec as _rawExec } from '../../../util/exec/common.ts';
 import type { ExecResult } from '../../../util/exec/types.ts';
 import type { CommitFilesConfig } from '../../../util/git/types.ts';
@@ -14,6 +15,7 @@ describe('modules/platform/local/scm', () => {
   let localFs: LocalFs;
 
   beforeEach(() => {
+    GlobalConfig.reset();
     localFs = new LocalFs();
   });
 
@@ -93,4 +95,32 @@ describe('modules/platform/local/scm', () => {
   it('mergeBranch', async () => {
     await expect(localFs.mergeToLocal('branchName')).resolves.toBeUndefined();
   });
+
+  describe('with localPlatformDir', () => {
+    beforeEach(() => {
+      GlobalConfig.set({ localPlatformDir: '/out' });
+    });
+
+    it('uses git', async () => {
+      git.branchExists.mockReturnValueOnce(false);
+      git.getFileList.mockResolvedValueOnce(['file1']);
+      git.isBranchModified.mockResolvedValueOnce(true);
+
+      expect(await localFs.branchExists('renovate/foo')).toBeFalse();
+      expect(await localFs.getFileList()).toEqual(['file1']);
+      expect(await localFs.isBranchModified('renovate/foo', 'main')).toBeTrue();
+      await localFs.commitAndPush(partial<CommitFilesConfig>());
+      await localFs.deleteBranch('renovate/foo');
+
+      expect(git.commitFiles).toHaveBeenCalledOnce();
+      expect(git.deleteBranch).toHaveBeenCalledExactlyOnceWith('renovate/foo');
+      expect(execMock).not.toHaveBeenCalled();
+    });
+
+    it('never pushes to the base branch', async () => {
+      await localFs.mergeAndPush('renovate/foo');
+
+      expect(git.mergeBranch).not.toHaveBeenCalled();
+    });
+  });
 });
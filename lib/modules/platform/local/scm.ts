@@ -1,47 +1,87 @@
 import { glob } from 'glob';
 import type { DateTime } from 'luxon';
+import { GlobalConfig } from '../../../config/global.ts';
 import { logger } from '../../../logger/index.ts';
 import { rawExec } from '../../../util/exec/common.ts';
 import type { CommitFilesConfig } from '../../../util/git/types.ts';
 import type { LongCommitSha } from '../../../util/schema-utils/git.ts';
+import { DefaultGitScm } from '../default-scm.ts';
 import type { PlatformScm } from '../types.ts';
 
+/**
+ * Branches are only written when `localPlatformDir` is configured, otherwise
+ * Renovate runs directly against the files in the working directory.
+ */
+function writesBranches(): boolean {
+  return !!GlobalConfig.get('localPlatformDir');
+}
+
 let fileList: string[] | undefined;
 export class LocalFs implements PlatformScm {
-  isBranchBehindBase(
-    _branchName: string,
-    _baseBranch: string,
-  ): Promise<boolean> {
+  private readonly git = new DefaultGitScm();
+
+  isBranchBehindBase(branchName: string, baseBranch: string): Promise<boolean> {
+    if (writesBranches()) {
+      return this.git.isBranchBehindBase(branchName, baseBranch);
+    }
     return Promise.resolve(false);
   }
-  isBranchModified(_branchName: string, _baseBranch: string): Promise<boolean> {
+  isBranchModified(branchName: string, baseBranch: string): Promise<boolean> {
+    if (writesBranches()) {
+      return this.git.isBranchModified(branchName, baseBranch);
+    }
     return Promise.resolve(false);
   }
-  isBranchConflicted(_baseBranch: string, _branch: string): Promise<boolean> {
+  isBranchConflicted(baseBranch: string, branch: string): Promise<boolean> {
+    if (writesBranches()) {
+      return this.git.isBranchConflicted(baseBranch, branch);
+    }
     return Promise.resolve(false);
   }
-  branchExists(_branchName: string): Promise<boolean> {
+  branchExists(branchName: string): Promise<boolean> {
+    if (writesBranches()) {
+      return this.git.branchExists(branchName);
+    }
     return Promise.resolve(true);
   }
-  getBranchCommit(_branchName: string): Promise<LongCommitSha | null> {
+  getBranchCommit(branchName: string): Promise<LongCommitSha | null> {
+    if (writesBranches()) {
+      return this.git.getBranchCommit(branchName);
+    }
     return Promise.resolve(null);
   }
-  getBranchUpdateDate(_branchName: string): Promise<DateTime | null> {
+  getBranchUpdateDate(branchName: string): Promise<DateTime | null> {
+    if (writesBranches()) {
+      return this.git.getBranchUpdateDate(branchName);
+    }
     return Promise.resolve(null);
   }
   getAllBranchUpdateDates(): Promise<Record<string, DateTime>> {
+    if (writesBranches()) {
+      return this.git.getAllBranchUpdateDates();
+    }
     return Promise.resolve({});
   }
-  deleteBranch(_branchName: string): Promise<void> {
+  deleteBranch(branchName: string): Promise<void> {
+    if (writesBranches()) {
+      return this.git.deleteBranch(branchName);
+    }
     return Promise.resolve();
   }
   commitAndPush(
-    _commitConfig: CommitFilesConfig,
+    commitConfig: CommitFilesConfig,
   ): Promise<LongCommitSha | null> {
+    if (writesBranches()) {
+      return this.git.commitAndPush(commitConfig);
+    }
     return Promise.resolve(null);
   }
 
   async getFileList(): Promise<string[]> {
+    if (writesBranches()) {
+      return this.git.getFileList();
+    }
+
     try {
       // fetch file list using git
       const maxBuffer = 10 * 1024 * 1024; // 10 MiB in bytes
@@ -59,15 +99,22 @@ export class LocalFs implements PlatformScm {
     return fileList;
   }
 
-  checkoutBranch(_branchName: string): Promise<LongCommitSha | null> {
+  checkoutBranch(branchName: string): Promise<LongCommitSha | null> {
+    if (writesBranches()) {
+      return this.git.checkoutBranch(branchName);
+    }
     return Promise.resolve(null);
   }
 
   mergeAndPush(_branchName: string): Promise<void> {
+    // the base branch is checked out in the working copy, so can't be pushed
     return Promise.resolve();
   }
 
-  mergeToLocal(_branchName: string): Promise<void> {
+  mergeToLocal(branchName: string): Promise<void> {
+    if (writesBranches()) {
+      return this.git.mergeToLocal(branchName);
+    }
     return Promise.resolve();
   }
 }
@@ -13,6 +13,8 @@ export interface PlatformParams {
   username?: string;
   password?: string;
   gitAuthor?: string;
+  /** Local only: directory for PR files and state. */
+  localPlatformDir?: string;
 }
 
 export interface PlatformResult {
@@ -60,6 +60,7 @@ export type SkipReason =
   | 'malicious-update-proposed';
 
 export type StageName =
+  | 'current-support'
   | 'current-timestamp'
   | 'datasource-merge'
   | 'extract'
@@ -66,6 +66,7 @@ export const packageCacheNamespaces = [
   'datasource-gitlab-tags',
   'datasource-glasskube-packages',
   'datasource-go-direct',
+  'datasource-go-module-path',
   'datasource-go-proxy',
   'datasource-go',
   'datasource-golang-version',
@@ -105,8 +106,10 @@ export const packageCacheNamespaces = [
   'github-branches-datasource-v1',
   'github-releases-datasource-v2',
   'github-tags-datasource-v2',
+  'go-module-hash',
   'merge-confidence',
   'preset',
+  'source-activity',
   'terraform-provider-hash',
   'url-sha256',
 ] as const;
@@ -3,6 +3,7 @@ import { logger } from '../../../../logger/index.ts';
 import type { RepoCache } from '../types.ts';
 import { RepoCacheLocal } from './local.ts';
 import { RepoCacheS3 } from './s3.ts';
+import { RepoCacheSqlite } from './sqlite.ts';
 
 export class CacheFactory {
   static get(
@@ -16,6 +17,8 @@ export class CacheFactory {
         return new RepoCacheLocal(repository, repoFingerprint);
       case 's3':
         return new RepoCacheS3(repository, repoFingerprint, cacheType);
+      case 'sqlite':
+        return new RepoCacheSqlite(repository, repoFingerprint, cacheType);
       default:
         logger.warn(
           { cacheType },
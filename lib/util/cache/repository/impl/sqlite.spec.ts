@@ -0,0 +1,129 @@
+import { DatabaseSync } from 'node:sqlite';
+import { withDir } from 'tmp-promise';
+import upath from 'upath';
+import { logger as _logger } from '~test/util.ts';
+import { GlobalConfig } from '../../../../config/global.ts';
+import { CacheFactory } from './cache-factory.ts';
+import { RepoCacheSqlite, getSqliteFile } from './sqlite.ts';
+
+const { logger } = _logger;
+
+function withCacheDir<T>(fn: (cacheDir: string) => Promise<T>): Promise<T> {
+  return withDir(
+    async ({ path }) => {
+      GlobalConfig.set({ cacheDir: path, platform: 'github' });
+      return await fn(path);
+    },
+    { unsafeCleanup: true },
+  );
+}
+
+describe('util/cache/repository/impl/sqlite', () => {
+  describe('getSqliteFile()', () => {
+    beforeEach(() => {
+      GlobalConfig.set({ cacheDir: '/tmp/cache' });
+    });
+
+    it.each`
+      url                           | expected
+      ${'sqlite://'}                | ${'/tmp/cache/renovate/repository/cache.sqlite'}
+      ${'sqlite://repos.sqlite'}    | ${'/tmp/cache/repos.sqlite'}
+      ${'SQLite:///data/db.sqlite'} | ${'/data/db.sqlite'}
+    `('getSqliteFile("$url") === $expected', ({ url, expected }) => {
+      expect(getSqliteFile(url)).toBe(expected);
+    });
+  });
+
+  it('is created by the factory', () => {
+    expect(
+      CacheFactory.get('org/repo', '0123456789abcdef', 'sqlite://'),
+    ).toBeInstanceOf(RepoCacheSqlite);
+  });
+
+  it('stores every repository in one database', async () => {
+    await withCacheDir(async (cacheDir) => {
+      const cache1 = CacheFactory.get('org/repo1', '111', 'sqlite://db.sqlite');
+      await cache1.load();
+      expect(cache1.getData()).toEqual({});
+      cache1.getData().semanticCommits = 'enabled';
+      await cache1.save();
+
+      const cache2 = CacheFactory.get('org/repo2', '222', 'sqlite://db.sqlite');
+      cache2.getData().semanticCommits = 'disabled';
+      await cache2.save();
+
+      const restored1 = CacheFactory.get(
+        'org/repo1',
+        '111',
+        'sqlite://db.sqlite',
+      );
+      await restored1.load();
+      expect(restored1.getData()).toEqual({ semanticCommits: 'enabled' });
+      expect(restored1.isModified()).toBeFalse();
+
+      const restored2 = CacheFactory.get(
+        'org/repo2',
+        '222',
+        'sqlite://db.sqlite',
+      );
+      await restored2.load();
+      expect(restored2.getData()).toEqual({ semanticCommits: 'disabled' });
+
+      const client = new DatabaseSync(upath.join(cacheDir, 'db.sqlite'));
+      try {
+        expect(
+          client
+            .prepare('SELECT platform, repository FROM repository_cache')
+            .all(),
+        ).toEqual([
+          { platform: 'github', repository: 'org/repo1' },
+          { platform: 'github', repository: 'org/repo2' },
+        ]);
+      } finally {
+        client.close();
+      }
+    });
+  });
+
+  it('ignores data for another fingerprint', async () => {
+    await withCacheDir(async () => {
+      const cache = CacheFactory.get('org/repo', '111', 'sqlite://');
+      cache.getData().semanticCommits = 'enabled';
+      await cache.save();
+
+      const changed = CacheFactory.get('org/repo', '222', 'sqlite://');
+      await changed.load();
+
+      expect(changed.getData()).toEqual({});
+      expect(logger.debug).toHaveBeenCalledWith(
+        'RepoCacheSqlite.read() - Repository fingerprint changed, ignoring cached data',
+      );
+
+      changed.getData().semanticCommits = 'disabled';
+      await changed.save();
+
+      const restored = CacheFactory.get('org/repo', '222', 'sqlite://');
+      await restored.load();
+      expect(restored.getData()).toEqual({ semanticCommits: 'disabled' });
+    });
+  });
+
+  it('handles database errors', async () => {
+    await withCacheDir(async (cacheDir) => {
+      // a directory can't be opened as database
+      const cache = CacheFactory.get('org/repo', '111', `sqlite://${cacheDir}`);
+      await cache.load();
+      cache.getData().semanticCommits = 'enabled';
+      await cache.save();
+
+      expect(logger.warn).toHaveBeenCalledWith(
+        { err: expect.any(Error) },
+        'RepoCacheSqlite.read() - failure',
+      );
+      expect(logger.warn).toHaveBeenCalledWith(
+        { err: expect.any(Error) },
+        'RepoCacheSqlite.write() - failure',
+      );
+    });
+  });
+});
@@ -0,0 +1,138 @@
+import type { DatabaseSync, StatementSync } from 'node:sqlite';
+import upath from 'upath';
+import { GlobalConfig } from '../../../../config/global.ts';
+import { logger } from '../../../../logger/index.ts';
+import { getEnv } from '../../../env.ts';
+import { ensureDir } from '../../../fs/index.ts';
+import { parseInteger } from '../../../number.ts';
+import { regEx } from '../../../regex.ts';
+import type { RepoCacheRecord } from '../schema.ts';
+import { RepoCacheBase } from './base.ts';
+
+interface RepoCacheDatabase {
+  client: DatabaseSync;
+  getStatement: StatementSync;
+  upsertStatement: StatementSync;
+}
+
+/**
+ * Databases are shared by all repositories processed by this Renovate process.
+ */
+const databases = new Map<string, Promise<RepoCacheDatabase>>();
+
+/**
+ * Returns the database file of a `sqlite://path` cache type.
+ * Relative paths are resolved against `cacheDir`.
+ */
+export function getSqliteFile(url: string): string {
+  const path = url.trim().replace(regEx(/^sqlite:\/\//i), '');
+  const cacheDir = GlobalConfig.get('cacheDir');
+  if (!path) {
+    return upath.join(cacheDir, 'renovate/repository/cache.sqlite');
+  }
+  return upath.isAbsolute(path) ? path : upath.join(cacheDir, path);
+}
+
+async function openDatabase(sqliteFile: string): Promise<RepoCacheDatabase> {
+  const { DatabaseSync: Sqlite } = await import('node:sqlite');
+  await ensureDir(upath.dirname(sqliteFile));
+  logger.debug(`Using SQLite repository cache: ${sqliteFile}`);
+
+  const { RENOVATE_X_SQLITE_BUSY_TIMEOUT } = getEnv();
+  const timeout = parseInteger(RENOVATE_X_SQLITE_BUSY_TIMEOUT, 5000);
+
+  const client = new Sqlite(sqliteFile, { timeout });
+  client.exec('PRAGMA journal_mode = WAL');
+  client.exec("PRAGMA encoding = 'UTF-8'");
+
+  client.exec(
+    `
+      CREATE TABLE IF NOT EXISTS repository_cache (
+        platform TEXT NOT NULL,
+        repository TEXT NOT NULL,
+        fingerprint TEXT NOT NULL,
+        data TEXT NOT NULL,
+        updated INTEGER NOT NULL,
+        PRIMARY KEY (platform, repository)
+      )
+    `,
+  );
+
+  const getStatement = client.prepare(`
+    SELECT fingerprint, data FROM repository_cache
+    WHERE platform = @platform AND repository = @repository
+  `);
+
+  const upsertStatement = client.prepare(`
+    INSERT INTO repository_cache
+      (platform, repository, fingerprint, data, updated)
+    VALUES (@platform, @repository, @fingerprint, @data, unixepoch())
+    ON CONFLICT (platform, repository) DO UPDATE SET
+      fingerprint = @fingerprint,
+      data = @data,
+      updated = unixepoch()
+  `);
+
+  return { client, getStatement, upsertStatement };
+}
+
+function getDatabase(sqliteFile: string): Promise<RepoCacheDatabase> {
+  let database = databases.get(sqliteFile);
+  if (!database) {
+    database = openDatabase(sqliteFile);
+    databases.set(sqliteFile, database);
+    // allow retrying on the next access
+    database.catch(() => databases.delete(sqliteFile));
+  }
+  return database;
+}
+
+export class RepoCacheSqlite extends RepoCacheBase {
+  private readonly sqliteFile: string;
+
+  constructor(repository: string, fingerprint: string, url: string) {
+    super(repository, fingerprint);
+    this.sqliteFile = getSqliteFile(url);
+  }
+
+  protected async read(): Promise<string | null> {
+    try {
+      const { getStatement } = await getDatabase(this.sqliteFile);
+      const row = getStatement.get({
+        platform: this.platform,
+        repository: this.repository,
+      }) as { fingerprint: string; data: string } | undefined;
+
+      if (!row) {
+        logger.debug('RepoCacheSqlite.read() - No cached data found');
+        return null;
+      }
+
+      if (row.fingerprint !== this.fingerprint) {
+        logger.debug(
+          'RepoCacheSqlite.read() - Repository fingerprint changed, ignoring cached data',
+        );
+        return null;
+      }
+
+      return row.data;
+    } catch (err) {
+      logger.warn({ err }, 'RepoCacheSqlite.read() - failure');
+    }
+    return null;
+  }
+
+  protected async write(data: RepoCacheRecord): Promise<void> {
+    try {
+      const { upsertStatement } = await getDatabase(this.sqliteFile);
+      upsertStatement.run({
+        platform: this.platform,
+        repository: this.repository,
+        fingerprint: data.fingerprint,
+        data: JSON.stringify(data),
+      });
+    } catch (err) {
+      logger.warn({ err }, 'RepoCacheSqlite.write() - failure');
+    }
+  }
+}
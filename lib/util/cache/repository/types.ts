@@ -3,6 +3,7 @@ import type {
   RepositoryCacheType,
   UpdateType,
 } from '../../../config/types.ts';
+import type { LibYearsWithStatus } from '../../../instrumentation/types.ts';
 import type { PackageFile } from '../../../modules/manager/types.ts';
 import type { RepoInitConfig } from '../../../workers/repository/init/types.ts';
 import type { ExtractResult } from '../../../workers/repository/process/extract-update.ts';
@@ -132,6 +133,11 @@ export interface BranchCache {
   result?: string;
 }
 
+export interface LibYearsCache extends LibYearsWithStatus {
+  /** ISO timestamp of the run */
+  date: string;
+}
+
 export interface RepoCacheData {
   configFileName?: string;
   httpCache?: Record<string, unknown>;
@@ -141,6 +147,8 @@ export interface RepoCacheData {
   init?: RepoInitConfig;
   scan?: Record<string, BaseBranchCache>;
   lastPlatformAutomergeFailure?: string;
+  /** libyears of the most recent runs per base branch, oldest first */
+  libYears?: Record<string, LibYearsCache[]>;
   platform?: {
     forgejo?: {
       pullRequestsCache?: unknown;
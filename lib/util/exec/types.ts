@@ -196,9 +196,8 @@ NOTE that the \`constraints.golang\` is not used (https://github.com/renovatebot
   },
   {
     name: 'gomodMod',
-    description: `Used in the \`gomod\` manager to specify a tag for [\`github.com/marwan-at-work/mod\`](https://github.com/marwan-at-work/mod).
-
-Must be prefixed with \`v\`.`,
+    description:
+      'No longer used. The `gomod` manager now rewrites import paths natively, without installing `github.com/marwan-at-work/mod`.',
   },
   {
     name: 'jenkins',
@@ -22,6 +22,7 @@ import {
   getSiblingFileName,
   isValidLocalPath,
   listCacheDir,
+  listSystemDir,
   localPathExists,
   localPathIsFile,
   localPathIsSymbolicLink,
@@ -630,6 +631,15 @@ describe('util/fs/index', () => {
     });
   });
 
+  describe('listSystemDir', () => {
+    it('lists directory recursively', async () => {
+      await fs.outputFile(`${tmpDir}/foo/bar/file.txt`, 'foobar');
+      expect(
+        (await listSystemDir(`${tmpDir}/foo`, { recursive: true })).sort(),
+      ).toEqual(['bar', 'bar/file.txt']);
+    });
+  });
+
   describe('getLocalFiles', () => {
     it('reads list of files from local fs', async () => {
       const fileContentMap = {
@@ -70,7 +70,10 @@ export async function writeLocalFile(
 
 export async function deleteLocalFile(fileName: string): Promise<void> {
   // This a failsafe and hopefully will never be triggered
-  if (GlobalConfig.get('platform') === 'local') {
+  if (
+    GlobalConfig.get('platform') === 'local' &&
+    !GlobalConfig.get('localPlatformDir')
+  ) {
     throw new Error('Cannot delete file when platform=local');
   }
   const localDir = GlobalConfig.get('localDir');
@@ -359,6 +362,16 @@ export async function writeSystemFile(
   await fs.outputFile(fileName, data);
 }
 
+export function listSystemDir(
+  path: string,
+  options: { recursive: boolean } = { recursive: false },
+): Promise<string[]> {
+  return fs.readdir(path, {
+    encoding: 'utf-8',
+    recursive: options.recursive,
+  });
+}
+
 export async function getLocalFiles(
   fileNames: string[],
 ): Promise<Record<string, string | null>> {
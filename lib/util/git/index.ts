@@ -493,7 +493,10 @@ export const syncGit = withInstrumenting(
       return;
     }
     /* v8 ignore if -- TODO: add test #40625 */
-    if (GlobalConfig.get('platform') === 'local') {
+    if (
+      GlobalConfig.get('platform') === 'local' &&
+      !GlobalConfig.get('localPlatformDir')
+    ) {
       throw new Error('Cannot sync git when platform=local');
     }
     gitInitialized = true;
@@ -730,7 +733,10 @@ export async function getAllBranchUpdateDates(): Promise<
 export async function getCommitMessages(): Promise<string[]> {
   logger.debug('getCommitMessages');
   // v8 ignore else -- TODO: add test #40625
-  if (GlobalConfig.get('platform') !== 'local') {
+  if (
+    GlobalConfig.get('platform') !== 'local' ||
+    GlobalConfig.get('localPlatformDir')
+  ) {
     await syncGit();
   }
   try {
@@ -16,6 +16,7 @@ import { RegistryUrlsMatcher } from './registryurls.ts';
 import { RepositoriesMatcher } from './repositories.ts';
 import { SourceUrlsMatcher } from './sourceurls.ts';
 import type { MatcherApi } from './types.ts';
+import { UnsupportedMatcher } from './unsupported.ts';
 import { UpdateTypesMatcher } from './update-types.ts';
 
 const matchers: MatcherApi[] = [];
@@ -43,4 +44,5 @@ matchers.push(new SourceUrlsMatcher());
 matchers.push(new RegistryUrlsMatcher());
 matchers.push(new NewValueMatcher());
 matchers.push(new CurrentAgeMatcher());
+matchers.push(new UnsupportedMatcher());
 matchers.push(new JsonataMatcher());
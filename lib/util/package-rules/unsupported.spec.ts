@@ -0,0 +1,23 @@
+import { UnsupportedMatcher } from './unsupported.ts';
+
+describe('util/package-rules/unsupported', () => {
+  const matcher = new UnsupportedMatcher();
+
+  it.each`
+    isUnsupported | matchUnsupported | expected
+    ${true}       | ${true}          | ${true}
+    ${false}      | ${true}          | ${false}
+    ${undefined}  | ${true}          | ${false}
+    ${true}       | ${false}         | ${false}
+    ${false}      | ${false}         | ${true}
+    ${undefined}  | ${false}         | ${false}
+    ${true}       | ${undefined}     | ${null}
+  `(
+    'matches($isUnsupported, $matchUnsupported) === $expected',
+    ({ isUnsupported, matchUnsupported, expected }) => {
+      expect(matcher.matches({ isUnsupported }, { matchUnsupported })).toBe(
+        expected,
+      );
+    },
+  );
+});
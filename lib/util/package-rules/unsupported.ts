@@ -0,0 +1,24 @@
+import { isBoolean } from '@sindresorhus/is';
+import type {
+  PackageRule,
+  PackageRuleInputConfig,
+} from '../../config/types.ts';
+import { Matcher } from './base.ts';
+
+export class UnsupportedMatcher extends Matcher {
+  override matches(
+    { isUnsupported }: PackageRuleInputConfig,
+    { matchUnsupported }: PackageRule,
+  ): boolean | null {
+    if (!isBoolean(matchUnsupported)) {
+      return null;
+    }
+
+    // support is only known after the lookup
+    if (!isBoolean(isUnsupported)) {
+      return false;
+    }
+
+    return isUnsupported === matchUnsupported;
+  }
+}
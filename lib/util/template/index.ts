@@ -161,6 +161,8 @@ export const allowedFields = {
   baseBranch: 'The baseBranch for this branch/PR',
   body: 'The body of the release notes',
   categories: 'The categories of the manager of the dependency being updated',
+  checksums:
+    "The SHA256 checksums of the new version's files, keyed by file name, if the datasource provides them (`golang-version` leaves out the release name)",
   currentValue: 'The extracted current value of the dependency being updated',
   currentVersion:
     'The version that would be currently installed. For example, if currentValue is ^3.0.0 then currentVersion might be 3.1.0.',
@@ -0,0 +1,213 @@
+import type { Osv } from '@renovatebot/osv-offline';
+import AdmZip from 'adm-zip';
+import fs from 'fs-extra';
+import type { DirectoryResult } from 'tmp-promise';
+import tmp from 'tmp-promise';
+import * as httpMock from '~test/http-mock.ts';
+import { logger } from '~test/util.ts';
+import {
+  OsvAdvisorySources,
+  mergeVulnerabilities,
+} from './advisory-sources.ts';
+
+function advisory(
+  id: string,
+  ecosystem: string,
+  name: string,
+  aliases?: string[],
+): Osv.Vulnerability {
+  return {
+    id,
+    modified: '2025-01-01T00:00:00Z',
+    ...(aliases ? { aliases } : {}),
+    affected: [
+      {
+        package: { ecosystem, name },
+        ranges: [
+          {
+            type: 'SEMVER',
+            events: [{ introduced: '0' }, { fixed: '1.2.0' }],
+          },
+        ],
+      },
+    ],
+  };
+}
+
+function toBuffer(value: unknown): Buffer {
+  return Buffer.from(JSON.stringify(value));
+}
+
+const internalGo = advisory(
+  'INTERNAL-2025-0001',
+  'Go',
+  'git.example.com/team/lib',
+);
+const internalNpm = advisory(
+  'INTERNAL-2025-0002',
+  'npm',
+  '@example/lib',
+  ['CVE-2025-0002'],
+);
+const internalPackagist = advisory(
+  'INTERNAL-2025-0003',
+  'Packagist:https://packages.example.com',
+  'example/lib',
+);
+
+describe('util/vulnerability/advisory-sources', () => {
+  let tmpDirResult: DirectoryResult;
+  let tmpDir: string;
+
+  beforeEach(async () => {
+    tmpDirResult = await tmp.dir({ unsafeCleanup: true });
+    tmpDir = tmpDirResult.path;
+  });
+
+  afterEach(async () => {
+    await tmpDirResult.cleanup();
+  });
+
+  describe('mergeVulnerabilities()', () => {
+    it('keeps the first advisory of each alias set', () => {
+      const publicNpm = advisory('GHSA-xxxx-xxxx-xxxx', 'npm', '@example/lib', [
+        'CVE-2025-0002',
+      ]);
+
+      expect(
+        mergeVulnerabilities([publicNpm], [internalNpm, internalGo]),
+      ).toEqual([publicNpm, internalGo]);
+    });
+  });
+
+  describe('OsvAdvisorySources', () => {
+    it('loads advisories from a local directory', async () => {
+      await fs.outputJson(`${tmpDir}/go/internal-1.json`, internalGo);
+      await fs.outputJson(`${tmpDir}/npm/internal-2.json`, internalNpm);
+      await fs.outputJson(`${tmpDir}/php/internal-3.json`, internalPackagist);
+      await fs.outputFile(`${tmpDir}/invalid.json`, '{"summary": "foo"}');
+      await fs.outputFile(`${tmpDir}/README.md`, '# Advisories');
+
+      const sources = await OsvAdvisorySources.create([tmpDir]);
+
+      await expect(
+        sources.getVulnerabilities('Go', 'git.example.com/team/lib'),
+      ).resolves.toEqual([internalGo]);
+      await expect(
+        sources.getVulnerabilities('npm', '@example/lib'),
+      ).resolves.toEqual([internalNpm]);
+      await expect(
+        sources.getVulnerabilities('Packagist', 'example/lib'),
+      ).resolves.toEqual([internalPackagist]);
+      await expect(
+        sources.getVulnerabilities('npm', 'other'),
+      ).resolves.toBeEmptyArray();
+    });
+
+    it('loads advisories from a zip archive', async () => {
+      const zip = new AdmZip();
+      zip.addFile('INTERNAL-2025-0001.json', toBuffer(internalGo));
+      zip.addFile('INTERNAL-2025-0002.json', toBuffer(internalNpm));
+      zip.addFile('LICENSE', Buffer.from('MIT'));
+      httpMock
+        .scope('https://advisories.example.com')
+        .get('/all.zip')
+        .reply(200, zip.toBuffer());
+
+      const sources = await OsvAdvisorySources.create([
+        'https://advisories.example.com/all.zip',
+      ]);
+
+      await expect(
+        sources.getVulnerabilities('Go', 'git.example.com/team/lib'),
+      ).resolves.toEqual([internalGo]);
+      await expect(
+        sources.getVulnerabilities('npm', '@example/lib'),
+      ).resolves.toEqual([internalNpm]);
+    });
+
+    it('loads advisories from a local zip archive', async () => {
+      const zip = new AdmZip();
+      zip.addFile('INTERNAL-2025-0002.json', toBuffer(internalNpm));
+      zip.writeZip(`${tmpDir}/all.zip`);
+
+      const sources = await OsvAdvisorySources.create([`${tmpDir}/all.zip`]);
+
+      await expect(
+        sources.getVulnerabilities('npm', '@example/lib'),
+      ).resolves.toEqual([internalNpm]);
+    });
+
+    it('loads advisories from a Go vulnerability database', async () => {
+      httpMock
+        .scope('https://vulndb.example.com')
+        .get('/index/modules.json')
+        .reply(200, [
+          {
+            path: 'git.example.com/team/lib',
+            vulns: [{ id: 'INTERNAL-2025-0001' }, { id: 'INTERNAL-2025-0009' }],
+          },
+          { path: 'git.example.com/team/other', vulns: [] },
+        ])
+        .get('/ID/INTERNAL-2025-0001.json')
+        .reply(200, internalGo)
+        .get('/ID/INTERNAL-2025-0009.json')
+        .reply(404);
+
+      const sources = await OsvAdvisorySources.create([
+        'https://vulndb.example.com',
+      ]);
+
+      await expect(
+        sources.getVulnerabilities('Go', 'git.example.com/team/lib'),
+      ).resolves.toEqual([internalGo]);
+      // entries are fetched only once
+      await expect(
+        sources.getVulnerabilities('Go', 'git.example.com/team/lib'),
+      ).resolves.toEqual([internalGo]);
+      await expect(
+        sources.getVulnerabilities('npm', 'git.example.com/team/lib'),
+      ).resolves.toBeEmptyArray();
+    });
+
+    it('loads advisories from a local Go vulnerability database', async () => {
+      await fs.outputJson(`${tmpDir}/index/modules.json`, [
+        {
+          path: 'git.example.com/team/lib',
+          vulns: [{ id: 'INTERNAL-2025-0001' }],
+        },
+      ]);
+      await fs.outputJson(`${tmpDir}/ID/INTERNAL-2025-0001.json`, internalGo);
+      await fs.outputJson(`${tmpDir}/ID/INTERNAL-2025-0002.json`, internalNpm);
+
+      const sources = await OsvAdvisorySources.create([tmpDir]);
+
+      await expect(
+        sources.getVulnerabilities('Go', 'git.example.com/team/lib'),
+      ).resolves.toEqual([internalGo]);
+      // only the index is used for Go vulnerability databases
+      await expect(
+        sources.getVulnerabilities('npm', '@example/lib'),
+      ).resolves.toBeEmptyArray();
+    });
+
+    it('skips sources which cannot be loaded', async () => {
+      await fs.outputJson(`${tmpDir}/internal-2.json`, internalNpm);
+      httpMock
+        .scope('https://advisories.example.com')
+        .get('/all.zip')
+        .reply(500);
+
+      const sources = await OsvAdvisorySources.create([
+        'https://advisories.example.com/all.zip',
+        `${tmpDir}/missing`,
+        tmpDir,
+      ]);
+
+      await expect(
+        sources.getVulnerabilities('npm', '@example/lib'),
+      ).resolves.toEqual([internalNpm]);
+      expect(logger.logger.warn).toHaveBeenCalledTimes(2);
+    });
+  });
+});
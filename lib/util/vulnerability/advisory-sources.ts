@@ -0,0 +1,248 @@
+import type { Ecosystem, Osv } from '@renovatebot/osv-offline';
+import AdmZip from 'adm-zip';
+import upath from 'upath';
+import { z } from 'zod/v4';
+import { logger } from '../../logger/index.ts';
+import { listSystemDir, readSystemFile } from '../fs/index.ts';
+import { Http } from '../http/index.ts';
+import { Json, LooseArray } from '../schema-utils/index.ts';
+import { isHttpUrl, joinUrlParts } from '../url.ts';
+
+const http = new Http('osv');
+
+const OsvVulnerability = z.looseObject({
+  id: z.string(),
+  aliases: z.array(z.string()).optional(),
+  affected: LooseArray(
+    z.looseObject({
+      package: z
+        .looseObject({
+          ecosystem: z.string(),
+          name: z.string(),
+        })
+        .optional(),
+    }),
+  ).optional(),
+});
+
+const OsvVulnerabilityJson = Json.pipe(OsvVulnerability);
+
+// https://go.dev/doc/security/vuln/database#api
+const VulnDbModules = Json.pipe(
+  LooseArray(
+    z.object({
+      path: z.string(),
+      vulns: LooseArray(z.object({ id: z.string() })).catch([]),
+    }),
+  ),
+);
+
+const vulnDbModulesFile = 'index/modules.json';
+
+type ReadFile = (path: string) => Promise<string>;
+
+interface VulnDb {
+  source: string;
+  read: ReadFile;
+  modules: Map<string, string[]>;
+  entries: Map<string, Promise<Osv.Vulnerability | null>>;
+}
+
+function parseVulnerability(
+  content: string,
+  fileName: string,
+): Osv.Vulnerability | null {
+  const res = OsvVulnerabilityJson.safeParse(content);
+  if (!res.success) {
+    logger.debug({ fileName, err: res.error }, 'Skipping invalid OSV advisory');
+    return null;
+  }
+  return res.data as unknown as Osv.Vulnerability;
+}
+
+function getIds(vulnerability: Osv.Vulnerability): string[] {
+  return [vulnerability.id, ...(vulnerability.aliases ?? [])];
+}
+
+/**
+ * Merges lists of OSV advisories, keeping only the first advisory of each set of aliases.
+ */
+export function mergeVulnerabilities(
+  ...lists: Osv.Vulnerability[][]
+): Osv.Vulnerability[] {
+  const result: Osv.Vulnerability[] = [];
+  const seenIds = new Set<string>();
+  for (const vulnerability of lists.flat()) {
+    const ids = getIds(vulnerability);
+    if (ids.some((id) => seenIds.has(id))) {
+      logger.trace(`Skipping duplicate OSV advisory ${vulnerability.id}`);
+      continue;
+    }
+    ids.forEach((id) => seenIds.add(id));
+    result.push(vulnerability);
+  }
+  return result;
+}
+
+/**
+ * Additional OSV advisories configured with `osvAdvisorySources`.
+ *
+ * Supported sources are:
+ *
+ * - local directories containing OSV JSON files
+ * - local paths or URLs of zip archives containing OSV JSON files
+ * - local directories or URLs in the Go vulnerability database layout
+ */
+export class OsvAdvisorySources {
+  private readonly advisories = new Map<
+    string,
+    Map<string, Osv.Vulnerability[]>
+  >();
+
+  private readonly vulnDbs: VulnDb[] = [];
+
+  private constructor() {}
+
+  static async create(sources: string[]): Promise<OsvAdvisorySources> {
+    const instance = new OsvAdvisorySources();
+    for (const source of sources) {
+      try {
+        await instance.load(source);
+      } catch (err) {
+        logger.warn({ err, source }, 'Unable to load OSV advisory source');
+      }
+    }
+    return instance;
+  }
+
+  async getVulnerabilities(
+    ecosystem: Ecosystem,
+    packageName: string,
+  ): Promise<Osv.Vulnerability[]> {
+    const result = [
+      ...(this.advisories.get(ecosystem)?.get(packageName) ?? []),
+    ];
+
+    if (ecosystem === 'Go') {
+      for (const vulnDb of this.vulnDbs) {
+        for (const id of vulnDb.modules.get(packageName) ?? []) {
+          const vulnerability = await this.getVulnDbEntry(vulnDb, id);
+          if (vulnerability) {
+            result.push(vulnerability);
+          }
+        }
+      }
+    }
+
+    return mergeVulnerabilities(result);
+  }
+
+  private async load(source: string): Promise<void> {
+    if (source.endsWith('.zip')) {
+      const content = isHttpUrl(source)
+        ? (await http.getBuffer(source)).body
+        : await readSystemFile(source);
+      this.loadZip(source, content);
+      return;
+    }
+
+    if (isHttpUrl(source)) {
+      await this.loadVulnDb(
+        source,
+        async (path) => (await http.getText(joinUrlParts(source, path))).body,
+      );
+      return;
+    }
+
+    const read: ReadFile = (path) =>
+      readSystemFile(upath.join(source, path), 'utf8');
+    const files = await listSystemDir(source, { recursive: true });
+    if (files.includes(vulnDbModulesFile)) {
+      await this.loadVulnDb(source, read);
+      return;
+    }
+
+    let count = 0;
+    for (const file of files.filter((f) => f.endsWith('.json'))) {
+      const vulnerability = parseVulnerability(await read(file), file);
+      if (vulnerability) {
+        this.add(vulnerability);
+        count += 1;
+      }
+    }
+    logger.debug({ source, count }, 'Loaded OSV advisories from directory');
+  }
+
+  private loadZip(source: string, content: Buffer): void {
+    let count = 0;
+    for (const entry of new AdmZip(content).getEntries()) {
+      if (entry.isDirectory || !entry.entryName.endsWith('.json')) {
+        continue;
+      }
+      const vulnerability = parseVulnerability(
+        entry.getData().toString('utf8'),
+        entry.entryName,
+      );
+      if (vulnerability) {
+        this.add(vulnerability);
+        count += 1;
+      }
+    }
+    logger.debug({ source, count }, 'Loaded OSV advisories from zip archive');
+  }
+
+  private async loadVulnDb(source: string, read: ReadFile): Promise<void> {
+    const index = VulnDbModules.parse(await read(vulnDbModulesFile));
+    const modules = new Map<string, string[]>();
+    for (const { path, vulns } of index) {
+      modules.set(path, vulns.map(({ id }) => id));
+    }
+    this.vulnDbs.push({ source, read, modules, entries: new Map() });
+    logger.debug(
+      { source, modules: modules.size },
+      'Loaded Go vulnerability database index',
+    );
+  }
+
+  private getVulnDbEntry(
+    vulnDb: VulnDb,
+    id: string,
+  ): Promise<Osv.Vulnerability | null> {
+    let entry = vulnDb.entries.get(id);
+    if (!entry) {
+      const fileName = `ID/${id}.json`;
+      entry = vulnDb
+        .read(fileName)
+        .then((content) => parseVulnerability(content, fileName))
+        .catch((err) => {
+          logger.debug(
+            { err, source: vulnDb.source, id },
+            'Unable to read Go vulnerability database entry',
+          );
+          return null;
+        });
+      vulnDb.entries.set(id, entry);
+    }
+    return entry;
+  }
+
+  private add(vulnerability: Osv.Vulnerability): void {
+    for (const { package: pkg } of vulnerability.affected ?? []) {
+      if (!pkg) {
+        continue;
+      }
+      // OSV sub-ecosystems (e.g. Packagist:https://packages.drupal.org/8) are matched later on
+      const [ecosystem] = pkg.ecosystem.split(':');
+      let packages = this.advisories.get(ecosystem);
+      if (!packages) {
+        packages = new Map();
+        this.advisories.set(ecosystem, packages);
+      }
+      const vulnerabilities = packages.get(pkg.name) ?? [];
+      if (!vulnerabilities.includes(vulnerability)) {
+        vulnerabilities.push(vulnerability);
+        packages.set(pkg.name, vulnerabilities);
+      }
+    }
+  }
+}
@@ -95,7 +95,7 @@ export async function getRepositoryConfig(
   repoConfig.topLevelOrg = repoParts.shift();
   const platform = GlobalConfig.get('platform');
   repoConfig.localDir =
-    platform === 'local'
+    platform === 'local' && !repoConfig.localPlatformDir
       ? process.cwd()
       : // TODO: types (#22198)
         upath.join(repoConfig.baseDir!, `./repos/${platform}/${repoName}`);
@@ -15,6 +15,7 @@ import type {
 } from '../../modules/manager/types.ts';
 import { massageMarkdown } from '../../modules/platform/github/index.ts';
 import type { Platform } from '../../modules/platform/index.ts';
+import { getCache, resetCache } from '../../util/cache/repository/index.ts';
 import { clone } from '../../util/clone.ts';
 import { emojify } from '../../util/emoji.ts';
 import { regEx } from '../../util/regex.ts';
@@ -1977,6 +1978,57 @@ None detected
       );
     });
 
+    it('lists unreachable vulnerabilities last', async () => {
+      const fetchVulnerabilitiesMock = vi.fn();
+      createVulnerabilitiesMock.mockResolvedValueOnce({
+        fetchVulnerabilities: fetchVulnerabilitiesMock,
+      });
+
+      fetchVulnerabilitiesMock.mockResolvedValueOnce([
+        {
+          packageName: 'gopkg.in/yaml.v2',
+          depVersion: '2.2.7',
+          fixedVersion: '>= 2.2.8',
+          reachable: false,
+          packageFileConfig: {
+            manager: 'gomod',
+            packageFile: 'go.mod',
+          },
+          vulnerability: {
+            id: 'GO-2020-0036',
+          },
+        },
+        {
+          packageName: 'gopkg.in/yaml.v2',
+          depVersion: '2.2.7',
+          fixedVersion: '>= 2.2.4',
+          reachable: true,
+          packageFileConfig: {
+            manager: 'gomod',
+            packageFile: 'go.mod',
+          },
+          vulnerability: {
+            id: 'GO-2021-0061',
+          },
+        },
+      ]);
+      const result = await getDashboardMarkdownVulnerabilities(
+        {
+          ...config,
+          dependencyDashboardOSVVulnerabilitySummary: 'all',
+          osvVulnerabilityAlerts: true,
+        },
+        packageFiles,
+      );
+
+      expect(result).toContain(
+        codeBlock`
+          - [GO-2021-0061](https://osv.dev/vulnerability/GO-2021-0061) (fixed in >= 2.2.4)
+          - [GO-2020-0036](https://osv.dev/vulnerability/GO-2020-0036) (fixed in >= 2.2.8) (not reachable)
+        `,
+      );
+    });
+
     it('return unresolved vulnerabilities if set to "unresolved"', async () => {
       const fetchVulnerabilitiesMock = vi.fn();
       createVulnerabilitiesMock.mockResolvedValueOnce({
@@ -2152,6 +2204,48 @@ None detected
       expect(result).not.toContain('pkg2');
     });
 
+    it('shows the abandonment reasons', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        npm: [
+          {
+            packageFile: 'package.json',
+            deps: [
+              {
+                depName: 'archived-pkg',
+                isAbandoned: true,
+                mostRecentTimestamp: asTimestamp('2022-02-01T00:00:00.000Z')!,
+                abandonmentReasons: [
+                  'source repository is archived',
+                  'last release on 2022-02-01',
+                ],
+              },
+              {
+                depName: 'stale-pkg',
+                isAbandoned: true,
+                mostRecentTimestamp: asTimestamp('2019-07-22T08:15:00.000Z')!,
+              },
+            ],
+          },
+        ],
+      };
+
+      const result = dependencyDashboard.getAbandonedPackagesMd(
+        config,
+        packageFiles,
+      );
+
+      expect(result).toContain(
+        'abandonment is detected by release and source repository activity.',
+      );
+      expect(result).toContain(
+        '| Datasource | Package | Last Updated | Reason |',
+      );
+      expect(result).toContain(
+        '| npm | `archived-pkg` | `2022-02-01` | source repository is archived, last release on 2022-02-01 |',
+      );
+      expect(result).toContain('| npm | `stale-pkg` | `2019-07-22` |  |');
+    });
+
     it('displays "unknown" when mostRecentTimestamp is missing', () => {
       const packageFiles: Record<string, PackageFile[]> = {
         npm: [
@@ -2204,4 +2298,145 @@ None detected
       expect(result).toEqual('');
     });
   });
+
+  describe('getLibYearsMd()', () => {
+    const libYears = {
+      managers: { gomod: 1.5, npm: 0 },
+      packageFiles: { gomod: { 'go.mod': 1.5 }, npm: { 'package.json': 0 } },
+      depTypes: { gomod: { indirect: 0.5, require: 1 } },
+      total: 1.5,
+    };
+
+    beforeEach(() => {
+      resetCache();
+    });
+
+    it('returns empty string without libyears', () => {
+      expect(
+        dependencyDashboard.getLibYearsMd({ defaultBranch: 'main' }),
+      ).toBe('');
+    });
+
+    it('shows libyears of the first run', () => {
+      getCache().libYears = {
+        main: [
+          {
+            date: '2023-01-01T00:00:00.000Z',
+            libYears,
+            dependencyStatus: { outdated: 2, total: 5 },
+          },
+        ],
+      };
+
+      const result = dependencyDashboard.getLibYearsMd({
+        defaultBranch: 'main',
+      });
+
+      expect(result).toStartWith('## Libyears\n\n');
+      expect(result).toContain(
+        'The dependencies have `1.50` libyears in total, with `2` of `5` dependencies outdated.',
+      );
+      expect(result).toContain('| gomod | `1.50` | - |');
+      expect(result).toContain('| gomod | `go.mod` | `1.50` | - |');
+      expect(result).toContain('| gomod | `indirect` | `0.50` | - |');
+    });
+
+    it('shows the change since the last run', () => {
+      getCache().libYears = {
+        main: [
+          {
+            date: '2023-01-01T00:00:00.000Z',
+            libYears: {
+              managers: { gomod: 2, npm: 0 },
+              packageFiles: { gomod: { 'go.mod': 2 } },
+              depTypes: { gomod: { indirect: 0.5, require: 1.5 } },
+              total: 2,
+            },
+            dependencyStatus: { outdated: 3, total: 5 },
+          },
+        ],
+        next: [
+          {
+            date: '2023-01-02T00:00:00.000Z',
+            libYears,
+            dependencyStatus: { outdated: 2, total: 5 },
+          },
+        ],
+      };
+      getCache().libYears!.main.push({
+        date: '2023-01-02T00:00:00.000Z',
+        libYears: {
+          ...libYears,
+          managers: { ...libYears.managers, npm: 0.25 },
+        },
+        dependencyStatus: { outdated: 3, total: 5 },
+      });
+
+      const result = dependencyDashboard.getLibYearsMd({
+        baseBranches: ['main', 'next'],
+        defaultBranch: 'next',
+      });
+
+      expect(result).toStartWith('## Libyears of `main`\n\n');
+      expect(result).toContain('## Libyears of `next`\n\n');
+      expect(result).toContain(
+        'The dependencies have `1.50` libyears in total (`-0.50` since the last run), with `3` of `5` dependencies outdated.',
+      );
+      expect(result).toContain(
+        'The dependencies have `1.50` libyears in total, with `2` of `5` dependencies outdated.',
+      );
+      expect(result).toContain('| gomod | `1.50` | `-0.50` |');
+      expect(result).toContain('| npm | `0.25` | `+0.25` |');
+      expect(result).toContain('| gomod | `go.mod` | `1.50` | `-0.50` |');
+      expect(result).toContain('| npm | `package.json` | `0.00` | - |');
+      expect(result).toContain('| gomod | `indirect` | `0.50` | `0.00` |');
+      expect(result).toContain('| gomod | `require` | `1.00` | `-0.50` |');
+    });
+  });
+
+  describe('getUnsupportedVersionsMd()', () => {
+    it('returns empty string when all versions are supported', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        gomod: [
+          {
+            packageFile: 'go.mod',
+            deps: [
+              { depName: 'go', currentValue: '1.23', isUnsupported: false },
+            ],
+          },
+        ],
+      };
+
+      expect(dependencyDashboard.getUnsupportedVersionsMd(packageFiles)).toBe(
+        '',
+      );
+    });
+
+    it('lists unsupported versions', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        gomod: [
+          {
+            packageFile: 'go.mod',
+            deps: [
+              { depName: 'go', currentValue: '1.21', isUnsupported: true },
+              {
+                depName: 'go',
+                depType: 'toolchain',
+                currentValue: '1.23.1',
+                isUnsupported: false,
+              },
+            ],
+          },
+        ],
+      };
+
+      const result = dependencyDashboard.getUnsupportedVersionsMd(packageFiles);
+
+      expect(result).toContain('## Unsupported Versions');
+      expect(result).toContain('> ⚠️ **Warning**');
+      expect(result).toContain('| Manager | File | Package | Current value |');
+      expect(result).toContain('| gomod | go.mod | `go` | `1.21` |');
+      expect(result).not.toContain('1.23.1');
+    });
+  });
 });
@@ -12,6 +12,7 @@ import { logger } from '../../logger/index.ts';
 import type { PackageFile } from '../../modules/manager/types.ts';
 import { platform } from '../../modules/platform/index.ts';
 import { coerceArray } from '../../util/array.ts';
+import { getCache } from '../../util/cache/repository/index.ts';
 import { emojify } from '../../util/emoji.ts';
 import { regEx } from '../../util/regex.ts';
 import { coerceString } from '../../util/string.ts';
@@ -21,6 +22,7 @@ import { extractRepoProblems, replacementAlreadyExists } from './common.ts';
 import type { ConfigMigrationResult } from './config-migration/index.ts';
 import { getDepWarningsDashboard } from './errors-warnings.ts';
 import { PackageFiles } from './package-files.ts';
+import { getLibYearsBaseBranch } from './process/libyear.ts';
 import type { Vulnerability } from './process/types.ts';
 import { Vulnerabilities } from './process/vulnerabilities.ts';
 
@@ -426,11 +428,14 @@ export async function ensureDependencyDashboard(
     }
   }
 
+  const unsupportedVersionsMd = getUnsupportedVersionsMd(packageFiles);
+
   const hasBranches = isNonEmptyArray(branches);
   if (
     config.dependencyDashboardAutoclose &&
     !hasBranches &&
-    !hasDeprecationsOrReplacements
+    !hasDeprecationsOrReplacements &&
+    !unsupportedVersionsMd
   ) {
     if (GlobalConfig.get('dryRun')) {
       logger.info(
@@ -482,10 +487,16 @@ export async function ensureDependencyDashboard(
     issueBody += '\n';
   }
 
+  issueBody += unsupportedVersionsMd;
+
   if (config.dependencyDashboardReportAbandonment) {
     issueBody += getAbandonedPackagesMd(config, packageFiles);
   }
 
+  if (config.dependencyDashboardLibYears) {
+    issueBody += getLibYearsMd(config);
+  }
+
   issueBody += getBranchesListMd(
     branches,
     (branch) => branch.result === 'needs-approval',
@@ -687,10 +698,15 @@ export function getAbandonedPackagesMd(
     string,
     Record<
       string,
-      { mostRecentTimestamp?: string | null; sourceUrl?: string | null }
+      {
+        mostRecentTimestamp?: string | null;
+        sourceUrl?: string | null;
+        abandonmentReasons?: string[];
+      }
     >
   > = {};
   let abandonedCount = 0;
+  let hasReasons = false;
 
   for (const [manager, managerPackageFiles] of Object.entries(packageFiles)) {
     for (const packageFile of managerPackageFiles) {
@@ -701,7 +717,9 @@ export function getAbandonedPackagesMd(
           abandonedPackages[manager][dep.depName] = {
             mostRecentTimestamp: dep.mostRecentTimestamp,
             sourceUrl: dep.sourceUrl,
+            abandonmentReasons: dep.abandonmentReasons,
           };
+          hasReasons ||= !!dep.abandonmentReasons?.length;
         }
       }
     }
@@ -718,23 +736,37 @@ export function getAbandonedPackagesMd(
   abandonedMd += '<details>\n';
   abandonedMd += `<summary>View abandoned dependencies (${abandonedCount})</summary>\n\n`;
 
+  const documentation = GlobalConfig.get('productLinks').documentation;
   abandonedMd += emojify('> :information_source: **Note**\n> \n');
-  abandonedMd += `Packages are marked as abandoned when they exceed the [\`abandonmentThreshold\`](${GlobalConfig.get('productLinks').documentation}configuration-options/#abandonmentthreshold) since their last release. `;
-  abandonedMd +=
-    'Unlike deprecated packages with official notices, abandonment is detected by release inactivity.\n> \n';
-
-  abandonedMd += '| Datasource | Package | Last Updated |\n';
-  abandonedMd += '|------------|------|-------------|\n';
+  // the reasons are only known when the source repository activity is checked
+  if (hasReasons) {
+    abandonedMd += `Packages are marked as abandoned when their source repository is archived, or when both their last release and the last commit of their source repository exceed the [\`abandonmentThreshold\`](${documentation}configuration-options/#abandonmentthreshold). `;
+    abandonedMd +=
+      'Unlike deprecated packages with official notices, abandonment is detected by release and source repository activity.\n> \n';
+    abandonedMd += '| Datasource | Package | Last Updated | Reason |\n';
+    abandonedMd += '|------------|------|-------------|--------|\n';
+  } else {
+    abandonedMd += `Packages are marked as abandoned when they exceed the [\`abandonmentThreshold\`](${documentation}configuration-options/#abandonmentthreshold) since their last release. `;
+    abandonedMd +=
+      'Unlike deprecated packages with official notices, abandonment is detected by release inactivity.\n> \n';
+    abandonedMd += '| Datasource | Package | Last Updated |\n';
+    abandonedMd += '|------------|------|-------------|\n';
+  }
 
   for (const manager of Object.keys(abandonedPackages).sort()) {
     const deps = abandonedPackages[manager];
     for (const depName of Object.keys(deps).sort()) {
-      const { mostRecentTimestamp, sourceUrl } = deps[depName];
+      const { mostRecentTimestamp, sourceUrl, abandonmentReasons } =
+        deps[depName];
       const formattedDate = mostRecentTimestamp
         ? DateTime.fromISO(mostRecentTimestamp).toFormat('yyyy-MM-dd')
         : 'unknown';
       const packageName = formatAsMarkdownLink(depName, sourceUrl);
-      abandonedMd += `| ${manager} | ${packageName} | \`${formattedDate}\` |\n`;
+      abandonedMd += `| ${manager} | ${packageName} | \`${formattedDate}\` |`;
+      if (hasReasons) {
+        abandonedMd += ` ${coerceArray(abandonmentReasons).join(', ')} |`;
+      }
+      abandonedMd += '\n';
     }
   }
 
@@ -743,6 +775,135 @@ export function getAbandonedPackagesMd(
   return abandonedMd;
 }
 
+export function getUnsupportedVersionsMd(
+  packageFiles: Record<string, PackageFile[]>,
+): string {
+  const rows: string[] = [];
+
+  for (const manager of Object.keys(packageFiles).sort()) {
+    for (const packageFile of packageFiles[manager]) {
+      for (const dep of coerceArray(packageFile.deps)) {
+        if (dep.depName && dep.isUnsupported) {
+          const packageName = formatAsMarkdownLink(dep.depName, dep.sourceUrl);
+          rows.push(
+            `| ${manager} | ${packageFile.packageFile} | ${packageName} | \`${dep.currentValue}\` |\n`,
+          );
+        }
+      }
+    }
+  }
+
+  if (rows.length === 0) {
+    return '';
+  }
+
+  let unsupportedMd = '## Unsupported Versions\n';
+  unsupportedMd += emojify('> :warning: **Warning**\n> \n');
+  unsupportedMd +=
+    'The current versions of the following dependencies are no longer supported by their maintainers.\n\n';
+  unsupportedMd += '| Manager | File | Package | Current value |\n';
+  unsupportedMd += '|---------|------|---------|---------------|\n';
+  unsupportedMd += rows.join('');
+  unsupportedMd += '\n';
+
+  return unsupportedMd;
+}
+
+function formatLibYearsChange(
+  value: number,
+  previous: number | undefined,
+): string {
+  if (previous === undefined) {
+    return '-';
+  }
+  const change = Math.round((value - previous) * 100) / 100;
+  if (change === 0) {
+    return '`0.00`';
+  }
+  return `\`${change > 0 ? '+' : ''}${change.toFixed(2)}\``;
+}
+
+function getLibYearsRows(
+  current: Record<string, Record<string, number>>,
+  previous: Record<string, Record<string, number>> | undefined,
+): string {
+  let rows = '';
+  for (const manager of Object.keys(current).sort()) {
+    for (const group of Object.keys(current[manager]).sort()) {
+      const value = current[manager][group];
+      const change = formatLibYearsChange(value, previous?.[manager]?.[group]);
+      rows += `| ${manager} | \`${group}\` | \`${value.toFixed(2)}\` | ${change} |\n`;
+    }
+  }
+  return rows;
+}
+
+function getBaseBranchLibYearsMd(
+  config: RenovateConfig,
+  heading: string,
+): string {
+  const history = getCache().libYears?.[getLibYearsBaseBranch(config)!];
+  const current = history?.at(-1);
+  if (!current) {
+    return '';
+  }
+  // libyears of the previous run, if any
+  const previous = history!.at(-2);
+  const { libYears, dependencyStatus } = current;
+
+  let libYearsMd = `## ${heading}\n\n`;
+  libYearsMd += `The dependencies have \`${libYears.total.toFixed(2)}\` libyears in total`;
+  if (previous) {
+    libYearsMd += ` (${formatLibYearsChange(libYears.total, previous.libYears.total)} since the last run)`;
+  }
+  libYearsMd += `, with \`${dependencyStatus.outdated}\` of \`${dependencyStatus.total}\` dependencies outdated.\n\n`;
+
+  libYearsMd += '<details>\n';
+  libYearsMd +=
+    '<summary>View libyears by manager, package file and dependency type</summary>\n\n';
+
+  libYearsMd += '| Manager | Libyears | Change |\n';
+  libYearsMd += '|---------|----------|--------|\n';
+  for (const manager of Object.keys(libYears.managers).sort()) {
+    const value = libYears.managers[manager];
+    const change = formatLibYearsChange(
+      value,
+      previous?.libYears.managers[manager],
+    );
+    libYearsMd += `| ${manager} | \`${value.toFixed(2)}\` | ${change} |\n`;
+  }
+
+  libYearsMd += '\n| Manager | Package file | Libyears | Change |\n';
+  libYearsMd += '|---------|--------------|----------|--------|\n';
+  libYearsMd += getLibYearsRows(
+    libYears.packageFiles,
+    previous?.libYears.packageFiles,
+  );
+
+  libYearsMd += '\n| Manager | Dependency type | Libyears | Change |\n';
+  libYearsMd += '|---------|-----------------|----------|--------|\n';
+  libYearsMd += getLibYearsRows(libYears.depTypes, previous?.libYears.depTypes);
+
+  libYearsMd += '\n</details>\n\n';
+
+  return libYearsMd;
+}
+
+export function getLibYearsMd(config: RenovateConfig): string {
+  // without `baseBranchPatterns`, only the default branch is processed
+  if (!config.baseBranches?.length) {
+    return getBaseBranchLibYearsMd(config, 'Libyears');
+  }
+  return config.baseBranches
+    .map((baseBranch) =>
+      getBaseBranchLibYearsMd(
+        { ...config, baseBranch },
+        `Libyears of \`${baseBranch}\``,
+      ),
+    )
+    .join('');
+}
+
 function getFooter(config: RenovateConfig): string {
   let footer = '';
   if (config.dependencyDashboardFooter?.length) {
@@ -835,11 +996,18 @@ export async function getDashboardMarkdownVulnerabilities(
       result += `<details><summary>${packageFile}</summary>\n<blockquote>\n\n`;
       for (const [packageName, cves] of Object.entries(packageNameRecords)) {
         result += `<details><summary>${packageName}</summary>\n<blockquote>\n\n`;
-        for (const vul of cves) {
+        // unreachable vulnerabilities are listed last
+        const sortedCves = [...cves].sort(
+          (a, b) => Number(a.reachable === false) - Number(b.reachable === false),
+        );
+        for (const vul of sortedCves) {
           const id = vul.vulnerability.id;
-          const suffix = isNonEmptyString(vul.fixedVersion)
+          let suffix = isNonEmptyString(vul.fixedVersion)
             ? ` (fixed in ${vul.fixedVersion})`
             : '';
+          if (vul.reachable === false) {
+            suffix += ' (not reachable)';
+          }
           result += `- [${id}](https://osv.dev/vulnerability/${id})${suffix}\n`;
         }
         result += `</blockquote>\n</details>\n\n`;
@@ -1,16 +1,23 @@
 import type { RenovateConfig } from '~test/util.ts';
 import { logger } from '~test/util.ts';
+import { recordLibYears } from '../../../instrumentation/metrics.ts';
 import { addLibYears } from '../../../instrumentation/reporting.ts';
 import type { PackageFile } from '../../../modules/manager/types.ts';
+import { getCache, resetCache } from '../../../util/cache/repository/index.ts';
 import type { Timestamp } from '../../../util/timestamp.ts';
-import { calculateLibYears } from './libyear.ts';
+import { calculateLibYears, getLibYearsBaseBranch } from './libyear.ts';
 
+vi.mock('../../../instrumentation/metrics.ts');
 vi.mock('../../../instrumentation/reporting.ts');
 
 describe('workers/repository/process/libyear', () => {
   const config: RenovateConfig = {};
 
   describe('calculateLibYears', () => {
+    beforeEach(() => {
+      resetCache();
+    });
+
     it('returns early if no packageFiles', () => {
       calculateLibYears(config, undefined);
       expect(logger.logger.debug).not.toHaveBeenCalled();
@@ -115,6 +122,12 @@ describe('workers/repository/process/libyear', () => {
               dockerfile: 0,
               npm: 1,
             },
+            packageFiles: {
+              bundler: { Gemfile: 0.5027322404371585 },
+              dockerfile: { Dockerfile: 0 },
+              npm: { 'package.json': 1 },
+            },
+            depTypes: {},
             total: 1.5027322404371586,
           },
           dependencyStatus: {
@@ -131,6 +144,12 @@ describe('workers/repository/process/libyear', () => {
             dockerfile: 0,
             npm: 1,
           },
+          packageFiles: {
+            bundler: { Gemfile: 0.5027322404371585 },
+            dockerfile: { Dockerfile: 0 },
+            npm: { 'package.json': 1 },
+          },
+          depTypes: {},
           total: 1.5027322404371586,
         },
         dependencyStatus: {
@@ -199,6 +218,10 @@ describe('workers/repository/process/libyear', () => {
             managers: {
               npm: 1,
             },
+            packageFiles: {
+              npm: { 'package.json': 1 },
+            },
+            depTypes: {},
             total: 1,
           },
           dependencyStatus: {
@@ -213,6 +236,10 @@ describe('workers/repository/process/libyear', () => {
           managers: {
             npm: 1,
           },
+          packageFiles: {
+            npm: { 'package.json': 1 },
+          },
+          depTypes: {},
           total: 1,
         },
         dependencyStatus: {
@@ -290,6 +317,11 @@ describe('workers/repository/process/libyear', () => {
               npm: 1,
               regex: 1,
             },
+            packageFiles: {
+              npm: { 'folder1/package.json': 1, 'folder2/package.json': 1 },
+              regex: { 'folder3/package.json': 1 },
+            },
+            depTypes: {},
             total: 2,
           },
           dependencyStatus: {
@@ -333,6 +365,8 @@ describe('workers/repository/process/libyear', () => {
         {
           libYears: {
             managers: {},
+            packageFiles: {},
+            depTypes: {},
             total: 0,
           },
           dependencyStatus: {
@@ -343,5 +377,123 @@ describe('workers/repository/process/libyear', () => {
         'Repository libYears',
       );
     });
+
+    it('calculates libYears per dependency type', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        gomod: [
+          {
+            packageFile: 'go.mod',
+            deps: [
+              {
+                depName: 'github.com/some/direct',
+                depType: 'require',
+                datasource: 'go',
+                currentVersion: 'v1.0.0',
+                currentVersionTimestamp: '2019-07-01T00:00:00Z',
+                updates: [
+                  {
+                    newVersion: 'v2.0.0',
+                    releaseTimestamp: '2020-07-01T00:00:00Z' as Timestamp,
+                  },
+                ],
+              },
+              {
+                depName: 'github.com/some/indirect',
+                depType: 'indirect',
+                datasource: 'go',
+                currentVersion: 'v1.0.0',
+                currentVersionTimestamp: '2019-07-01T00:00:00Z',
+                updates: [
+                  {
+                    newVersion: 'v1.1.0',
+                    releaseTimestamp: '2020-01-01T00:00:00Z' as Timestamp,
+                  },
+                ],
+              },
+              {
+                depName: 'github.com/some/up-to-date',
+                depType: 'indirect',
+                datasource: 'go',
+                currentVersion: 'v1.0.0',
+              },
+            ],
+          },
+        ],
+      };
+
+      calculateLibYears(
+        { repository: 'some/repo', baseBranch: 'main' },
+        packageFiles,
+      );
+
+      expect(recordLibYears).toHaveBeenCalledExactlyOnceWith(
+        'some/repo',
+        'main',
+        {
+          libYears: {
+            managers: { gomod: 1.5027322404371586 },
+            packageFiles: { gomod: { 'go.mod': 1.5027322404371586 } },
+            depTypes: {
+              gomod: { indirect: 0.5027322404371585, require: 1 },
+            },
+            total: 1.5027322404371586,
+          },
+          dependencyStatus: { outdated: 2, total: 3 },
+        },
+      );
+    });
+
+    it('keeps the libYears of previous runs in the repository cache', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        npm: [
+          {
+            packageFile: 'package.json',
+            deps: [
+              { depName: 'dep1', datasource: 'npm', currentValue: '1.0.0' },
+            ],
+          },
+        ],
+      };
+
+      for (let i = 0; i < 12; i++) {
+        calculateLibYears({ defaultBranch: 'main' }, packageFiles);
+      }
+      calculateLibYears(
+        { defaultBranch: 'main', baseBranch: 'next' },
+        packageFiles,
+      );
+
+      const { libYears } = getCache();
+      expect(libYears?.main).toHaveLength(10);
+      expect(libYears?.next).toEqual([
+        {
+          date: expect.any(String),
+          libYears: {
+            managers: { npm: 0 },
+            packageFiles: { npm: { 'package.json': 0 } },
+            depTypes: {},
+            total: 0,
+          },
+          dependencyStatus: { outdated: 0, total: 1 },
+        },
+      ]);
+    });
+
+    it('does not keep libYears without base branch', () => {
+      calculateLibYears(config, {});
+
+      expect(getCache().libYears).toBeUndefined();
+    });
+  });
+
+  describe('getLibYearsBaseBranch', () => {
+    it.each`
+      config                                           | expected
+      ${{ defaultBranch: 'main' }}                     | ${'main'}
+      ${{ defaultBranch: 'main', baseBranch: 'next' }} | ${'next'}
+      ${{}}                                            | ${undefined}
+    `('getLibYearsBaseBranch($config)', ({ config, expected }) => {
+      expect(getLibYearsBaseBranch(config)).toBe(expected);
+    });
   });
 });
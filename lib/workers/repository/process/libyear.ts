@@ -1,9 +1,14 @@
 import { DateTime } from 'luxon';
 import type { RenovateConfig } from '../../../config/types.ts';
+import { recordLibYears } from '../../../instrumentation/metrics.ts';
 import { addLibYears } from '../../../instrumentation/reporting.ts';
 import type { LibYearsWithStatus } from '../../../instrumentation/types.ts';
 import { logger } from '../../../logger/index.ts';
 import type { PackageFile } from '../../../modules/manager/types.ts';
+import { getCache } from '../../../util/cache/repository/index.ts';
+
+/** Number of runs kept in the repository cache per base branch */
+const libYearsHistoryLength = 10;
 
 interface DepInfo {
   depName: string;
@@ -11,6 +16,7 @@ interface DepInfo {
   datasource: string;
   version: string;
   file: string;
+  depType?: string;
   outdated?: boolean;
   libYear?: number;
 }
@@ -34,6 +40,7 @@ export function calculateLibYears(
           depName: dep.depName!,
           manager,
           file: file.packageFile,
+          depType: dep.depType,
           datasource: dep.datasource!,
           version: (dep.currentVersion ?? dep.currentValue)!,
         };
@@ -83,6 +90,32 @@ export function calculateLibYears(
   logger.debug(libYearsWithStatus, 'Repository libYears');
 
   addLibYears(config, libYearsWithStatus);
+
+  const baseBranch = getLibYearsBaseBranch(config);
+  recordLibYears(config.repository!, baseBranch, libYearsWithStatus);
+  if (baseBranch) {
+    addLibYearsHistory(baseBranch, libYearsWithStatus);
+  }
+}
+
+/**
+ * Returns the branch whose libyears are kept in the repository cache, which is the default branch without `baseBranchPatterns`.
+ */
+export function getLibYearsBaseBranch(
+  config: RenovateConfig,
+): string | undefined {
+  return config.baseBranch ?? config.defaultBranch;
+}
+
+function addLibYearsHistory(
+  baseBranch: string,
+  libYearsWithStatus: LibYearsWithStatus,
+): void {
+  const cache = getCache();
+  cache.libYears ??= {};
+  const history = cache.libYears[baseBranch] ?? [];
+  history.push({ date: new Date().toISOString(), ...libYearsWithStatus });
+  cache.libYears[baseBranch] = history.slice(-libYearsHistoryLength);
 }
 
 function getLibYears(allDeps: DepInfo[]): LibYearsWithStatus {
@@ -92,6 +125,8 @@ function getLibYears(allDeps: DepInfo[]): LibYearsWithStatus {
   return {
     libYears: {
       managers: managerLibYears,
+      packageFiles: getGroupedLibYears(allDeps, (dep) => dep.file),
+      depTypes: getGroupedLibYears(allDeps, (dep) => dep.depType),
       total: totalLibYears,
     },
     dependencyStatus: {
@@ -124,6 +159,34 @@ function getManagerLibYears(deps: DepInfo[]): Record<string, number> {
   return res;
 }
 
+/**
+ * Sums libyears per manager and group, counting each dependency once per group.
+ * Dependencies without a group are skipped.
+ */
+function getGroupedLibYears(
+  deps: DepInfo[],
+  getGroup: (dep: DepInfo) => string | undefined,
+): Record<string, Record<string, number>> {
+  const res: Record<string, Record<string, number>> = {};
+  const distinctDeps = new Set<string>();
+  for (const dep of deps) {
+    const group = getGroup(dep);
+    if (!group) {
+      continue;
+    }
+
+    const depKey = `${dep.manager}@${group}@${dep.depName}@${dep.version}@${dep.datasource}`;
+    res[dep.manager] ??= {};
+    res[dep.manager][group] ??= 0;
+    if (dep.libYear && !distinctDeps.has(depKey)) {
+      res[dep.manager][group] += dep.libYear;
+    }
+    distinctDeps.add(depKey);
+  }
+
+  return res;
+}
+
 function getCounts(deps: DepInfo[]): [number, number, number] {
   const distinctDeps = new Set<string>();
   let totalDepsCount = 0,
@@ -1,9 +1,14 @@
 import { DateTime } from 'luxon';
 import type { ReleaseResult } from '../../../../modules/datasource/types.ts';
 import { asTimestamp } from '../../../../util/timestamp.ts';
-import { calculateAbandonment } from './abandonment.ts';
+import { calculateAbandonment, getAbandonmentVerdict } from './abandonment.ts';
+import * as _sourceActivity from './source-activity.ts';
 import type { LookupUpdateConfig } from './types.ts';
 
+vi.mock('./source-activity.ts');
+
+const sourceActivity = vi.mocked(_sourceActivity);
+
 describe('workers/repository/process/lookup/abandonment', () => {
   describe('calculateAbandonment', () => {
     const mockDate = '2023-01-01T00:00:00.000Z';
@@ -24,25 +29,25 @@ describe('workers/repository/process/lookup/abandonment', () => {
       rangeStrategy: 'auto',
     };
 
-    it('returns the original release result when no abandonment threshold is provided', () => {
+    it('returns the original release result when no abandonment threshold is provided', async () => {
       const releaseResult: ReleaseResult = {
         releases: [{ version: '1.0.0' }],
         mostRecentTimestamp: asTimestamp('2022-01-01T00:00:00.000Z')!,
       };
 
-      const result = calculateAbandonment(releaseResult, config);
+      const result = await calculateAbandonment(releaseResult, config);
 
       expect(result).toBe(releaseResult);
       expect(result.isAbandoned).toBeUndefined();
     });
 
-    it('returns the original release result when abandonment threshold is invalid', () => {
+    it('returns the original release result when abandonment threshold is invalid', async () => {
       const releaseResult: ReleaseResult = {
         releases: [{ version: '1.0.0' }],
         mostRecentTimestamp: asTimestamp('2022-01-01T00:00:00.000Z')!,
       };
 
-      const result = calculateAbandonment(releaseResult, {
+      const result = await calculateAbandonment(releaseResult, {
         ...config,
         abandonmentThreshold: 'invalid',
       });
@@ -51,12 +56,12 @@ describe('workers/repository/process/lookup/abandonment', () => {
       expect(result.isAbandoned).toBeUndefined();
     });
 
-    it('returns the original release result when no mostRecentTimestamp timestamp is available', () => {
+    it('returns the original release result when no mostRecentTimestamp timestamp is available', async () => {
       const releaseResult: ReleaseResult = {
         releases: [{ version: '1.0.0' }],
       };
 
-      const result = calculateAbandonment(releaseResult, {
+      const result = await calculateAbandonment(releaseResult, {
         ...config,
 
         abandonmentThreshold: '1 year',
@@ -66,13 +71,13 @@ describe('workers/repository/process/lookup/abandonment', () => {
       expect(result.isAbandoned).toBeUndefined();
     });
 
-    it('marks a package as abandoned when mostRecentTimestamp plus threshold is before now', () => {
+    it('marks a package as abandoned when mostRecentTimestamp plus threshold is before now', async () => {
       const releaseResult: ReleaseResult = {
         releases: [{ version: '1.0.0' }],
         mostRecentTimestamp: asTimestamp('2020-01-01T00:00:00.000Z')!, // 3 years before mocked now
       };
 
-      const result = calculateAbandonment(releaseResult, {
+      const result = await calculateAbandonment(releaseResult, {
         ...config,
         abandonmentThreshold: '2 years',
       });
@@ -80,13 +85,13 @@ describe('workers/repository/process/lookup/abandonment', () => {
       expect(result.isAbandoned).toBe(true);
     });
 
-    it('does not mark a package as abandoned when mostRecentTimestamp plus threshold is after now', () => {
+    it('does not mark a package as abandoned when mostRecentTimestamp plus threshold is after now', async () => {
       const releaseResult: ReleaseResult = {
         releases: [{ version: '1.0.0' }],
         mostRecentTimestamp: asTimestamp('2022-06-01T00:00:00.000Z')!, // 6 months before mocked now
       };
 
-      const result = calculateAbandonment(releaseResult, {
+      const result = await calculateAbandonment(releaseResult, {
         ...config,
         abandonmentThreshold: '1 year',
       });
@@ -94,7 +99,7 @@ describe('workers/repository/process/lookup/abandonment', () => {
       expect(result.isAbandoned).toBe(false);
     });
 
-    it('preserves other properties in the release result', () => {
+    it('preserves other properties in the release result', async () => {
       const releaseResult: ReleaseResult = {
         releases: [{ version: '1.0.0' }],
         mostRecentTimestamp: asTimestamp('2020-01-01T00:00:00.000Z')!,
@@ -103,7 +108,7 @@ describe('workers/repository/process/lookup/abandonment', () => {
         tags: { latest: '1.0.0' },
       };
 
-      const result = calculateAbandonment(releaseResult, {
+      const result = await calculateAbandonment(releaseResult, {
         ...config,
         abandonmentThreshold: '1 year',
       });
@@ -114,7 +119,7 @@ describe('workers/repository/process/lookup/abandonment', () => {
       expect(result.tags).toEqual({ latest: '1.0.0' });
     });
 
-    it('handles exactly at the threshold boundary', () => {
+    it('handles exactly at the threshold boundary', async () => {
       const twoYearsAgo = DateTime.fromISO('2021-01-01T00:00:00.000Z')
         .minus({ years: 2 })
         .toISO();
@@ -124,12 +129,142 @@ describe('workers/repository/process/lookup/abandonment', () => {
         mostRecentTimestamp: asTimestamp(twoYearsAgo)!,
       };
 
-      const result = calculateAbandonment(releaseResult, {
+      const result = await calculateAbandonment(releaseResult, {
         ...config,
         abandonmentThreshold: '2 years',
       });
 
       expect(result.isAbandoned).toBe(true);
     });
+
+    it('does not fetch source activity by default', async () => {
+      const releaseResult: ReleaseResult = {
+        releases: [{ version: '1.0.0' }],
+        mostRecentTimestamp: asTimestamp('2020-01-01T00:00:00.000Z')!,
+        sourceUrl: 'https://github.com/some/repo',
+      };
+
+      const result = await calculateAbandonment(releaseResult, {
+        ...config,
+        abandonmentThreshold: '1 year',
+      });
+
+      expect(result.isAbandoned).toBe(true);
+      expect(result.abandonmentReasons).toBeUndefined();
+      expect(sourceActivity.getSourceActivity).not.toHaveBeenCalled();
+    });
+
+    it('uses the source activity when enabled', async () => {
+      sourceActivity.getSourceActivity.mockResolvedValueOnce({
+        archived: false,
+        lastCommitTimestamp: '2022-10-01T00:00:00.000Z',
+      });
+      const releaseResult: ReleaseResult = {
+        releases: [{ version: '1.0.0' }],
+        mostRecentTimestamp: asTimestamp('2020-01-01T00:00:00.000Z')!,
+        sourceUrl: 'https://github.com/some/repo',
+      };
+
+      const result = await calculateAbandonment(releaseResult, {
+        ...config,
+        abandonmentThreshold: '1 year',
+        abandonmentSourceActivity: true,
+      });
+
+      expect(result.isAbandoned).toBe(false);
+      expect(result.abandonmentReasons).toEqual([
+        'no release since 2020-01-01',
+        'last commit on 2022-10-01',
+      ]);
+      expect(sourceActivity.getSourceActivity).toHaveBeenCalledWith(
+        'https://github.com/some/repo',
+      );
+    });
+
+    it('marks archived packages without release timestamp as abandoned', async () => {
+      sourceActivity.getSourceActivity.mockResolvedValueOnce({
+        archived: true,
+      });
+      const releaseResult: ReleaseResult = {
+        releases: [{ version: '1.0.0' }],
+        sourceUrl: 'https://github.com/some/repo',
+      };
+
+      const result = await calculateAbandonment(releaseResult, {
+        ...config,
+        abandonmentThreshold: '1 year',
+        abandonmentSourceActivity: true,
+      });
+
+      expect(result.isAbandoned).toBe(true);
+      expect(result.abandonmentReasons).toEqual([
+        'source repository is archived',
+      ]);
+    });
+  });
+
+  describe('getAbandonmentVerdict', () => {
+    const now = DateTime.fromISO('2023-01-01T00:00:00.000Z', { zone: 'utc' });
+    const yearMs = 365 * 24 * 60 * 60 * 1000;
+
+    it('returns null without any timestamps', () => {
+      expect(getAbandonmentVerdict(undefined, null, yearMs, now)).toBeNull();
+      expect(
+        getAbandonmentVerdict(undefined, { archived: false }, yearMs, now),
+      ).toBeNull();
+    });
+
+    it('requires both release and commit to be stale', () => {
+      expect(
+        getAbandonmentVerdict(
+          '2020-01-01T00:00:00.000Z',
+          {
+            archived: false,
+            lastCommitTimestamp: '2020-06-01T00:00:00.000Z',
+            lastIssueActivityTimestamp: '2022-12-01T00:00:00.000Z',
+          },
+          yearMs,
+          now,
+        ),
+      ).toEqual({
+        isAbandoned: true,
+        reasons: [
+          'no release since 2020-01-01',
+          'no commits since 2020-06-01',
+          'open issues last updated on 2022-12-01',
+        ],
+      });
+    });
+
+    it('is not abandoned with a recent release', () => {
+      expect(
+        getAbandonmentVerdict(
+          '2022-06-01T00:00:00.000Z',
+          { archived: false, lastCommitTimestamp: '2020-06-01T00:00:00.000Z' },
+          yearMs,
+          now,
+        ),
+      ).toEqual({
+        isAbandoned: false,
+        reasons: ['last release on 2022-06-01', 'no commits since 2020-06-01'],
+      });
+    });
+
+    it('is abandoned when archived', () => {
+      expect(
+        getAbandonmentVerdict(
+          '2022-06-01T00:00:00.000Z',
+          { archived: true },
+          yearMs,
+          now,
+        ),
+      ).toEqual({
+        isAbandoned: true,
+        reasons: [
+          'source repository is archived',
+          'last release on 2022-06-01',
+        ],
+      });
+    });
   });
 });
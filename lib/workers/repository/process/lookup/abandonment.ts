@@ -3,12 +3,84 @@ import { logger } from '../../../../logger/index.ts';
 import type { ReleaseResult } from '../../../../modules/datasource/types.ts';
 import { toMs } from '../../../../util/pretty-time.ts';
 import { AbandonedPackageStats } from '../../../../util/stats.ts';
+import type { SourceActivity } from './source-activity.ts';
+import { getSourceActivity } from './source-activity.ts';
 import type { LookupUpdateConfig } from './types.ts';
 
-export function calculateAbandonment(
+export interface AbandonmentVerdict {
+  isAbandoned: boolean;
+  reasons: string[];
+}
+
+function formatDate(timestamp: string): string {
+  return DateTime.fromISO(timestamp).toFormat('yyyy-MM-dd');
+}
+
+/**
+ * Combines the most recent release with the source repository activity.
+ *
+ * An archived repository is always abandoned. Otherwise, a package is only
+ * abandoned when both its last release and its last commit are older than the
+ * threshold. Open issue activity is reported, but doesn't change the verdict.
+ */
+export function getAbandonmentVerdict(
+  mostRecentTimestamp: string | undefined,
+  activity: SourceActivity | null,
+  abandonmentThresholdMs: number,
+  now: DateTime,
+): AbandonmentVerdict | null {
+  const isStale = (timestamp: string): boolean => {
+    const date = DateTime.fromISO(timestamp);
+    return date.plus({ milliseconds: abandonmentThresholdMs }) < now;
+  };
+
+  const reasons: string[] = [];
+  const timestamps: string[] = [];
+
+  if (activity?.archived) {
+    reasons.push('source repository is archived');
+  }
+
+  if (mostRecentTimestamp) {
+    timestamps.push(mostRecentTimestamp);
+    const date = formatDate(mostRecentTimestamp);
+    reasons.push(
+      isStale(mostRecentTimestamp)
+        ? `no release since ${date}`
+        : `last release on ${date}`,
+    );
+  }
+
+  const lastCommitTimestamp = activity?.lastCommitTimestamp;
+  if (lastCommitTimestamp) {
+    timestamps.push(lastCommitTimestamp);
+    const date = formatDate(lastCommitTimestamp);
+    reasons.push(
+      isStale(lastCommitTimestamp)
+        ? `no commits since ${date}`
+        : `last commit on ${date}`,
+    );
+  }
+
+  const lastIssueActivityTimestamp = activity?.lastIssueActivityTimestamp;
+  if (lastIssueActivityTimestamp) {
+    reasons.push(
+      `open issues last updated on ${formatDate(lastIssueActivityTimestamp)}`,
+    );
+  }
+
+  if (!activity?.archived && !timestamps.length) {
+    return null;
+  }
+
+  const isAbandoned = !!activity?.archived || timestamps.every(isStale);
+  return { isAbandoned, reasons };
+}
+
+export async function calculateAbandonment(
   releaseResult: ReleaseResult,
   config: LookupUpdateConfig,
-): ReleaseResult {
+): Promise<ReleaseResult> {
   const { lookupName } = releaseResult;
   const { abandonmentThreshold } = config;
   if (!abandonmentThreshold) {
@@ -28,26 +100,37 @@ export function calculateAbandonment(
     return releaseResult;
   }
 
-  const { mostRecentTimestamp } = releaseResult;
-  if (!mostRecentTimestamp) {
+  const { mostRecentTimestamp, sourceUrl } = releaseResult;
+  const activity =
+    config.abandonmentSourceActivity && sourceUrl
+      ? await getSourceActivity(sourceUrl)
+      : null;
+
+  const now = DateTime.local();
+  const verdict = getAbandonmentVerdict(
+    mostRecentTimestamp,
+    activity,
+    abandonmentThresholdMs,
+    now,
+  );
+  if (!verdict) {
     logger.trace(
       { lookupName },
       'No mostRecentTimestamp value found, skipping abandonment check',
     );
     return releaseResult;
   }
-  const mostRecentTimestampDate = DateTime.fromISO(mostRecentTimestamp);
 
-  const abandonmentDate = mostRecentTimestampDate.plus({
-    milliseconds: abandonmentThresholdMs,
-  });
-  const now = DateTime.local();
-  const isAbandoned = abandonmentDate < now;
+  const { isAbandoned, reasons } = verdict;
   releaseResult.isAbandoned = isAbandoned;
+  // without source activity, the release date is the only reason
+  if (activity) {
+    releaseResult.abandonmentReasons = reasons;
+  }
 
   if (isAbandoned) {
     logger.debug(
-      `Package abandonment detected: ${config.packageName} (${config.datasource}) - most recent release: ${mostRecentTimestamp}`,
+      `Package abandonment detected: ${config.packageName} (${config.datasource}) - ${reasons.join(', ')}`,
     );
   }
 
@@ -55,16 +138,17 @@ export function calculateAbandonment(
     {
       lookupName,
       mostRecentTimestamp,
+      activity,
       abandonmentThreshold,
       abandonmentThresholdMs,
-      abandonmentDate: abandonmentDate.toISO(),
       now: now.toISO(),
       isAbandoned,
+      reasons,
     },
     'Calculated abandonment status',
   );
 
-  if (isAbandoned) {
+  if (isAbandoned && mostRecentTimestamp) {
     const { datasource, packageName } = config;
     AbandonedPackageStats.write(datasource, packageName, mostRecentTimestamp);
   }
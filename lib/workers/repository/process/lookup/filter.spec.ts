@@ -121,6 +121,31 @@ describe('workers/repository/process/lookup/filter', () => {
       expect(filteredVersions).toEqual([{ version: '1.2.3-beta' }]);
     });
 
+    it('keeps unstable versions of a followed branch', () => {
+      const looseVersioning = allVersioning.get('loose');
+
+      const releases = [
+        { version: 'v1.4.2-0.20240601103045-0123456789ab' },
+      ] satisfies Release[];
+
+      const config = partial<FilterConfig>({
+        ignoreUnstable: true,
+        ignoreDeprecated: true,
+        followBranch: 'release-1.x',
+      });
+      const currentVersion = 'v1.4.1-0.20240101000000-abcdef123456';
+
+      const filteredVersions = filterVersions(
+        config,
+        currentVersion,
+        currentVersion,
+        releases,
+        looseVersioning,
+      );
+
+      expect(filteredVersions).toEqual(releases);
+    });
+
     it('ignores version insufficient prefixes', () => {
       const releases = [
         { version: '1.0.1' },
@@ -166,7 +166,7 @@ export function filterVersions(
     }
   }
 
-  if (config.followTag) {
+  if (config.followTag || config.followBranch) {
     return filteredReleases;
   }
 
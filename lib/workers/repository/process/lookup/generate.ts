@@ -31,6 +31,9 @@ export async function generateUpdate(
   if (release.checksumUrl !== undefined) {
     update.checksumUrl = release.checksumUrl;
   }
+  if (release.checksums !== undefined) {
+    update.checksums = release.checksums;
+  }
   // istanbul ignore if
   if (release.downloadUrl !== undefined) {
     update.downloadUrl = release.downloadUrl;
@@ -4001,6 +4001,73 @@ describe('workers/repository/process/lookup/index', () => {
       });
     });
 
+    it('reports the deprecation message of the current release', async () => {
+      config.currentValue = '1.0.0';
+      config.packageName = 'some/action';
+      config.datasource = GithubTagsDatasource.id;
+      getGithubTags.mockResolvedValueOnce({
+        releases: [
+          {
+            version: '1.0.0',
+            isDeprecated: true,
+            deprecationMessage: 'Retracted by the module author: broken build',
+          },
+          { version: '1.0.1' },
+        ],
+      });
+
+      const res = await Result.wrap(
+        lookup.lookupUpdates(config),
+      ).unwrapOrThrow();
+
+      expect(res).toMatchObject({
+        currentVersion: '1.0.0',
+        currentVersionDeprecationMessage:
+          'Retracted by the module author: broken build',
+        updates: [{ newValue: '1.0.1', updateType: 'patch' }],
+      });
+    });
+
+    it('reports a range whose oldest version is unsupported', async () => {
+      config.currentValue = '1.21';
+      config.packageName = 'golang/go';
+      config.datasource = GithubTagsDatasource.id;
+      config.versioning = 'go-mod-directive';
+      getGithubTags.mockResolvedValueOnce({
+        releases: [
+          { version: '1.21.0', isUnsupported: true },
+          { version: '1.21.1', isUnsupported: true },
+          { version: '1.22.0' },
+          { version: '1.23.0' },
+        ],
+      });
+
+      const res = await Result.wrap(
+        lookup.lookupUpdates(config),
+      ).unwrapOrThrow();
+
+      expect(res.isUnsupported).toBeTrue();
+    });
+
+    it('reports a supported version', async () => {
+      config.currentValue = '1.22.0';
+      config.packageName = 'golang/go';
+      config.datasource = GithubTagsDatasource.id;
+      getGithubTags.mockResolvedValueOnce({
+        releases: [
+          { version: '1.21.0', isUnsupported: true },
+          { version: '1.22.0' },
+          { version: '1.23.0' },
+        ],
+      });
+
+      const res = await Result.wrap(
+        lookup.lookupUpdates(config),
+      ).unwrapOrThrow();
+
+      expect(res.isUnsupported).toBeFalse();
+    });
+
     it('skips unsupported values', async () => {
       config.currentValue = 'alpine';
       config.packageName = 'node';
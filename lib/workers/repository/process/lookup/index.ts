@@ -1,4 +1,9 @@
-import { isNonEmptyString, isString, isUndefined } from '@sindresorhus/is';
+import {
+  isBoolean,
+  isNonEmptyString,
+  isString,
+  isUndefined,
+} from '@sindresorhus/is';
 import { mergeChildConfig } from '../../../../config/index.ts';
 import type { ValidationMessage } from '../../../../config/types.ts';
 import { CONFIG_VALIDATION } from '../../../../constants/error-messages.ts';
@@ -324,6 +329,7 @@ export async function lookupUpdates(
         'packageScope',
         'mostRecentTimestamp',
         'isAbandoned',
+        'abandonmentReasons',
         'respectLatest',
       ]);
 
@@ -462,6 +468,38 @@ export async function lookupUpdates(
 
       res.currentVersion = currentVersion;
 
+      const currentRelease = allVersions.find(
+        (v) => v.version === currentVersion,
+      );
+      if (currentRelease?.isDeprecated && currentRelease.deprecationMessage) {
+        res.currentVersionDeprecationMessage =
+          currentRelease.deprecationMessage;
+      }
+
+      // only datasources which know the support window mark releases
+      if (allVersions.some((v) => v.isUnsupported)) {
+        // a range such as `go 1.21` is only as supported as the oldest version it allows
+        const oldestVersion =
+          compareValue && !versioningApi.isVersion(compareValue)
+            ? (versioningApi.minSatisfyingVersion(
+                allVersions.map((v) => v.version),
+                compareValue,
+              ) ?? currentVersion)
+            : currentVersion;
+        res.isUnsupported = !!allVersions.find(
+          (v) => v.version === oldestVersion,
+        )?.isUnsupported;
+      }
+      if (
+        config.packageRules?.some((rule) => isBoolean(rule.matchUnsupported))
+      ) {
+        // Reapply package rules to check matches for matchUnsupported
+        config = await applyPackageRules(
+          { ...config, isUnsupported: !!res.isUnsupported },
+          'current-support',
+        );
+      }
+
       // Use lockedVersion for the timestamp lookup when available, because
       // res.currentVersion is later overwritten to lockedVersion (see below).
       // Without this, strategies like "replace" would compute the timestamp
@@ -0,0 +1,108 @@
+import * as httpMock from '~test/http-mock.ts';
+import { getSourceActivity } from './source-activity.ts';
+
+describe('workers/repository/process/lookup/source-activity', () => {
+  it('returns null for unsupported platforms', async () => {
+    expect(
+      await getSourceActivity('https://bitbucket.org/some/repo'),
+    ).toBeNull();
+  });
+
+  it('returns null for invalid urls', async () => {
+    expect(await getSourceActivity('not a url')).toBeNull();
+  });
+
+  it('fetches github activity', async () => {
+    httpMock
+      .scope('https://api.github.com/repos/some/repo')
+      .get('')
+      .reply(200, { archived: false, default_branch: 'main' })
+      .get('/commits?sha=main&per_page=1')
+      .reply(200, [{ commit: { committer: { date: '2022-06-01T00:00:00Z' } } }])
+      .get('/issues?state=open&sort=updated&direction=desc&per_page=50')
+      .reply(200, [
+        { updated_at: '2023-01-01T00:00:00Z', pull_request: {} },
+        { updated_at: '2022-12-01T00:00:00Z' },
+      ]);
+
+    expect(
+      await getSourceActivity('https://github.com/some/repo.git'),
+    ).toEqual({
+      archived: false,
+      lastCommitTimestamp: '2022-06-01T00:00:00Z',
+      lastIssueActivityTimestamp: '2022-12-01T00:00:00Z',
+    });
+  });
+
+  it('fetches github enterprise activity', async () => {
+    httpMock
+      .scope('https://github.example.com/api/v3/repos/some/repo')
+      .get('')
+      .reply(200, { archived: true, default_branch: null })
+      .get('/issues?state=open&sort=updated&direction=desc&per_page=50')
+      .reply(200, [{ updated_at: '2023-01-01T00:00:00Z', pull_request: {} }]);
+
+    expect(
+      await getSourceActivity('https://github.example.com/some/repo'),
+    ).toEqual({ archived: true });
+  });
+
+  it('fetches gitlab activity', async () => {
+    httpMock
+      .scope('https://gitlab.com/api/v4/projects/group%2Fsub%2Frepo')
+      .get('')
+      .reply(200, { archived: true, default_branch: 'master' })
+      .get('/repository/commits?ref_name=master&per_page=1')
+      .reply(200, [{ committed_date: '2020-01-01T00:00:00Z' }])
+      .get('/issues?state=opened&order_by=updated_at&sort=desc&per_page=1')
+      .reply(200, []);
+
+    expect(
+      await getSourceActivity('https://gitlab.com/group/sub/repo'),
+    ).toEqual({
+      archived: true,
+      lastCommitTimestamp: '2020-01-01T00:00:00Z',
+    });
+  });
+
+  it('fetches gitea activity', async () => {
+    httpMock
+      .scope('https://gitea.com/api/v1/repos/some/repo')
+      .get('')
+      .reply(200, { archived: false, default_branch: 'main' })
+      .get(
+        '/commits?sha=main&limit=1&stat=false&verification=false&files=false',
+      )
+      .reply(200, [{ commit: { committer: { date: '2022-06-01T00:00:00Z' } } }])
+      .get('/issues?state=open&type=issues&limit=50')
+      .reply(200, [
+        { updated_at: '2022-01-01T00:00:00Z' },
+        { updated_at: '2022-03-01T00:00:00Z' },
+      ]);
+
+    expect(await getSourceActivity('https://gitea.com/some/repo')).toEqual({
+      archived: false,
+      lastCommitTimestamp: '2022-06-01T00:00:00Z',
+      lastIssueActivityTimestamp: '2022-03-01T00:00:00Z',
+    });
+  });
+
+  it('fetches forgejo activity of empty repositories', async () => {
+    httpMock
+      .scope('https://codeberg.org/api/v1/repos/some/repo')
+      .get('')
+      .reply(200, { archived: false, default_branch: null })
+      .get('/issues?state=open&type=issues&limit=50')
+      .reply(200, []);
+
+    expect(await getSourceActivity('https://codeberg.org/some/repo')).toEqual({
+      archived: false,
+    });
+  });
+
+  it('returns null on errors', async () => {
+    httpMock.scope('https://api.github.com/repos/some/repo').get('').reply(404);
+
+    expect(await getSourceActivity('https://github.com/some/repo')).toBeNull();
+  });
+});
@@ -0,0 +1,185 @@
+import { z } from 'zod/v4';
+import { logger } from '../../../../logger/index.ts';
+import { withCache } from '../../../../util/cache/package/with-cache.ts';
+import { detectPlatform } from '../../../../util/common.ts';
+import { getApiBaseUrl } from '../../../../util/github/url.ts';
+import { ForgejoHttp } from '../../../../util/http/forgejo.ts';
+import { GiteaHttp } from '../../../../util/http/gitea.ts';
+import { GithubHttp } from '../../../../util/http/github.ts';
+import { GitlabHttp } from '../../../../util/http/gitlab.ts';
+import { regEx } from '../../../../util/regex.ts';
+import { parseUrl, trimSlashes } from '../../../../util/url.ts';
+
+export interface SourceActivity {
+  archived: boolean;
+  /** Date of the most recent commit on the default branch */
+  lastCommitTimestamp?: string;
+  /** Date of the most recently updated open issue */
+  lastIssueActivityTimestamp?: string;
+}
+
+const Repository = z.object({
+  archived: z.boolean(),
+  default_branch: z.string().nullish(),
+});
+
+const Commits = z.array(
+  z.object({
+    commit: z.object({ committer: z.object({ date: z.string() }) }),
+  }),
+);
+
+const GitlabCommits = z.array(z.object({ committed_date: z.string() }));
+
+const Issues = z.array(z.object({ updated_at: z.string() }));
+
+const GithubIssues = z.array(
+  z.object({ updated_at: z.string(), pull_request: z.unknown().optional() }),
+);
+
+function getLatest(timestamps: string[]): string | undefined {
+  return timestamps.sort().at(-1);
+}
+
+async function getGithubActivity(
+  baseUrl: string,
+  repository: string,
+): Promise<SourceActivity> {
+  const http = new GithubHttp();
+  const url = `${getApiBaseUrl(baseUrl)}repos/${repository}`;
+
+  const { body: repo } = await http.getJson(url, Repository);
+  const result: SourceActivity = { archived: repo.archived };
+
+  if (repo.default_branch) {
+    const { body: commits } = await http.getJson(
+      `${url}/commits?sha=${encodeURIComponent(repo.default_branch)}&per_page=1`,
+      Commits,
+    );
+    result.lastCommitTimestamp = commits[0]?.commit.committer.date;
+  }
+
+  // the issues API also returns pull requests, so look at the most recent page
+  const { body: issues } = await http.getJson(
+    `${url}/issues?state=open&sort=updated&direction=desc&per_page=50`,
+    GithubIssues,
+  );
+  result.lastIssueActivityTimestamp = issues.find(
+    ({ pull_request }) => !pull_request,
+  )?.updated_at;
+
+  return result;
+}
+
+async function getGitlabActivity(
+  baseUrl: string,
+  repository: string,
+): Promise<SourceActivity> {
+  const http = new GitlabHttp();
+  const url = `${baseUrl}api/v4/projects/${encodeURIComponent(repository)}`;
+
+  const { body: project } = await http.getJson(url, Repository);
+  const result: SourceActivity = { archived: project.archived };
+
+  if (project.default_branch) {
+    const { body: commits } = await http.getJson(
+      `${url}/repository/commits?ref_name=${encodeURIComponent(project.default_branch)}&per_page=1`,
+      GitlabCommits,
+    );
+    result.lastCommitTimestamp = commits[0]?.committed_date;
+  }
+
+  const { body: issues } = await http.getJson(
+    `${url}/issues?state=opened&order_by=updated_at&sort=desc&per_page=1`,
+    Issues,
+  );
+  result.lastIssueActivityTimestamp = issues[0]?.updated_at;
+
+  return result;
+}
+
+async function getGiteaActivity(
+  http: GiteaHttp | ForgejoHttp,
+  baseUrl: string,
+  repository: string,
+): Promise<SourceActivity> {
+  const url = `${baseUrl}api/v1/repos/${repository}`;
+
+  const { body: repo } = await http.getJson(url, Repository);
+  const result: SourceActivity = { archived: repo.archived };
+
+  if (repo.default_branch) {
+    const { body: commits } = await http.getJson(
+      `${url}/commits?sha=${encodeURIComponent(repo.default_branch)}&limit=1&stat=false&verification=false&files=false`,
+      Commits,
+    );
+    result.lastCommitTimestamp = commits[0]?.commit.committer.date;
+  }
+
+  // the issues API can't be sorted, so look at the most recent page
+  const { body: issues } = await http.getJson(
+    `${url}/issues?state=open&type=issues&limit=50`,
+    Issues,
+  );
+  result.lastIssueActivityTimestamp = getLatest(
+    issues.map(({ updated_at }) => updated_at),
+  );
+
+  return result;
+}
+
+async function fetchSourceActivity(
+  sourceUrl: string,
+): Promise<SourceActivity | null> {
+  const parsedUrl = parseUrl(sourceUrl);
+  if (!parsedUrl) {
+    return null;
+  }
+
+  const baseUrl = `${parsedUrl.protocol}//${parsedUrl.host}/`;
+  const path = trimSlashes(parsedUrl.pathname).replace(regEx(/\.git$/), '');
+  // GitLab supports nested groups, other platforms only `owner/repo`
+  const repository = path.split('/').slice(0, 2).join('/');
+
+  try {
+    switch (detectPlatform(sourceUrl)) {
+      case 'github':
+        return await getGithubActivity(baseUrl, repository);
+      case 'gitlab':
+        return await getGitlabActivity(baseUrl, path);
+      case 'gitea':
+        return await getGiteaActivity(new GiteaHttp(), baseUrl, repository);
+      case 'forgejo':
+        return await getGiteaActivity(new ForgejoHttp(), baseUrl, repository);
+      default:
+        logger.trace(
+          { sourceUrl },
+          'Source repository activity is not supported for this platform',
+        );
+        return null;
+    }
+  } catch (err) {
+    logger.debug(
+      { sourceUrl, err },
+      'Failed to fetch source repository activity',
+    );
+    return null;
+  }
+}
+
+/**
+ * Returns whether the source repository is archived, and when it last had
+ * commits on its default branch and activity on open issues.
+ */
+export function getSourceActivity(
+  sourceUrl: string,
+): Promise<SourceActivity | null> {
+  return withCache(
+    {
+      namespace: 'source-activity',
+      key: sourceUrl,
+      ttlMinutes: 24 * 60,
+    },
+    () => fetchSourceActivity(sourceUrl),
+  );
+}
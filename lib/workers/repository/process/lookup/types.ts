@@ -13,6 +13,7 @@ import type { Timestamp } from '../../../../util/timestamp.ts';
 export interface FilterConfig {
   allowedVersions?: string;
   depName?: string;
+  followBranch?: string;
   followTag?: string;
   ignoreDeprecated?: boolean;
   ignoreUnstable?: boolean;
@@ -58,6 +59,7 @@ export interface LookupUpdateConfig
   vulnerabilityFixVersion?: string;
   vulnerabilityFixStrategy?: string;
   abandonmentThreshold?: string;
+  abandonmentSourceActivity?: boolean;
 }
 
 export interface UpdateResult {
@@ -67,6 +69,7 @@ export interface UpdateResult {
   dependencyUrl?: string;
   homepage?: string;
   deprecationMessage?: string;
+  currentVersionDeprecationMessage?: string;
   sourceUrl?: string | null;
   currentVersion?: string;
   isSingleVersion?: boolean;
@@ -83,4 +86,6 @@ export interface UpdateResult {
   vulnerabilityFixStrategy?: string;
   mostRecentTimestamp?: Timestamp | null;
   isAbandoned?: boolean;
+  abandonmentReasons?: string[];
+  isUnsupported?: boolean;
 }
@@ -13,6 +13,8 @@ export interface Vulnerability {
   datasource: string;
   vulnerability: Osv.Vulnerability;
   affected: Osv.Affected;
+  /** `false` if static analysis found none of the affected symbols to be used */
+  reachable?: boolean;
 }
 
 export interface DependencyVulnerabilities {
@@ -1,10 +1,14 @@
 import type { Osv, OsvOffline } from '@renovatebot/osv-offline';
 import { codeBlock } from 'common-tags';
+import fs from 'fs-extra';
+import tmp from 'tmp-promise';
 import { mockFn } from 'vitest-mock-extended';
 import type { RenovateConfig } from '~test/util.ts';
 import { logger } from '~test/util.ts';
 import { getConfig } from '../../../config/defaults.ts';
+import { GlobalConfig } from '../../../config/global.ts';
 import type { PackageRuleInputConfig } from '../../../config/types.ts';
+import { isGoVulnerabilityReachable } from '../../../modules/manager/gomod/reachability.ts';
 import type { PackageFile } from '../../../modules/manager/types.ts';
 import { applyPackageRules } from '../../../util/package-rules/index.ts';
 import { Vulnerabilities } from './vulnerabilities.ts';
@@ -13,6 +17,8 @@ const getVulnerabilitiesMock =
   mockFn<typeof OsvOffline.prototype.getVulnerabilities>();
 const createMock = vi.fn();
 
+vi.mock('../../../modules/manager/gomod/reachability.ts');
+
 vi.mock('@renovatebot/osv-offline', () => {
   return {
     __esModule: true,
@@ -54,11 +60,22 @@ describe('workers/repository/process/vulnerabilities', () => {
         getVulnerabilities: getVulnerabilitiesMock,
       });
       vulnerabilities = await Vulnerabilities.create();
+      localDir = await tmp.dir({ unsafeCleanup: true });
     });
 
     beforeEach(() => {
       config = getConfig();
       config.packageRules = [];
+      GlobalConfig.set({ localDir: localDir.path });
+    });
+
+    afterEach(async () => {
+      GlobalConfig.reset();
+      await fs.emptyDir(localDir.path);
+    });
+
+    afterAll(async () => {
+      await localDir.cleanup();
     });
 
     it('return list of Vulnerabilities', async () => {
@@ -127,6 +144,77 @@ describe('workers/repository/process/vulnerabilities', () => {
       ]);
     });
 
+    it('merges advisories from osvAdvisorySources', async () => {
+      const advisoryDir = await tmp.dir({ unsafeCleanup: true });
+      const affected: Osv.Affected[] = [
+        {
+          package: { name: '@example/lib', ecosystem: 'npm' },
+          ranges: [
+            {
+              type: 'SEMVER',
+              events: [{ introduced: '0' }, { fixed: '1.2.0' }],
+            },
+          ],
+        },
+      ];
+      await fs.outputJson(`${advisoryDir.path}/INTERNAL-1.json`, {
+        id: 'INTERNAL-1',
+        modified: '',
+        aliases: ['CVE-2025-0001'],
+        affected,
+      });
+      await fs.outputJson(`${advisoryDir.path}/INTERNAL-2.json`, {
+        id: 'INTERNAL-2',
+        modified: '',
+        affected,
+      });
+      GlobalConfig.set({ osvAdvisorySources: [advisoryDir.path] });
+      resetOsv();
+      createMock.mockResolvedValue({
+        getVulnerabilities: getVulnerabilitiesMock,
+      });
+      const withAdvisories = await Vulnerabilities.create();
+      const packageFiles: Record<string, PackageFile[]> = {
+        npm: [
+          {
+            deps: [
+              {
+                depName: '@example/lib',
+                currentValue: '1.0.0',
+                datasource: 'npm',
+              },
+            ],
+            packageFile: 'package.json',
+          },
+        ],
+      };
+      getVulnerabilitiesMock.mockResolvedValueOnce([
+        {
+          id: 'GHSA-xxxx-xxxx-xxxx',
+          modified: '',
+          aliases: ['CVE-2025-0001'],
+          affected,
+        },
+      ]);
+
+      const vulnerabilityList = await withAdvisories.fetchVulnerabilities(
+        config,
+        packageFiles,
+      );
+
+      expect(vulnerabilityList).toMatchObject([
+        {
+          vulnerability: { id: 'GHSA-xxxx-xxxx-xxxx' },
+          fixedVersion: '>= 1.2.0',
+        },
+        { vulnerability: { id: 'INTERNAL-2' }, fixedVersion: '>= 1.2.0' },
+      ]);
+
+      GlobalConfig.reset();
+      resetOsv();
+      await advisoryDir.cleanup();
+    });
+
     describe('malicious packages', () => {
       it('are marked for dependencies with a MAL- advisory ID against their current version with malicious-version-in-use', async () => {
         const packageFiles: Record<string, PackageFile[]> = {
@@ -817,6 +905,7 @@ describe('workers/repository/process/vulnerabilities', () => {
   describe('appendVulnerabilityPackageRules()', () => {
     let config: RenovateConfig;
     let vulnerabilities: Vulnerabilities;
+    let localDir: tmp.DirectoryResult;
     const lodashVulnerability: Osv.Vulnerability = {
       id: 'GHSA-x5rq-j2xg-h7qm',
       modified: '',
@@ -845,11 +934,22 @@ describe('workers/repository/process/vulnerabilities', () => {
         getVulnerabilities: getVulnerabilitiesMock,
       });
       vulnerabilities = await Vulnerabilities.create();
+      localDir = await tmp.dir({ unsafeCleanup: true });
     });
 
     beforeEach(() => {
       config = getConfig();
       config.packageRules = [];
+      GlobalConfig.set({ localDir: localDir.path });
+    });
+
+    afterEach(async () => {
+      GlobalConfig.reset();
+      await fs.emptyDir(localDir.path);
+    });
+
+    afterAll(async () => {
+      await localDir.cleanup();
     });
 
     it('unsupported datasource', async () => {
@@ -2246,6 +2346,251 @@ describe('workers/repository/process/vulnerabilities', () => {
       ]);
     });
 
+    describe('go.sum remediation', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        gomod: [
+          {
+            deps: [
+              {
+                depName: 'github.com/pkg/errors',
+                currentValue: 'v0.9.1',
+                datasource: 'go',
+                depType: 'require',
+              },
+            ],
+            packageFile: 'go.mod',
+          },
+        ],
+      };
+
+      const netAdvisory: Osv.Vulnerability = {
+        id: 'GO-2024-3333',
+        modified: '',
+        aliases: ['CVE-2024-45338'],
+        affected: [
+          {
+            package: { name: 'golang.org/x/net', ecosystem: 'Go' },
+            ranges: [
+              {
+                type: 'SEMVER',
+                events: [{ introduced: '0' }, { fixed: '0.33.0' }],
+              },
+            ],
+          },
+        ],
+      };
+
+      beforeEach(async () => {
+        config.osvVulnerabilityAlerts = true;
+        delete packageFiles.gomod[0].lockFiles;
+        await fs.outputFile(
+          `${localDir.path}/go.sum`,
+          codeBlock`
+            github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+            github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+            golang.org/x/net v0.25.0 h1:d/OCCoBEUq33pjydKrGQhw7IlUPI2Oylr+8qLx49kac=
+            golang.org/x/net v0.25.0/go.mod h1:JkAGAh7GEvH74S6FOH42FLoXpXbE/aqXSrIQjXgsiwM=
+            golang.org/x/text v0.15.0 h1:h1V/4gjBv8v9cjcR6+AR5+/cIYK5N/WAgiv4xlsEtAk=
+          `,
+        );
+        getVulnerabilitiesMock.mockImplementation((_ecosystem, name) =>
+          Promise.resolve(name === 'golang.org/x/net' ? [netAdvisory] : []),
+        );
+      });
+
+      it('requires the fixed version of modules which are only in go.sum', async () => {
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          packageFiles,
+        );
+        // lookups run twice, before and after fetching updates
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          packageFiles,
+        );
+
+        expect(config.packageRules).toBeEmptyArray();
+        expect(config.remediations).toEqual({
+          'go.sum': [
+            {
+              datasource: 'go',
+              depName: 'golang.org/x/net',
+              packageName: 'golang.org/x/net',
+              depType: 'indirect',
+              currentVersion: 'v0.25.0',
+              newVersion: 'v0.33.0',
+              prBodyNotes: [expect.stringContaining('GO-2024-3333')],
+            },
+          ],
+        });
+        expect(packageFiles.gomod[0].lockFiles).toEqual(['go.sum']);
+      });
+
+      it('skips go.sum without osvVulnerabilityAlerts', async () => {
+        config.osvVulnerabilityAlerts = false;
+
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          packageFiles,
+        );
+
+        expect(config.remediations).toBeUndefined();
+        expect(getVulnerabilitiesMock).not.toHaveBeenCalledWith(
+          'Go',
+          'golang.org/x/net',
+        );
+      });
+
+      it('looks up modules shared by several go.sum files once', async () => {
+        await fs.outputFile(
+          `${localDir.path}/tools/go.sum`,
+          codeBlock`
+            golang.org/x/net v0.25.0 h1:d/OCCoBEUq33pjydKrGQhw7IlUPI2Oylr+8qLx49kac=
+          `,
+        );
+        const twoPackageFiles: Record<string, PackageFile[]> = {
+          gomod: [
+            { deps: [], packageFile: 'go.mod' },
+            { deps: [], packageFile: 'tools/go.mod' },
+          ],
+        };
+
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          twoPackageFiles,
+        );
+
+        expect(Object.keys(config.remediations!)).toEqual([
+          'go.sum',
+          'tools/go.sum',
+        ]);
+        expect(
+          getVulnerabilitiesMock.mock.calls.filter(
+            ([, name]) => name === 'golang.org/x/net',
+          ),
+        ).toHaveLength(1);
+      });
+
+      it('skips modules without a fixed version', async () => {
+        getVulnerabilitiesMock.mockResolvedValue([
+          {
+            ...netAdvisory,
+            affected: [
+              {
+                package: { name: 'golang.org/x/net', ecosystem: 'Go' },
+                ranges: [{ type: 'SEMVER', events: [{ introduced: '0' }] }],
+              },
+            ],
+          },
+        ]);
+
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          packageFiles,
+        );
+
+        expect(config.remediations).toEqual({});
+        expect(packageFiles.gomod[0].lockFiles).toBeUndefined();
+      });
+    });
+
+    describe('reachability', () => {
+      const packageFiles: Record<string, PackageFile[]> = {
+        gomod: [
+          {
+            deps: [
+              {
+                depName: 'gopkg.in/yaml.v2',
+                depType: 'require',
+                currentValue: '2.2.7',
+                datasource: 'go',
+              },
+              {
+                depName: 'github.com/pkg/errors',
+                depType: 'indirect',
+                currentValue: '0.8.0',
+                datasource: 'go',
+              },
+            ],
+            packageFile: 'go.mod',
+          },
+        ],
+      };
+
+      function goAdvisory(id: string, name: string): Osv.Vulnerability {
+        return {
+          id,
+          modified: '',
+          affected: [
+            {
+              package: { name, ecosystem: 'Go' },
+              ranges: [
+                {
+                  type: 'SEMVER',
+                  events: [{ introduced: '0' }, { fixed: '3.0.0' }],
+                },
+              ],
+              ecosystem_specific: {
+                imports: [{ path: name, symbols: ['Unmarshal'] }],
+              },
+            },
+          ],
+        };
+      }
+
+      beforeEach(() => {
+        config.osvVulnerabilityReachability = true;
+        getVulnerabilitiesMock.mockImplementation((_ecosystem, name) =>
+          Promise.resolve([goAdvisory(`GO-${name}`, name)]),
+        );
+      });
+
+      it('skips unreachable vulnerabilities of direct dependencies', async () => {
+        vi.mocked(isGoVulnerabilityReachable).mockResolvedValue(false);
+
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          packageFiles,
+        );
+
+        expect(isGoVulnerabilityReachable).toHaveBeenCalledExactlyOnceWith(
+          'go.mod',
+          expect.objectContaining({
+            package: { name: 'gopkg.in/yaml.v2', ecosystem: 'Go' },
+          }),
+        );
+        expect(config.packageRules).toMatchObject([
+          { matchPackageNames: ['github.com/pkg/errors'] },
+        ]);
+      });
+
+      it('marks vulnerabilities as unreachable', async () => {
+        vi.mocked(isGoVulnerabilityReachable).mockResolvedValue(false);
+
+        const vulnerabilityList = await vulnerabilities.fetchVulnerabilities(
+          config,
+          packageFiles,
+        );
+
+        expect(vulnerabilityList).toMatchObject([
+          { packageName: 'gopkg.in/yaml.v2', reachable: false },
+          { packageName: 'github.com/pkg/errors' },
+        ]);
+        expect(vulnerabilityList[1]).not.toHaveProperty('reachable');
+      });
+
+      it('keeps reachable vulnerabilities', async () => {
+        vi.mocked(isGoVulnerabilityReachable).mockResolvedValue(true);
+
+        await vulnerabilities.appendVulnerabilityPackageRules(
+          config,
+          packageFiles,
+        );
+
+        expect(config.packageRules).toHaveLength(2);
+      });
+    });
+
     it('handles invalid CVSS scores gracefully', async () => {
       const packageFiles: Record<string, PackageFile[]> = {
         poetry: [
@@ -2600,4 +2945,6 @@ describe('workers/repository/process/vulnerabilities', () => {
 function resetOsv() {
   // @ts-expect-error - reset the cached OSV client to avoid state leak between tests
   Vulnerabilities.osvOffline = undefined;
+  // @ts-expect-error - reset the cached advisory sources as well
+  Vulnerabilities.advisorySources = undefined;
 }
@@ -10,21 +10,30 @@ import {
 import type { CvssVector } from 'ae-cvss-calculator';
 import * as _aeCvss from 'ae-cvss-calculator';
 import { z } from 'zod/v4';
+import { GlobalConfig } from '../../../config/global.ts';
 import { getManagerConfig, mergeChildConfig } from '../../../config/index.ts';
 import type { PackageRule, RenovateConfig } from '../../../config/types.ts';
 import { instrument } from '../../../instrumentation/index.ts';
 import { logger } from '../../../logger/index.ts';
 import { getDefaultVersioning } from '../../../modules/datasource/common.ts';
+import { GoDatasource } from '../../../modules/datasource/go/index.ts';
+import { isGoVulnerabilityReachable } from '../../../modules/manager/gomod/reachability.ts';
+import { parseGoSum } from '../../../modules/manager/gomod/update-locked.ts';
 import type {
   PackageDependency,
   PackageFile,
 } from '../../../modules/manager/types.ts';
 import type { VersioningApi } from '../../../modules/versioning/index.ts';
 import { get as getVersioning } from '../../../modules/versioning/index.ts';
+import { readLocalFile } from '../../../util/fs/index.ts';
 import { sanitizeMarkdown } from '../../../util/markdown.ts';
 import * as p from '../../../util/promises.ts';
 import { regEx } from '../../../util/regex.ts';
 import { titleCase } from '../../../util/string.ts';
+import {
+  OsvAdvisorySources,
+  mergeVulnerabilities,
+} from '../../../util/vulnerability/advisory-sources.ts';
 import { datasourceToOsvEcosystem } from '../../../util/vulnerability/ecosystem.ts';
 import {
   getFixedVersionConstraint,
@@ -36,18 +45,31 @@ import type {
   Vulnerability,
 } from './types.ts';
 
+interface GoFix {
+  newVersion: string;
+  prBodyNotes: string[];
+}
+
 const { fromVector } = (_aeCvss as unknown as { default: typeof _aeCvss })
   .default;
 
 export class Vulnerabilities {
   private static osvOffline: Promise<OsvOffline> | undefined;
 
+  private static advisorySources: Promise<OsvAdvisorySources> | undefined;
+
   private osvOffline: OsvOffline;
 
+  private advisorySources: OsvAdvisorySources;
+
   private static readonly datasourceEcosystemMap = datasourceToOsvEcosystem;
 
-  private constructor(osvOffline: OsvOffline) {
+  private constructor(
+    osvOffline: OsvOffline,
+    advisorySources: OsvAdvisorySources,
+  ) {
     this.osvOffline = osvOffline;
+    this.advisorySources = advisorySources;
   }
 
   private static initialize(): Promise<OsvOffline> {
@@ -56,11 +78,19 @@ export class Vulnerabilities {
     return Vulnerabilities.osvOffline;
   }
 
+  private static initializeAdvisorySources(): Promise<OsvAdvisorySources> {
+    Vulnerabilities.advisorySources ??= OsvAdvisorySources.create(
+      GlobalConfig.get('osvAdvisorySources'),
+    );
+    return Vulnerabilities.advisorySources;
+  }
+
   static async create(): Promise<Vulnerabilities> {
     // intialize osv only once
     const osvOffline = await Vulnerabilities.initialize();
+    const advisorySources = await Vulnerabilities.initializeAdvisorySources();
 
-    const instance = new Vulnerabilities(osvOffline);
+    const instance = new Vulnerabilities(osvOffline, advisorySources);
     return instance;
   }
 
@@ -90,6 +120,152 @@ export class Vulnerabilities {
 
       config.packageRules.push(...groupPackageRules);
     }
+
+    if (config.osvVulnerabilityAlerts && packageFiles.gomod) {
+      await this.appendGoRemediations(config, packageFiles.gomod);
+    }
+  }
+
+  /**
+   * Modules which are only in go.sum have no dependency to update, so they are remediated by requiring their fixed version.
+   */
+  private async appendGoRemediations(
+    config: RenovateConfig,
+    packageFiles: PackageFile[],
+  ): Promise<void> {
+    const versioningApi = getVersioning(getDefaultVersioning(GoDatasource.id));
+    config.remediations ??= {};
+    const remediations = config.remediations as Record<
+      string,
+      Record<string, unknown>[]
+    >;
+
+    const candidates: {
+      pFile: PackageFile;
+      lockFile: string;
+      module: string;
+      version: string;
+    }[] = [];
+    for (const pFile of packageFiles) {
+      const lockFile = pFile.packageFile.replace(regEx(/\.mod$/), '.sum');
+      if (lockFile === pFile.packageFile) {
+        continue;
+      }
+      const content = await readLocalFile(lockFile, 'utf8');
+      if (!content) {
+        continue;
+      }
+      const required = new Set(
+        pFile.deps.map((dep) => dep.packageName ?? dep.depName),
+      );
+
+      for (const [module, version] of parseGoSum(content)) {
+        if (
+          required.has(module) ||
+          remediations[lockFile]?.some(({ depName }) => depName === module)
+        ) {
+          continue;
+        }
+        candidates.push({ pFile, lockFile, module, version });
+      }
+    }
+
+    // look up each module version once, however many go.sum files contain it
+    const lookups = new Map<string, { module: string; version: string }>();
+    for (const { module, version } of candidates) {
+      lookups.set(`${module}@${version}`, { module, version });
+    }
+    const fixes = new Map<string, GoFix | null>();
+    await p.map(lookups, async ([key, { module, version }]) => {
+      fixes.set(key, await this.getGoFix(module, version, versioningApi));
+    });
+
+    for (const { pFile, lockFile, module, version } of candidates) {
+      const fix = fixes.get(`${module}@${version}`);
+      if (!fix) {
+        continue;
+      }
+      logger.debug(
+        `Requiring ${module}@${fix.newVersion} to fix vulnerabilities in ${lockFile}`,
+      );
+      remediations[lockFile] ??= [];
+      remediations[lockFile].push({
+        datasource: GoDatasource.id,
+        depName: module,
+        packageName: module,
+        depType: 'indirect',
+        currentVersion: version,
+        newVersion: fix.newVersion,
+        prBodyNotes: fix.prBodyNotes,
+      });
+      pFile.lockFiles ??= [];
+      if (!pFile.lockFiles.includes(lockFile)) {
+        pFile.lockFiles.push(lockFile);
+      }
+    }
+  }
+
+  private async getGoFix(
+    module: string,
+    version: string,
+    versioningApi: VersioningApi,
+  ): Promise<GoFix | null> {
+    let newVersion: string | undefined;
+    const prBodyNotes: string[] = [];
+    try {
+      for (const vulnerability of await this.getVulnerabilities('Go', module)) {
+        if (vulnerability.withdrawn) {
+          continue;
+        }
+        for (const affected of vulnerability.affected ?? []) {
+          if (
+            !this.isPackageVulnerable(
+              'Go',
+              module,
+              version,
+              affected,
+              versioningApi,
+            )
+          ) {
+            continue;
+          }
+          const fixedVersion = this.getRawFixedVersion(
+            version,
+            affected,
+            versioningApi,
+          );
+          if (!fixedVersion) {
+            logger.debug(
+              `No fixed version available for vulnerability ${vulnerability.id} in ${module} ${version}`,
+            );
+            continue;
+          }
+          if (
+            !newVersion ||
+            this.isVersionGt(fixedVersion, newVersion, versioningApi)
+          ) {
+            newVersion = fixedVersion;
+          }
+          prBodyNotes.push(
+            ...this.generatePrBodyNotes(vulnerability, affected),
+          );
+        }
+      }
+    } catch (err) {
+      logger.warn(
+        { err, packageName: module },
+        'Error fetching vulnerability information for package',
+      );
+      return null;
+    }
+
+    if (!newVersion) {
+      return null;
+    }
+    return {
+      newVersion: newVersion.startsWith('v') ? newVersion : `v${newVersion}`,
+      prBodyNotes,
+    };
   }
 
   async fetchVulnerabilities(
@@ -188,7 +364,7 @@ export class Vulnerabilities {
     try {
       const osvVulnerabilities = await instrument(
         'get OSV vulnerabilities',
-        () => this.osvOffline.getVulnerabilities(ecosystem, osvPackageName),
+        () => this.getVulnerabilities(ecosystem, osvPackageName),
         {
           attributes: {
             osvPackageName,
@@ -261,6 +437,18 @@ export class Vulnerabilities {
             versioningApi,
           );
 
+          const reachable = await this.isReachable(
+            ecosystem,
+            packageFileConfig,
+            dep,
+            affected,
+          );
+          if (reachable === false) {
+            logger.debug(
+              `Vulnerability ${osvVulnerability.id} in ${packageName} ${depVersion} is not reachable`,
+            );
+          }
+
           vulnerabilities.push({
             packageName,
             osvPackageName,
@@ -271,6 +459,7 @@ export class Vulnerabilities {
             fixedVersion,
             datasource: dep.datasource!,
             packageFileConfig,
+            ...(reachable === null ? {} : { reachable }),
           });
         }
       }
@@ -285,6 +474,41 @@ export class Vulnerabilities {
     }
   }
 
+  private async isReachable(
+    ecosystem: Ecosystem,
+    packageFileConfig: RenovateConfig & PackageFile,
+    dep: PackageDependency,
+    affected: Osv.Affected,
+  ): Promise<boolean | null> {
+    // indirect dependencies and the standard library are used through other modules too
+    if (
+      ecosystem !== 'Go' ||
+      packageFileConfig.manager !== 'gomod' ||
+      dep.depType !== 'require' ||
+      !packageFileConfig.osvVulnerabilityReachability
+    ) {
+      return null;
+    }
+    return await isGoVulnerabilityReachable(
+      packageFileConfig.packageFile,
+      affected,
+    );
+  }
+
+  private async getVulnerabilities(
+    ecosystem: Ecosystem,
+    osvPackageName: string,
+  ): Promise<Osv.Vulnerability[]> {
+    const [osvVulnerabilities, advisoryVulnerabilities] = await Promise.all([
+      this.osvOffline.getVulnerabilities(ecosystem, osvPackageName),
+      this.advisorySources.getVulnerabilities(ecosystem, osvPackageName),
+    ]);
+    return mergeVulnerabilities(
+      osvVulnerabilities ?? [],
+      advisoryVulnerabilities,
+    );
+  }
+
   private skipMaliciousPackages(
     ecosystem: Ecosystem,
     osvPackageName: string,
@@ -529,6 +753,24 @@ export class Vulnerabilities {
     return null;
   }
 
+  private getRawFixedVersion(
+    depVersion: string,
+    affected: Osv.Affected,
+    versioningApi: VersioningApi,
+  ): string | undefined {
+    const fixedVersions = (affected.ranges ?? [])
+      .filter(({ type }) => type !== 'GIT')
+      .flatMap(({ events }) => events)
+      .map(({ fixed }) => fixed)
+      .filter(
+        (fixed): fixed is string =>
+          isNonEmptyString(fixed) &&
+          this.isVersionGt(fixed, depVersion, versioningApi),
+      );
+    fixedVersions.sort((a, b) => versioningApi.sortVersions(a, b));
+    return fixedVersions[0];
+  }
+
   private getFixedVersionByEcosystem(
     fixedVersion: string,
     ecosystem: Ecosystem,
@@ -578,7 +820,15 @@ export class Vulnerabilities {
       fixedVersion,
       datasource,
       packageFileConfig,
+      reachable,
     } = vul;
+    if (reachable === false) {
+      logger.debug(
+        `Not raising the priority of unreachable vulnerability ${vulnerability.id} in ${packageName} ${depVersion}`,
+      );
+      return null;
+    }
+
     if (isNullOrUndefined(fixedVersion)) {
       logger.debug(
         `No fixed version available for vulnerability ${vulnerability.id} in ${packageName} ${depVersion}`,
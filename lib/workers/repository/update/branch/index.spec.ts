@@ -395,6 +395,33 @@ describe('workers/repository/update/branch/index', () => {
       expect(reuse.shouldReuseExistingBranch).toHaveBeenCalledTimes(0);
     });
 
+    it('disables automerge on the local platform', async () => {
+      GlobalConfig.set({ ...adminConfig, platform: 'local' });
+      getUpdated.getUpdatedPackageFiles.mockResolvedValueOnce(
+        partial<PackageFilesResult>({
+          updatedPackageFiles: [partial<FileChange>()],
+        }),
+      );
+      npmPostExtract.getAdditionalFiles.mockResolvedValueOnce({
+        artifactErrors: [],
+        updatedArtifacts: [partial<FileChange>()],
+      });
+      scm.branchExists.mockResolvedValue(true);
+      commit.commitFilesToBranch.mockResolvedValueOnce(null);
+      automerge.tryBranchAutomerge.mockResolvedValueOnce('no automerge');
+      config.automerge = true;
+      config.automergeType = 'branch';
+
+      await branchWorker.processBranch(config);
+
+      expect(logger.once.warn).toHaveBeenCalledWith(
+        'Automerge is not supported on the local platform',
+      );
+      expect(automerge.tryBranchAutomerge).toHaveBeenCalledWith(
+        expect.objectContaining({ automerge: false }),
+      );
+    });
+
     it('skips branch if closed minor PR found', async () => {
       schedule.isScheduledNow.mockReturnValueOnce(false);
       scm.branchExists.mockResolvedValue(true);
@@ -181,6 +181,11 @@ export async function processBranch(
     config.rebaseRequested = await rebaseCheck(config, branchPr);
     logger.debug(`PR rebase requested=${config.rebaseRequested}`);
   }
+  // the local platform can't merge PRs, and pushing to the checked out branch of the working copy would fail
+  if (config.automerge && GlobalConfig.get('platform') === 'local') {
+    logger.once.warn('Automerge is not supported on the local platform');
+    config.automerge = false;
+  }
   const keepUpdatedLabel = config.keepUpdatedLabel;
   const artifactErrorTopic = emojify(':warning: Artifact update problem');
   const artifactNoticeTopic = emojify(
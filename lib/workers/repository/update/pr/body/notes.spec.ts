@@ -62,4 +62,25 @@ describe('workers/repository/update/pr/body/notes', () => {
       "Add the preset `:preserveSemverRanges` to your config if you don't want to pin your dependencies.",
     );
   });
+
+  it('renders deprecation notes for the current version', () => {
+    const res = getPrExtraNotes({
+      manager: 'gomod',
+      branchName: 'branch',
+      baseBranch: 'base',
+      upgrades: [
+        {
+          manager: 'gomod',
+          branchName: 'branch',
+          depName: 'example.com/foo',
+          currentVersion: 'v1.2.0',
+          currentVersionDeprecationMessage:
+            'Retracted by the module author: data corruption',
+        },
+      ],
+    });
+    expect(res).toContain(
+      'The current version `v1.2.0` of `example.com/foo` is deprecated: Retracted by the module author: data corruption',
+    );
+  });
 });
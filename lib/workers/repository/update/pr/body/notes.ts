@@ -33,6 +33,14 @@ export function getPrExtraNotes(config: BranchConfig): string {
     );
   }
 
+  for (const upgrade of config.upgrades) {
+    if (upgrade.currentVersionDeprecationMessage) {
+      res += emojify(
+        `:warning: The current version \`${upgrade.currentVersion}\` of \`${upgrade.depName}\` is deprecated: ${upgrade.currentVersionDeprecationMessage}\n\n`,
+      );
+    }
+  }
+
   if (config.updateType === 'lockFileMaintenance') {
     res += emojify(
       ':wrench: This Pull Request updates lock files to use the latest dependency versions.\n\n',
@@ -16,6 +16,20 @@ describe('workers/repository/updates/branch-name', () => {
       expect(upgrade.branchName).toBe('some-variable-name-grouptopic');
     });
 
+    it('falls back to sourceGroupName if no groupName', () => {
+      const upgrade = partial<BranchUpgradeConfig>({
+        sourceGroupName: 'go.opentelemetry.io/otel Go modules',
+        group: {
+          branchName: '{{groupSlug}}-{{branchTopic}}',
+          branchTopic: 'grouptopic',
+        },
+      });
+      generateBranchName(upgrade);
+      expect(upgrade.branchName).toBe(
+        'go.opentelemetry.io-otel-go-modules-grouptopic',
+      );
+    });
+
     it('ignores grouping of replacement update', () => {
       const upgrade = partial<BranchUpgradeConfig>({
         groupName: 'grouptopic',
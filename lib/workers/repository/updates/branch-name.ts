@@ -65,6 +65,14 @@ export function generateBranchName(update: BranchUpgradeConfig): void {
     );
     update.groupName = update.sharedVariableName;
   }
+  if (!update.groupName && update.sourceGroupName) {
+    logger.debug(
+      `Using sourceGroupName=${update.sourceGroupName} as groupName for depName=${update.depName}`,
+    );
+    update.groupName = update.sourceGroupName;
+    // slugify drops the slashes of module paths
+    update.groupSlug ??= update.sourceGroupName.replace(regEx(/\//g), '-');
+  }
 
   if (update.groupName) {
     if (update.updateType === 'replacement') {
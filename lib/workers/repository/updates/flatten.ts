@@ -17,6 +17,7 @@ import { parseUrl } from '../../../util/url.ts';
 import type { BranchUpgradeConfig } from '../../types.ts';
 import { replacementAlreadyExists } from '../common.ts';
 import { generateBranchName } from './branch-name.ts';
+import { applyGoModuleGroups } from './go-module-groups.ts';
 
 function upper(str: string): string {
   return str.charAt(0).toUpperCase() + str.substring(1);
@@ -78,6 +79,7 @@ export async function flattenUpdates(
   config: RenovateConfig,
   packageFiles: Record<string, PackageFile[]>,
 ): Promise<BranchUpgradeConfig[]> {
+  applyGoModuleGroups(config, packageFiles);
   const updates = [];
   const updateTypes = [
     'major',
@@ -0,0 +1,149 @@
+import type { RenovateConfig } from '~test/util.ts';
+import { getConfig } from '../../../config/defaults.ts';
+import type { PackageFile } from '../../../modules/manager/types.ts';
+import {
+  applyGoModuleGroups,
+  getGoModuleGroupName,
+} from './go-module-groups.ts';
+
+let config: RenovateConfig;
+
+beforeEach(() => {
+  config = { ...getConfig(), goModuleGrouping: true };
+});
+
+function getPackageFiles(): Record<string, PackageFile[]> {
+  return {
+    gomod: [
+      {
+        packageFile: 'go.mod',
+        deps: [
+          {
+            depName: 'go.opentelemetry.io/otel',
+            datasource: 'go',
+            sourceUrl: 'https://github.com/open-telemetry/opentelemetry-go',
+            updates: [{ newVersion: 'v1.28.0', updateType: 'minor' }],
+          },
+          {
+            depName: 'go.opentelemetry.io/otel/sdk',
+            datasource: 'go',
+            sourceUrl: 'https://github.com/open-telemetry/opentelemetry-go',
+            updates: [
+              { newVersion: 'v1.28.0', updateType: 'minor' },
+              { newVersion: 'v2.0.0', updateType: 'major' },
+            ],
+          },
+          {
+            depName: 'go.opentelemetry.io/otel/exporters/prometheus',
+            datasource: 'go',
+            sourceUrl: 'https://github.com/open-telemetry/opentelemetry-go',
+            updates: [{ newVersion: 'v0.50.0', updateType: 'minor' }],
+          },
+          {
+            depName: 'github.com/aws/aws-sdk-go-v2/service/s3',
+            datasource: 'go',
+            sourceUrl: 'https://github.com/aws/aws-sdk-go-v2',
+            updates: [{ newVersion: 'v1.58.0', updateType: 'minor' }],
+          },
+          {
+            depName: 'github.com/aws/aws-sdk-go-v2/config',
+            datasource: 'go',
+            sourceUrl: 'https://github.com/aws/aws-sdk-go-v2',
+            updates: [{ newVersion: 'v1.27.0', updateType: 'minor' }],
+          },
+          {
+            depName: 'go',
+            datasource: 'golang-version',
+            sourceUrl: 'https://github.com/golang/go',
+            updates: [{ newVersion: '1.23.0', updateType: 'minor' }],
+          },
+        ],
+      },
+    ],
+  };
+}
+
+describe('workers/repository/updates/go-module-groups', () => {
+  describe('getGoModuleGroupName()', () => {
+    it.each`
+      modules                                                                       | expected
+      ${['go.opentelemetry.io/otel', 'go.opentelemetry.io/otel/sdk']}               | ${'go.opentelemetry.io/otel Go modules'}
+      ${['github.com/org/repo/a/v2', 'github.com/org/repo/b']}                      | ${'github.com/org/repo Go modules'}
+      ${['example.com/a', 'example.com/b']}                                         | ${'github.com/org/repo Go modules'}
+      ${['cloud.google.com/go/storage', 'cloud.google.com/go/storage/internal/v3']} | ${'cloud.google.com/go/storage Go modules'}
+    `('getGoModuleGroupName($modules)', ({ modules, expected }) => {
+      expect(getGoModuleGroupName('https://github.com/org/repo', modules)).toBe(
+        expected,
+      );
+    });
+  });
+
+  describe('applyGoModuleGroups()', () => {
+    it('groups modules of the same repository released together', () => {
+      const packageFiles = getPackageFiles();
+
+      applyGoModuleGroups(config, packageFiles);
+
+      const groups = packageFiles.gomod[0].deps.map((dep) =>
+        dep.updates!.map((update) => update.sourceGroupName),
+      );
+      expect(groups).toEqual([
+        ['go.opentelemetry.io/otel Go modules'],
+        ['go.opentelemetry.io/otel Go modules', undefined],
+        [undefined],
+        [undefined],
+        [undefined],
+        [undefined],
+      ]);
+    });
+
+    it('groups modules across package files', () => {
+      const packageFiles = getPackageFiles();
+      packageFiles.gomod.push({
+        packageFile: 'tools/go.mod',
+        deps: [
+          {
+            depName: 'github.com/aws/aws-sdk-go-v2/service/sqs',
+            datasource: 'go',
+            sourceUrl: 'https://github.com/aws/aws-sdk-go-v2',
+            updates: [{ newVersion: 'v1.27.0', updateType: 'minor' }],
+          },
+        ],
+      });
+
+      applyGoModuleGroups(config, packageFiles);
+
+      expect(packageFiles.gomod[0].deps[4].updates![0].sourceGroupName).toBe(
+        'github.com/aws/aws-sdk-go-v2 Go modules',
+      );
+      expect(packageFiles.gomod[1].deps[0].updates![0].sourceGroupName).toBe(
+        'github.com/aws/aws-sdk-go-v2 Go modules',
+      );
+    });
+
+    it('is disabled by default', () => {
+      config = getConfig();
+      const packageFiles = getPackageFiles();
+
+      applyGoModuleGroups(config, packageFiles);
+
+      expect(
+        packageFiles.gomod[0].deps.flatMap((dep) =>
+          dep.updates!.map((update) => update.sourceGroupName),
+        ),
+      ).toEqual(Array(7).fill(undefined));
+    });
+
+    it('ignores managers other than gomod', () => {
+      const packageFiles = { regex: getPackageFiles().gomod };
+
+      applyGoModuleGroups(config, packageFiles);
+
+      expect(
+        packageFiles.regex[0].deps.flatMap((dep) =>
+          dep.updates!.map((update) => update.sourceGroupName),
+        ),
+      ).toEqual(Array(7).fill(undefined));
+    });
+  });
+});
@@ -0,0 +1,112 @@
+import { getManagerConfig } from '../../../config/index.ts';
+import { getOptions } from '../../../config/options/index.ts';
+import type { RenovateConfig } from '../../../config/types.ts';
+import { logger } from '../../../logger/index.ts';
+import { GoDatasource } from '../../../modules/datasource/go/index.ts';
+import type {
+  LookupUpdate,
+  PackageFile,
+} from '../../../modules/manager/types.ts';
+import { regEx } from '../../../util/regex.ts';
+
+const majorSuffixRegex = regEx(/\/v\d+$/);
+
+const supportedManagers =
+  getOptions().find(({ name }) => name === 'goModuleGrouping')
+    ?.supportedManagers ?? [];
+
+function getCommonModulePrefix(modules: string[]): string {
+  const [first, ...rest] = modules.map((module) =>
+    module.replace(majorSuffixRegex, '').split('/'),
+  );
+  let length = first.length;
+  for (const parts of rest) {
+    let i = 0;
+    while (i < length && parts[i] === first[i]) {
+      i += 1;
+    }
+    length = i;
+  }
+  return first.slice(0, length).join('/');
+}
+
+/**
+ * Returns the name of a group for the modules of a repository, preferring their common module path, such as `go.opentelemetry.io/otel`.
+ */
+export function getGoModuleGroupName(
+  sourceUrl: string,
+  modules: string[],
+): string {
+  const prefix = getCommonModulePrefix(modules);
+  const name = prefix.includes('/')
+    ? prefix
+    : sourceUrl.replace(regEx(/^https?:\/\//), '');
+  return `${name} Go modules`;
+}
+
+/**
+ * Marks the updates of Go modules which come from the same source repository and are updated to the same version, so that they're grouped into a single branch unless a `groupName` is configured.
+ * Only the managers which `goModuleGrouping` supports are considered.
+ *
+ * Multi-module repositories such as `go.opentelemetry.io/otel` release their modules in lockstep, and these modules usually need to be updated together.
+ */
+export function applyGoModuleGroups(
+  config: RenovateConfig,
+  packageFiles: Record<string, PackageFile[]>,
+): void {
+  const candidates = new Map<
+    string,
+    { sourceUrl: string; modules: Set<string>; updates: LookupUpdate[] }
+  >();
+
+  for (const [manager, files] of Object.entries(packageFiles)) {
+    if (
+      !supportedManagers.includes(manager) ||
+      !getManagerConfig(config, manager).goModuleGrouping
+    ) {
+      continue;
+    }
+    for (const packageFile of files) {
+      for (const dep of packageFile.deps) {
+        const module = dep.packageName ?? dep.depName;
+        if (dep.datasource !== GoDatasource.id || !dep.sourceUrl || !module) {
+          continue;
+        }
+        for (const update of dep.updates ?? []) {
+          // pseudo-versions are unique to each module, and major updates change the module path
+          if (
+            !update.newVersion ||
+            update.updateType === 'digest' ||
+            update.updateType === 'major' ||
+            update.updateType === 'replacement'
+          ) {
+            continue;
+          }
+          const key = `${dep.sourceUrl}@${update.newVersion}`;
+          const candidate = candidates.get(key) ?? {
+            sourceUrl: dep.sourceUrl,
+            modules: new Set<string>(),
+            updates: [],
+          };
+          candidate.modules.add(module);
+          candidate.updates.push(update);
+          candidates.set(key, candidate);
+        }
+      }
+    }
+  }
+
+  for (const { sourceUrl, modules, updates } of candidates.values()) {
+    if (modules.size < 2) {
+      continue;
+    }
+    const sourceGroupName = getGoModuleGroupName(sourceUrl, [...modules]);
+    logger.debug(
+      { sourceUrl, modules: [...modules] },
+      `Grouping Go modules as "${sourceGroupName}"`,
+    );
+    for (const update of updates) {
+      update.sourceGroupName = sourceGroupName;
+    }
+  }
+}